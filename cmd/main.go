@@ -3,7 +3,7 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,11 +12,21 @@ import (
 
 	_ "github.com/product-management/docs" // Import docs for Swagger
 	"github.com/product-management/internal/config"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/infrastructure/cache"
 	"github.com/product-management/internal/infrastructure/database"
+	"github.com/product-management/internal/infrastructure/events"
+	"github.com/product-management/internal/infrastructure/imagecheck"
+	"github.com/product-management/internal/infrastructure/logging"
+	"github.com/product-management/internal/infrastructure/mail"
 	"github.com/product-management/internal/infrastructure/repository"
+	"github.com/product-management/internal/infrastructure/webhook"
+	"github.com/product-management/internal/interfaces/http/middleware"
 	"github.com/product-management/internal/interfaces/http/router"
 	"github.com/product-management/internal/usecase"
 	"github.com/product-management/pkg/jwt"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // @title Product Management API
@@ -43,53 +53,340 @@ func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
+	// Log structured records in the configured format. Making the logger the default routes
+	// the standard log package through it as well, so every line shares one format.
+	logger := logging.NewLogger(os.Stdout, cfg.Log.Format, cfg.Log.Level)
+	slog.SetDefault(logger)
+
+	// Missing env files are expected when the environment is provided directly
+	for _, file := range cfg.EnvFiles {
+		switch {
+		case file.Err != nil:
+			logger.Warn("Failed to load env file", slog.String("file", file.Path), slog.Any("error", file.Err))
+		case file.Loaded:
+			logger.Debug("Loaded env file", slog.String("file", file.Path))
+		default:
+			logger.Debug("Env file not found, skipping", slog.String("file", file.Path))
+		}
+	}
+
 	// Initialize database
-	db, err := database.NewDatabase(cfg)
+	db, err := database.NewDatabase(cfg, logger)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal(logger, "Failed to connect to database", slog.Any("error", err))
 	}
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Printf("Failed to close database: %v", err)
-		}
-	}()
 
 	// Run migrations
 	if err := db.AutoMigrate(); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		fatal(logger, "Failed to run migrations", slog.Any("error", err))
 	}
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db.GetDB())
 	productRepo := repository.NewProductRepository(db.GetDB())
+	productImageRepo := repository.NewProductImageRepository(db.GetDB())
+	productTagRepo := repository.NewProductTagRepository(db.GetDB())
+	categoryRepo := repository.NewCategoryRepository(db.GetDB())
+	auditLogRepo := repository.NewAuditLogRepository(db.GetDB())
+	priceHistoryRepo := repository.NewPriceHistoryRepository(db.GetDB())
+	reservationRepo := repository.NewReservationRepository(db.GetDB())
+	webhookRepo := repository.NewWebhookRepository(db.GetDB())
+
+	// Reject writes while read-only mode is on; admins can toggle it at runtime
+	readOnlyMode := repository.NewReadOnlyMode(cfg.Server.ReadOnly)
+	if readOnlyMode.Enabled() {
+		logger.Warn("Starting in read-only mode, writes will be rejected")
+	}
+	userRepo = repository.NewReadOnlyUserRepository(userRepo, readOnlyMode)
+	productRepo = repository.NewReadOnlyProductRepository(productRepo, readOnlyMode)
+	productImageRepo = repository.NewReadOnlyProductImageRepository(productImageRepo, readOnlyMode)
+	productTagRepo = repository.NewReadOnlyProductTagRepository(productTagRepo, readOnlyMode)
+	categoryRepo = repository.NewReadOnlyCategoryRepository(categoryRepo, readOnlyMode)
+	auditLogRepo = repository.NewReadOnlyAuditLogRepository(auditLogRepo, readOnlyMode)
+	priceHistoryRepo = repository.NewReadOnlyPriceHistoryRepository(priceHistoryRepo, readOnlyMode)
+	reservationRepo = repository.NewReadOnlyReservationRepository(reservationRepo, readOnlyMode)
+	webhookRepo = repository.NewReadOnlyWebhookRepository(webhookRepo, readOnlyMode)
+
+	// Revoked tokens are kept in memory, or in Redis when enabled so all instances share them
+	var tokenCache cache.Cache = cache.NewMemoryCache()
+
+	// Optionally cache products by ID in Redis
+	if cfg.Cache.Enabled {
+		cacheTTL, err := time.ParseDuration(cfg.Cache.TTL)
+		if err != nil {
+			fatal(logger, "Invalid cache TTL duration", slog.Any("error", err))
+		}
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     cfg.Cache.RedisAddr,
+			Password: cfg.Cache.RedisPassword,
+			DB:       cfg.Cache.RedisDB,
+		})
+		defer func() {
+			if err := redisClient.Close(); err != nil {
+				logger.Error("Failed to close Redis client", slog.Any("error", err))
+			}
+		}()
+		if err := redisClient.Ping(context.Background()).Err(); err != nil {
+			logger.Warn("Redis unavailable, product reads will fall back to the database", slog.Any("error", err))
+		}
+		redisCache := cache.NewRedisCache(redisClient)
+		productRepo = repository.NewCachedProductRepository(productRepo, redisCache, cacheTTL, logger)
+		tokenCache = redisCache
+	}
+
+	shutdownTimeout, err := time.ParseDuration(cfg.Server.ShutdownTimeout)
+	if err != nil {
+		fatal(logger, "Invalid shutdown timeout duration", slog.Any("error", err))
+	}
 
 	// Initialize JWT token manager
 	expiresIn, err := time.ParseDuration(cfg.JWT.ExpiresIn)
 	if err != nil {
-		log.Fatalf("Invalid JWT expires in duration: %v", err)
+		fatal(logger, "Invalid JWT expires in duration", slog.Any("error", err))
+	}
+	refreshExpiresIn, err := time.ParseDuration(cfg.JWT.RefreshExpiresIn)
+	if err != nil {
+		fatal(logger, "Invalid JWT refresh expires in duration", slog.Any("error", err))
+	}
+	leeway, err := time.ParseDuration(cfg.JWT.Leeway)
+	if err != nil {
+		fatal(logger, "Invalid JWT leeway duration", slog.Any("error", err))
+	}
+	signingKey, err := jwt.LoadSigningKey(cfg.JWT.Algorithm, cfg.JWT.Secret, cfg.JWT.PrivateKeyFile, cfg.JWT.PublicKeyFile)
+	if err != nil {
+		fatal(logger, "Invalid JWT signing key", slog.Any("error", err))
+	}
+	tokenManager := jwt.NewTokenManager(signingKey, expiresIn, refreshExpiresIn, leeway, cfg.JWT.Issuer, cfg.JWT.Audience)
+
+	// New accounts may need to verify their email before logging in
+	verificationTTL, err := time.ParseDuration(cfg.EmailVerification.TokenTTL)
+	if err != nil {
+		fatal(logger, "Invalid email verification token TTL duration", slog.Any("error", err))
+	}
+	emailVerification := usecase.EmailVerification{
+		Required:  cfg.EmailVerification.Required,
+		TokenTTL:  verificationTTL,
+		VerifyURL: cfg.EmailVerification.VerifyURL,
+		Sender:    mail.NewLogSender(logger),
+	}
+
+	// Forgot-password links, throttled per email address to stop email bombing
+	resetTTL, err := time.ParseDuration(cfg.PasswordReset.TokenTTL)
+	if err != nil || resetTTL <= 0 {
+		fatal(logger, "Invalid password reset token TTL: must be a positive duration", slog.String("ttl", cfg.PasswordReset.TokenTTL))
+	}
+	resetWindow, err := time.ParseDuration(cfg.PasswordReset.Window)
+	if err != nil || resetWindow <= 0 {
+		fatal(logger, "Invalid password reset window: must be a positive duration", slog.String("window", cfg.PasswordReset.Window))
+	}
+	passwordReset := usecase.PasswordReset{
+		TokenTTL:    resetTTL,
+		ResetURL:    cfg.PasswordReset.ResetURL,
+		MaxPerEmail: cfg.PasswordReset.MaxPerEmail,
+		Window:      resetWindow,
+		Sender:      emailVerification.Sender,
+	}
+
+	// Fail fast on a bcrypt cost the library would reject at hashing time
+	if cfg.Password.BcryptCost < bcrypt.MinCost || cfg.Password.BcryptCost > bcrypt.MaxCost {
+		fatal(logger, "Invalid password bcrypt cost: must be between the bcrypt minimum and maximum", slog.Int("cost", cfg.Password.BcryptCost), slog.Int("min", bcrypt.MinCost), slog.Int("max", bcrypt.MaxCost))
+	}
+
+	// Optional business rules products must pass before being saved
+	var productValidators []service.ProductValidator
+	if cfg.ProductRules.PriceEnding != "" {
+		validator, err := usecase.NewPriceEndingValidator(cfg.ProductRules.PriceEnding)
+		if err != nil {
+			fatal(logger, "Invalid product price ending rule", slog.Any("error", err))
+		}
+		productValidators = append(productValidators, validator)
+	}
+	if len(cfg.ProductRules.RequiredFields) > 0 {
+		validator, err := usecase.NewRequiredFieldsValidator(cfg.ProductRules.RequiredFields)
+		if err != nil {
+			fatal(logger, "Invalid product required fields rule", slog.Any("error", err))
+		}
+		productValidators = append(productValidators, validator)
+	}
+	if cfg.ProductRules.CheckImageURL {
+		timeout, err := time.ParseDuration(cfg.ProductRules.CheckImageURLTimeout)
+		if err != nil {
+			fatal(logger, "Invalid product image URL check timeout duration", slog.Any("error", err))
+		}
+		productValidators = append(productValidators, imagecheck.NewReachabilityValidator(timeout))
+	}
+	if cfg.ProductRules.SKUPattern != "" {
+		validator, err := usecase.NewSKUFormatValidator(cfg.ProductRules.SKUPattern)
+		if err != nil {
+			fatal(logger, "Invalid product SKU rule", slog.Any("error", err))
+		}
+		productValidators = append(productValidators, validator)
+	}
+
+	// A default page size must be usable and within the maximum (0 leaves page sizes uncapped)
+	if cfg.Pagination.DefaultPageSize < 1 || (cfg.Pagination.MaxPageSize > 0 && cfg.Pagination.DefaultPageSize > cfg.Pagination.MaxPageSize) {
+		fatal(logger, "Invalid page size configuration: default must be at least 1 and at most the maximum", slog.Int("default", cfg.Pagination.DefaultPageSize), slog.Int("max", cfg.Pagination.MaxPageSize))
 	}
-	tokenManager := jwt.NewTokenManager(cfg.JWT.Secret, expiresIn)
+
+	// bcrypt ignores everything past 72 bytes, so longer minimums can't be enforced meaningfully
+	if cfg.Password.MinLength < 1 || cfg.Password.MinLength > 72 {
+		fatal(logger, "Invalid password min length: must be between 1 and 72", slog.Int("min_length", cfg.Password.MinLength))
+	}
+	if cfg.Password.AdminMinLength < cfg.Password.MinLength || cfg.Password.AdminMinLength > 72 {
+		fatal(logger, "Invalid admin password min length: must be between the user min length and 72", slog.Int("admin_min_length", cfg.Password.AdminMinLength), slog.Int("min_length", cfg.Password.MinLength))
+	}
+
+	// Names are stored in 100 character columns
+	if cfg.Profile.MaxNameLength < 1 || cfg.Profile.MaxNameLength > 100 {
+		fatal(logger, "Invalid profile max name length: must be between 1 and 100", slog.Int("max_name_length", cfg.Profile.MaxNameLength))
+	}
+
+	// Initialize transaction manager
+	txManager := database.NewTxManager(db.GetDB())
+
+	// Business events are logged as structured records alongside the persisted audit trail
+	eventLogger := logging.NewEventLogger(logger)
+
+	// Product events are fanned out in-process; subscribe webhooks or a queue here
+	eventPublisher := events.NewInProcessPublisher()
 
 	// Initialize use cases
-	authService := usecase.NewAuthUseCase(userRepo, tokenManager)
-	productService := usecase.NewProductUseCase(productRepo)
+	passwordPolicies := usecase.PasswordPolicies{
+		User: usecase.PasswordPolicy{
+			MinLength:        cfg.Password.MinLength,
+			RequireLowercase: cfg.Password.RequireLowercase,
+			RequireUppercase: cfg.Password.RequireUppercase,
+			RequireDigit:     cfg.Password.RequireDigit,
+			RequireSymbol:    cfg.Password.RequireSymbol,
+		},
+		Admin: usecase.PasswordPolicy{
+			MinLength:        cfg.Password.AdminMinLength,
+			RequireLowercase: cfg.Password.AdminRequireLowercase,
+			RequireUppercase: cfg.Password.AdminRequireUppercase,
+			RequireDigit:     cfg.Password.AdminRequireDigit,
+			RequireSymbol:    cfg.Password.AdminRequireSymbol,
+		},
+		Denylist: cfg.Password.Denylist,
+	}
+	if !passwordPolicies.Admin.RequiresClassesOf(passwordPolicies.User) {
+		fatal(logger, "Invalid admin password policy: every character class required for users must be required for admins")
+	}
+	authService := usecase.NewAuthUseCase(userRepo, auditLogRepo, tokenManager, txManager, emailVerification, passwordReset, cfg.Password.BcryptCost, passwordPolicies, cache.NewTokenRevocationList(tokenCache), eventLogger, usecase.ProfileLimits{
+		MaxNameLength: cfg.Profile.MaxNameLength,
+	}, logger)
+	categoryService := usecase.NewCategoryUseCase(categoryRepo, txManager)
+	var skuGenerator *usecase.SKUGenerator
+	if cfg.SKUGeneration.Enabled {
+		skuGenerator, err = usecase.NewSKUGenerator(cfg.SKUGeneration.Template, cfg.SKUGeneration.RandomLength, cfg.SKUGeneration.MaxAttempts)
+		if err != nil {
+			fatal(logger, "Invalid SKU generation config", slog.Any("error", err))
+		}
+	}
+	if cfg.Product.ImageReportThreshold < 1 {
+		fatal(logger, "Invalid product image report threshold: must be at least 1", slog.Int("threshold", cfg.Product.ImageReportThreshold))
+	}
+	if cfg.Product.MaxBatchIDs < 1 {
+		fatal(logger, "Invalid product batch max IDs: must be at least 1", slog.Int("max_ids", cfg.Product.MaxBatchIDs))
+	}
+	categoryCase, err := usecase.ParseCategoryCase(cfg.Product.CategoryCase)
+	if err != nil {
+		fatal(logger, "Invalid product category casing", slog.Any("error", err))
+	}
+	categoryMode, err := usecase.ParseCategoryMode(cfg.Product.CategoryMode)
+	if err != nil {
+		fatal(logger, "Invalid product category mode", slog.Any("error", err))
+	}
+	productOptions := usecase.ProductUseCaseOptions{
+		MaxConcurrentImports: cfg.Import.MaxConcurrent,
+		MaxSearchDepth:       cfg.Search.MaxResultDepth,
+		DeactivateOnDelete:   cfg.Product.DeactivateOnDelete,
+		ImageReportThreshold: cfg.Product.ImageReportThreshold,
+		CategoryCase:         categoryCase,
+		CategoryMode:         categoryMode,
+		NamesPerCategory:     cfg.Product.NameUniquePerCategory,
+		Validators:           productValidators,
+		SKUs:                 skuGenerator,
+	}
+	productService := usecase.NewProductUseCase(productRepo, productImageRepo, productTagRepo, categoryRepo, auditLogRepo, priceHistoryRepo, reservationRepo, txManager, productOptions, eventLogger, eventPublisher, logger)
+
+	// Recompute stored search vectors in the background if they predate the current way of
+	// computing them; stopped on shutdown, it starts over on the next one
+	reindexCtx, stopReindex := context.WithCancel(context.Background())
+	defer stopReindex()
+	if cfg.Search.ReindexOnStartup {
+		go func() {
+			if err := productService.ReindexSearchIfStale(reindexCtx, database.SearchIndexVersion); err != nil && reindexCtx.Err() == nil {
+				logger.Error("Failed to reindex product search", slog.Any("error", err))
+			}
+		}()
+	}
+
+	// Return the stock of stale reservations in the background
+	releaseInterval, err := time.ParseDuration(cfg.Reservation.ReleaseInterval)
+	if err != nil || releaseInterval <= 0 {
+		fatal(logger, "Invalid reservation release interval: must be a positive duration", slog.String("interval", cfg.Reservation.ReleaseInterval))
+	}
+	reservationTTL, err := time.ParseDuration(cfg.Reservation.TTL)
+	if err != nil || reservationTTL < 0 {
+		fatal(logger, "Invalid reservation TTL: must be a non-negative duration", slog.String("ttl", cfg.Reservation.TTL))
+	}
+	releaserCtx, stopReleaser := context.WithCancel(context.Background())
+	defer stopReleaser()
+	releaserDone := make(chan struct{})
+	go func() {
+		defer close(releaserDone)
+		productService.RunReservationReleaser(releaserCtx, releaseInterval, reservationTTL)
+	}()
+
+	// Deliver product events to webhooks in the background, retrying failed deliveries
+	webhookTimeout, err := time.ParseDuration(cfg.Webhook.Timeout)
+	if err != nil || webhookTimeout <= 0 {
+		fatal(logger, "Invalid webhook timeout: must be a positive duration", slog.String("timeout", cfg.Webhook.Timeout))
+	}
+	webhookRetryDelay, err := time.ParseDuration(cfg.Webhook.RetryDelay)
+	if err != nil || webhookRetryDelay <= 0 {
+		fatal(logger, "Invalid webhook retry delay: must be a positive duration", slog.String("retry_delay", cfg.Webhook.RetryDelay))
+	}
+	webhookPollInterval, err := time.ParseDuration(cfg.Webhook.PollInterval)
+	if err != nil || webhookPollInterval <= 0 {
+		fatal(logger, "Invalid webhook poll interval: must be a positive duration", slog.String("poll_interval", cfg.Webhook.PollInterval))
+	}
+	if cfg.Webhook.MaxAttempts < 1 {
+		fatal(logger, "Invalid webhook max attempts: must be at least 1", slog.Int("max_attempts", cfg.Webhook.MaxAttempts))
+	}
+	webhookService := usecase.NewWebhookUseCase(webhookRepo, txManager, webhook.NewHTTPSender(webhookTimeout), usecase.WebhookDeliveryPolicy{
+		MaxAttempts: cfg.Webhook.MaxAttempts,
+		RetryDelay:  webhookRetryDelay,
+		Timeout:     webhookTimeout,
+	}, logger)
+	eventPublisher.Subscribe(webhookService.HandleProductEvent)
+	webhookCtx, stopWebhooks := context.WithCancel(context.Background())
+	defer stopWebhooks()
+	webhookWorkerDone := make(chan struct{})
+	go func() {
+		defer close(webhookWorkerDone)
+		webhookService.RunDeliveryWorker(webhookCtx, webhookPollInterval)
+	}()
 
 	// Setup router
-	r := router.SetupRouter(cfg, db, productService, authService)
+	r := router.SetupRouter(cfg, logger, db, readOnlyMode, productService, categoryService, webhookService, authService, tokenManager)
 
-	// Create HTTP server
+	// Create HTTP server, tracking in-flight requests for shutdown
+	requestTracker := middleware.NewRequestTracker()
 	server := &http.Server{
 		Addr:    ":" + cfg.Server.Port,
-		Handler: r,
+		Handler: requestTracker.Handler(r),
 	}
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Starting server on port %s", cfg.Server.Port)
-		log.Printf("Swagger documentation available at http://localhost:%s/swagger/index.html", cfg.Server.Port)
+		logger.Info("Starting server", slog.String("port", cfg.Server.Port))
+		if cfg.Server.GinMode != "release" {
+			logger.Info("Swagger documentation available", slog.String("url", "http://localhost:"+cfg.Server.Port+"/swagger/index.html"))
+		}
 		
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			fatal(logger, "Failed to start server", slog.Any("error", err))
 		}
 	}()
 
@@ -98,23 +395,56 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("Shutting down server", slog.Int64("in_flight", requestTracker.InFlight()))
 
 	// Create a context with timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	// Attempt graceful shutdown
+	// Stop accepting connections and drain in-flight requests, then background imports,
+	// before closing the database they rely on
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		logger.Error("Server forced to shutdown", slog.Any("error", err))
+	}
+	if err := requestTracker.Wait(ctx); err != nil {
+		logger.Warn("Gave up waiting for in-flight requests", slog.Int64("in_flight", requestTracker.InFlight()), slog.Any("error", err))
+	}
+	if err := productService.WaitForImports(ctx); err != nil {
+		logger.Warn("Gave up waiting for running imports", slog.Any("error", err))
+	}
+	stopReindex()
+	if err := productService.WaitForSearchReindex(ctx); err != nil {
+		logger.Warn("Gave up waiting for the search reindex", slog.Any("error", err))
+	}
+	stopReleaser()
+	select {
+	case <-releaserDone:
+	case <-ctx.Done():
+		logger.Warn("Gave up waiting for the reservation releaser", slog.Any("error", ctx.Err()))
 	}
+	stopWebhooks()
+	select {
+	case <-webhookWorkerDone:
+	case <-ctx.Done():
+		logger.Warn("Gave up waiting for the webhook delivery worker", slog.Any("error", ctx.Err()))
+	}
+
+	if err := db.Close(); err != nil {
+		logger.Error("Failed to close database", slog.Any("error", err))
+	}
+
+	logger.Info("Server exited")
+}
 
-	log.Println("Server exited")
+// fatal logs msg at error level and exits, for startup failures the server can't run with
+func fatal(logger *slog.Logger, msg string, attrs ...any) {
+	logger.Error(msg, attrs...)
+	os.Exit(1)
 }
 
 // createDefaultAdminUser creates a default admin user if it doesn't exist
 func createDefaultAdminUser(authService interface{}) {
 	// This is a placeholder for creating a default admin user
 	// In a real application, you might want to implement this
-	log.Println("Default admin user creation not implemented")
+	slog.Info("Default admin user creation not implemented")
 }
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"log"
 	"os"
 	"strconv"
 	"strings"
@@ -11,18 +10,62 @@ import (
 
 // Config holds all configuration for our application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	OAuth2   OAuth2Config
-	CORS     CORSConfig
-	Log      LogConfig
+	Server            ServerConfig
+	Database          DatabaseConfig
+	JWT               JWTConfig
+	OAuth2            OAuth2Config
+	CORS              CORSConfig
+	Log               LogConfig
+	Import            ImportConfig
+	Cache             CacheConfig
+	Export            ExportConfig
+	EmailVerification EmailVerificationConfig
+	PasswordReset     PasswordResetConfig
+	Password          PasswordConfig
+	PublicFeed        PublicFeedConfig
+	Search            SearchConfig
+	Product           ProductConfig
+	Pagination        PaginationConfig
+	Profile           ProfileConfig
+	ProductRules      ProductRulesConfig
+	RequestTimeout    RequestTimeoutConfig
+	Reservation       ReservationConfig
+	Webhook           WebhookConfig
+	SKUGeneration     SKUGenerationConfig
+
+	// EnvFiles reports each env file LoadConfig tried, for the caller to log once its logger exists
+	EnvFiles []EnvFile
+}
+
+// EnvFile is the outcome of loading one env file
+type EnvFile struct {
+	Path   string
+	Loaded bool  // false if the file does not exist or could not be read
+	Err    error // why an existing file could not be read
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port    string
-	GinMode string
+	Port                string
+	GinMode             string
+	ShutdownTimeout     string
+	ReadOnly            bool  // start with writes rejected, e.g. while a migration runs
+	MaxRequestBodyBytes int64 // body size limit for every route without one in BodyLimits
+	BodyLimits          BodyLimitConfig
+	Compression         CompressionConfig
+}
+
+// CompressionConfig holds gzip response compression configuration
+type CompressionConfig struct {
+	Enabled bool
+	MinSize int // smaller responses are sent uncompressed
+}
+
+// BodyLimitConfig holds the maximum request body size in bytes for groups of endpoints that
+// override MaxRequestBodyBytes
+type BodyLimitConfig struct {
+	Auth   int64
+	Import int64
 }
 
 // DatabaseConfig holds database configuration
@@ -33,12 +76,37 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// CaseInsensitiveUnique enforces unique user emails and usernames ignoring case
+	CaseInsensitiveUnique bool
+	Pool                  DatabasePoolConfig
+	Connect               DatabaseConnectConfig
+}
+
+// DatabaseConnectConfig holds how the initial database connection is retried
+type DatabaseConnectConfig struct {
+	Attempts  int    // total connection attempts before giving up
+	BaseDelay string // delay after the first failure, doubled after each further one
+}
+
+// DatabasePoolConfig holds database connection pool configuration
+type DatabasePoolConfig struct {
+	MaxIdleConns    int
+	MaxOpenConns    int    // 0 for unlimited
+	ConnMaxLifetime string // 0 to keep connections open indefinitely
+	ConnMaxIdleTime string // 0 to keep idle connections open indefinitely
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret    string
-	ExpiresIn string
+	Algorithm        string // HS256 (Secret) or RS256 (PrivateKeyFile and/or PublicKeyFile)
+	Secret           string
+	PrivateKeyFile   string // PEM RSA private key, needed to issue tokens
+	PublicKeyFile    string // PEM RSA public key, derived from the private key when empty
+	ExpiresIn        string
+	RefreshExpiresIn string
+	Leeway           string // clock skew tolerated when validating token times
+	Issuer           string // iss claim issued and required, empty to leave it out
+	Audience         string // aud claim issued and required, empty to leave it out
 }
 
 // OAuth2Config holds OAuth2 configuration
@@ -58,6 +126,8 @@ type CORSConfig struct {
 	AllowedOrigins []string
 	AllowedMethods []string
 	AllowedHeaders []string
+	// AllowCredentials lets browsers send cookies and auth headers cross-origin
+	AllowCredentials bool
 }
 
 // LogConfig holds logging configuration
@@ -66,17 +136,175 @@ type LogConfig struct {
 	Format string
 }
 
+// ImportConfig holds bulk import configuration
+type ImportConfig struct {
+	MaxConcurrent int
+}
+
+// CacheConfig holds product cache configuration
+type CacheConfig struct {
+	Enabled       bool
+	TTL           string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+}
+
+// SearchConfig holds product search configuration
+type SearchConfig struct {
+	MaxResultDepth int // how many results can be paged through, 0 for no limit
+	// ReindexOnStartup recomputes stored search vectors on startup when they were computed
+	// with an older search index version
+	ReindexOnStartup bool
+}
+
+// ProductConfig holds product lifecycle configuration
+type ProductConfig struct {
+	// DeactivateOnDelete marks products inactive as they are soft-deleted, so caches and
+	// synced systems that only look at is_active stop showing them
+	DeactivateOnDelete bool
+	// ImageReportThreshold broken image reports flag a product for review
+	ImageReportThreshold int
+	// ImageReportRateLimit caps the broken image reports each client IP can send per minute
+	ImageReportRateLimit int
+	// CategoryCase is the casing free-text categories are normalized to: none, lower or title.
+	// Categories are trimmed under every policy.
+	CategoryCase string
+	// CategoryMode is how products created with a category name are tied to the managed
+	// categories: free_text, require or auto_create
+	CategoryMode string
+	// NameUniquePerCategory only requires product names to be unique within their category,
+	// enforced by a unique index; otherwise names must be unique across all products
+	NameUniquePerCategory bool
+	// MaxBatchIDs caps how many products a single batch lookup may request
+	MaxBatchIDs int
+}
+
+// ProfileConfig holds user profile validation configuration
+type ProfileConfig struct {
+	MaxNameLength int // longest first or last name allowed, at most the column size of 100
+}
+
+// PaginationConfig holds the page sizes used by list endpoints
+type PaginationConfig struct {
+	DefaultPageSize int  // used when page_size is absent
+	MaxPageSize     int  // larger page sizes are capped to this
+	Headers         bool // also report pagination in X-Total-Count style and Link headers
+}
+
+// ProductRulesConfig holds the optional business rules products must pass before being saved
+type ProductRulesConfig struct {
+	PriceEnding    string   // e.g. ".99" to require prices like 9.99, empty to allow any price
+	RequiredFields []string // "category:field|field" rules, e.g. "electronics:description|image_url"
+	// CheckImageURL sends a HEAD request to verify a product's image URL serves an image
+	CheckImageURL        bool
+	CheckImageURLTimeout string
+	SKUPattern           string // regular expression SKUs must match, empty to accept any SKU
+}
+
+// ExportConfig holds product export configuration
+type ExportConfig struct {
+	GzipEnabled bool
+	// PriceSheetEmptyNotFound answers 404 for a category price sheet without products,
+	// rather than a sheet with only the header row
+	PriceSheetEmptyNotFound bool
+}
+
+// EmailVerificationConfig holds email verification configuration
+type EmailVerificationConfig struct {
+	Required  bool   // register accounts inactive until their email is verified
+	TokenTTL  string // how long a verification link stays valid
+	VerifyURL string // link sent to users, the token is appended as ?token=
+}
+
+// PasswordResetConfig holds forgot-password configuration. Reset requests are throttled per
+// email address and per client IP over the same window; a limit of 0 disables that throttle.
+type PasswordResetConfig struct {
+	TokenTTL    string // how long a reset link stays valid
+	ResetURL    string // link sent to users, the token is appended as ?token=
+	MaxPerEmail int
+	MaxPerIP    int
+	Window      string
+}
+
+// PasswordConfig holds password hashing and policy configuration. Admins get their own,
+// stricter policy.
+type PasswordConfig struct {
+	BcryptCost            int // existing hashes below this cost are upgraded on login
+	MinLength             int
+	RequireLowercase      bool
+	RequireUppercase      bool
+	RequireDigit          bool
+	RequireSymbol         bool
+	AdminMinLength        int
+	AdminRequireLowercase bool
+	AdminRequireUppercase bool
+	AdminRequireDigit     bool
+	AdminRequireSymbol    bool
+	Denylist              []string // common passwords refused for every account
+}
+
+// RequestTimeoutConfig holds the request timeouts of each client tier
+type RequestTimeoutConfig struct {
+	Tiers       map[string]string // tier name to timeout duration
+	DefaultTier string            // tier of clients without a known API key or token role
+	APIKeyTiers map[string]string // API key to tier name
+	RoleTiers   map[string]string // role claim of a bearer token to tier name
+}
+
+// ReservationConfig holds stock reservation configuration
+type ReservationConfig struct {
+	ReleaseInterval string // how often stale reservations are returned to stock
+	TTL             string // age after which a reservation is stale even if not expired, 0 to disable
+}
+
+// WebhookConfig holds outbound webhook delivery configuration
+type WebhookConfig struct {
+	Timeout      string // how long a single delivery attempt may take
+	MaxAttempts  int    // attempts before a delivery is given up on
+	RetryDelay   string // wait before the first retry, doubling for each one after
+	PollInterval string // how often deliveries due for a retry are picked up
+}
+
+// SKUGenerationConfig holds configuration for generating SKUs for products created without one
+type SKUGenerationConfig struct {
+	Enabled      bool
+	Template     string // e.g. "{CATEGORY_PREFIX}-{SEQUENCE}" or "P-{RANDOM}"
+	RandomLength int    // length of the {RANDOM} part
+	MaxAttempts  int    // SKUs tried before giving up when each one is taken
+}
+
+// PublicFeedConfig holds configuration for the partner product feed
+type PublicFeedConfig struct {
+	APIKeys     []string // the feed is only served when at least one key is configured
+	RateLimit   int      // requests per minute allowed for each API key
+	CacheMaxAge string   // how long clients and proxies may cache a feed page
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() *Config {
-	// Load .env file
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
-	}
+	envFiles := loadEnvFiles()
+
+	// *_REQUIRE_CHARACTER_CLASSES turns every character class rule of a password policy on at once
+	userClasses := getEnvAsBool("PASSWORD_REQUIRE_CHARACTER_CLASSES", false)
+	adminClasses := getEnvAsBool("ADMIN_PASSWORD_REQUIRE_CHARACTER_CLASSES", true)
 
 	config := &Config{
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:            getEnv("PORT", "8080"),
+			GinMode:         getEnv("GIN_MODE", "debug"),
+			ShutdownTimeout: getEnv("SHUTDOWN_TIMEOUT", "30s"),
+			ReadOnly:        getEnvAsBool("READ_ONLY_MODE", false),
+			// BODY_LIMIT_DEFAULT is the former name of MAX_REQUEST_BODY_BYTES
+			MaxRequestBodyBytes: getEnvAsInt64("MAX_REQUEST_BODY_BYTES", getEnvAsInt64("BODY_LIMIT_DEFAULT", 1<<20)),
+			BodyLimits: BodyLimitConfig{
+				Auth:   getEnvAsInt64("BODY_LIMIT_AUTH", 4<<10),
+				Import: getEnvAsInt64("BODY_LIMIT_IMPORT", 50<<20),
+			},
+			Compression: CompressionConfig{
+				Enabled: getEnvAsBool("COMPRESSION_ENABLED", true),
+				MinSize: getEnvAsInt("COMPRESSION_MIN_SIZE", 1024),
+			},
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -85,10 +313,29 @@ func LoadConfig() *Config {
 			Password: getEnv("DB_PASSWORD", "postgres"),
 			Name:     getEnv("DB_NAME", "product_management"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			CaseInsensitiveUnique: getEnvAsBool("DB_CASE_INSENSITIVE_UNIQUE", true),
+			Pool: DatabasePoolConfig{
+				MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+				MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 100),
+				ConnMaxLifetime: getEnv("DB_CONN_MAX_LIFETIME", "0"),
+				ConnMaxIdleTime: getEnv("DB_CONN_MAX_IDLE_TIME", "0"),
+			},
+			Connect: DatabaseConnectConfig{
+				Attempts:  getEnvAsInt("DB_CONNECT_ATTEMPTS", 5),
+				BaseDelay: getEnv("DB_CONNECT_BASE_DELAY", "1s"),
+			},
 		},
 		JWT: JWTConfig{
-			Secret:    getEnv("JWT_SECRET", "your-secret-key"),
-			ExpiresIn: getEnv("JWT_EXPIRES_IN", "24h"),
+			Algorithm:        getEnv("JWT_ALGORITHM", "HS256"),
+			Secret:           getEnv("JWT_SECRET", "your-secret-key"),
+			PrivateKeyFile:   getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			PublicKeyFile:    getEnv("JWT_PUBLIC_KEY_FILE", ""),
+			ExpiresIn:        getEnv("JWT_EXPIRES_IN", "24h"),
+			RefreshExpiresIn: getEnv("JWT_REFRESH_EXPIRES_IN", "168h"),
+			Leeway:           getEnv("JWT_LEEWAY", "5s"),
+			Issuer:           getEnv("JWT_ISSUER", "product-management"),
+			Audience:         getEnv("JWT_AUDIENCE", "product-management-api"),
 		},
 		OAuth2: OAuth2Config{
 			Google: GoogleOAuth2Config{
@@ -99,18 +346,155 @@ func LoadConfig() *Config {
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: getEnvAsSlice("ALLOWED_ORIGINS", []string{"*"}),
-			AllowedMethods: getEnvAsSlice("ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-			AllowedHeaders: getEnvAsSlice("ALLOWED_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization"}),
+			AllowedMethods: getEnvAsSlice("ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+			AllowedHeaders: getEnvAsSlice("ALLOWED_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "X-API-Key", "X-Confirm-Delete"}),
+
+			AllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
 		},
 		Log: LogConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
+		Import: ImportConfig{
+			MaxConcurrent: getEnvAsInt("IMPORT_MAX_CONCURRENT", 1),
+		},
+		Search: SearchConfig{
+			MaxResultDepth:   getEnvAsInt("SEARCH_MAX_RESULT_DEPTH", 1000),
+			ReindexOnStartup: getEnvAsBool("SEARCH_REINDEX_ON_STARTUP", true),
+		},
+		Product: ProductConfig{
+			DeactivateOnDelete:   getEnvAsBool("PRODUCT_DEACTIVATE_ON_DELETE", true),
+			ImageReportThreshold: getEnvAsInt("PRODUCT_IMAGE_REPORT_THRESHOLD", 3),
+			ImageReportRateLimit: getEnvAsInt("PRODUCT_IMAGE_REPORT_RATE_LIMIT", 10),
+			CategoryCase:         getEnv("PRODUCT_CATEGORY_CASE", "none"),
+			CategoryMode:         getEnv("PRODUCT_CATEGORY_MODE", "free_text"),
+
+			NameUniquePerCategory: getEnvAsBool("PRODUCT_NAME_UNIQUE_PER_CATEGORY", true),
+			MaxBatchIDs:           getEnvAsInt("PRODUCT_BATCH_MAX_IDS", 100),
+		},
+		Profile: ProfileConfig{
+			MaxNameLength: getEnvAsInt("PROFILE_MAX_NAME_LENGTH", 100),
+		},
+		Pagination: PaginationConfig{
+			DefaultPageSize: getEnvAsInt("PAGE_SIZE_DEFAULT", 10),
+			MaxPageSize:     getEnvAsInt("PAGE_SIZE_MAX", 100),
+			Headers:         getEnvAsBool("PAGINATION_HEADERS_ENABLED", true),
+		},
+		ProductRules: ProductRulesConfig{
+			PriceEnding:    getEnv("PRODUCT_RULE_PRICE_ENDING", ""),
+			RequiredFields: getEnvAsSlice("PRODUCT_RULE_REQUIRED_FIELDS", nil),
+
+			CheckImageURL:        getEnvAsBool("PRODUCT_RULE_CHECK_IMAGE_URL", false),
+			CheckImageURLTimeout: getEnv("PRODUCT_RULE_CHECK_IMAGE_URL_TIMEOUT", "3s"),
+			SKUPattern:           getEnv("PRODUCT_RULE_SKU_PATTERN", "^[A-Za-z0-9]+(-[A-Za-z0-9]+)*$"),
+		},
+		Cache: CacheConfig{
+			Enabled:       getEnvAsBool("CACHE_ENABLED", false),
+			TTL:           getEnv("CACHE_TTL", "5m"),
+			RedisAddr:     getEnv("REDIS_ADDR", "localhost:6379"),
+			RedisPassword: getEnv("REDIS_PASSWORD", ""),
+			RedisDB:       getEnvAsInt("REDIS_DB", 0),
+		},
+		Export: ExportConfig{
+			GzipEnabled: getEnvAsBool("EXPORT_GZIP_ENABLED", true),
+
+			PriceSheetEmptyNotFound: getEnvAsBool("EXPORT_PRICE_SHEET_EMPTY_NOT_FOUND", true),
+		},
+		EmailVerification: EmailVerificationConfig{
+			Required:  getEnvAsBool("EMAIL_VERIFICATION_REQUIRED", false),
+			TokenTTL:  getEnv("EMAIL_VERIFICATION_TOKEN_TTL", "24h"),
+			VerifyURL: getEnv("EMAIL_VERIFICATION_URL", "http://localhost:8080/api/v1/auth/verify"),
+		},
+		PasswordReset: PasswordResetConfig{
+			TokenTTL:    getEnv("PASSWORD_RESET_TOKEN_TTL", "1h"),
+			ResetURL:    getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+			MaxPerEmail: getEnvAsInt("PASSWORD_RESET_MAX_PER_EMAIL", 3),
+			MaxPerIP:    getEnvAsInt("PASSWORD_RESET_MAX_PER_IP", 10),
+			Window:      getEnv("PASSWORD_RESET_WINDOW", "1h"),
+		},
+		Password: PasswordConfig{
+			BcryptCost:            getEnvAsInt("PASSWORD_BCRYPT_COST", 10),
+			MinLength:             getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+			RequireLowercase:      getEnvAsBool("PASSWORD_REQUIRE_LOWERCASE", userClasses),
+			RequireUppercase:      getEnvAsBool("PASSWORD_REQUIRE_UPPERCASE", userClasses),
+			RequireDigit:          getEnvAsBool("PASSWORD_REQUIRE_DIGIT", userClasses),
+			RequireSymbol:         getEnvAsBool("PASSWORD_REQUIRE_SYMBOL", userClasses),
+			AdminMinLength:        getEnvAsInt("ADMIN_PASSWORD_MIN_LENGTH", 12),
+			AdminRequireLowercase: getEnvAsBool("ADMIN_PASSWORD_REQUIRE_LOWERCASE", adminClasses),
+			AdminRequireUppercase: getEnvAsBool("ADMIN_PASSWORD_REQUIRE_UPPERCASE", adminClasses),
+			AdminRequireDigit:     getEnvAsBool("ADMIN_PASSWORD_REQUIRE_DIGIT", adminClasses),
+			AdminRequireSymbol:    getEnvAsBool("ADMIN_PASSWORD_REQUIRE_SYMBOL", adminClasses),
+			Denylist:              getEnvAsSlice("PASSWORD_DENYLIST", nil),
+		},
+		RequestTimeout: RequestTimeoutConfig{
+			Tiers:       getEnvAsMap("REQUEST_TIMEOUT_TIERS", map[string]string{"free": "15s", "premium": "60s"}),
+			DefaultTier: getEnv("REQUEST_TIMEOUT_DEFAULT_TIER", "free"),
+			APIKeyTiers: getEnvAsMap("REQUEST_TIMEOUT_API_KEY_TIERS", nil),
+			RoleTiers:   getEnvAsMap("REQUEST_TIMEOUT_ROLE_TIERS", nil),
+		},
+		Reservation: ReservationConfig{
+			ReleaseInterval: getEnv("RESERVATION_RELEASE_INTERVAL", "1m"),
+			TTL:             getEnv("RESERVATION_TTL", "0"),
+		},
+		Webhook: WebhookConfig{
+			Timeout:      getEnv("WEBHOOK_TIMEOUT", "10s"),
+			MaxAttempts:  getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5),
+			RetryDelay:   getEnv("WEBHOOK_RETRY_DELAY", "30s"),
+			PollInterval: getEnv("WEBHOOK_POLL_INTERVAL", "10s"),
+		},
+		SKUGeneration: SKUGenerationConfig{
+			Enabled:      getEnvAsBool("SKU_GENERATION_ENABLED", false),
+			Template:     getEnv("SKU_GENERATION_TEMPLATE", "{CATEGORY_PREFIX}-{SEQUENCE}"),
+			RandomLength: getEnvAsInt("SKU_GENERATION_RANDOM_LENGTH", 8),
+			MaxAttempts:  getEnvAsInt("SKU_GENERATION_MAX_ATTEMPTS", 5),
+		},
+		PublicFeed: PublicFeedConfig{
+			APIKeys:     getEnvAsSlice("PUBLIC_FEED_API_KEYS", nil),
+			RateLimit:   getEnvAsInt("PUBLIC_FEED_RATE_LIMIT", 60),
+			CacheMaxAge: getEnv("PUBLIC_FEED_CACHE_MAX_AGE", "5m"),
+		},
 	}
+	config.EnvFiles = envFiles
 
 	return config
 }
 
+// loadEnvFiles loads .env files into the process environment.
+//
+// Variables already set in the environment always win, followed by ENV_FILE (if set),
+// then .env.{APP_ENV}.local, .env.{APP_ENV} and finally .env. Setting CONFIG_SOURCE=env
+// skips file loading entirely for deployments where the orchestrator provides the
+// environment. The outcome for each file is returned rather than logged, since logging is
+// configured from the environment these files populate.
+func loadEnvFiles() []EnvFile {
+	if strings.EqualFold(os.Getenv("CONFIG_SOURCE"), "env") {
+		return nil
+	}
+
+	var files []string
+	if envFile := os.Getenv("ENV_FILE"); envFile != "" {
+		files = append(files, envFile)
+	}
+	if appEnv := os.Getenv("APP_ENV"); appEnv != "" {
+		files = append(files, ".env."+appEnv+".local", ".env."+appEnv)
+	}
+	files = append(files, ".env")
+
+	results := make([]EnvFile, 0, len(files))
+	for _, file := range files {
+		// godotenv never overrides variables that are already set, so earlier files take precedence
+		switch err := godotenv.Load(file); {
+		case err == nil:
+			results = append(results, EnvFile{Path: file, Loaded: true})
+		case os.IsNotExist(err):
+			results = append(results, EnvFile{Path: file})
+		default:
+			results = append(results, EnvFile{Path: file, Err: err})
+		}
+	}
+	return results
+}
+
 // Helper functions for environment variable handling
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -127,6 +511,22 @@ func getEnvAsInt(name string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsInt64(name string, defaultValue int64) int64 {
+	valueStr := getEnv(name, "")
+	if value, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(name string, defaultValue bool) bool {
+	valueStr := getEnv(name, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsSlice(name string, defaultValue []string) []string {
 	valueStr := getEnv(name, "")
 	if valueStr == "" {
@@ -134,3 +534,25 @@ func getEnvAsSlice(name string, defaultValue []string) []string {
 	}
 	return strings.Split(valueStr, ",")
 }
+
+// getEnvAsMap parses a comma-separated list of key:value pairs. Values can't contain a
+// colon, but keys can, so API keys are safe to use as keys.
+func getEnvAsMap(name string, defaultValue map[string]string) map[string]string {
+	valueStr := getEnv(name, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	values := make(map[string]string)
+	for _, pair := range strings.Split(valueStr, ",") {
+		i := strings.LastIndex(pair, ":")
+		if i < 0 {
+			continue
+		}
+		key, value := strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:])
+		if key != "" {
+			values[key] = value
+		}
+	}
+	return values
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPasswordCharacterClassesDefaultToTheCombinedSetting(t *testing.T) {
+	t.Setenv("CONFIG_SOURCE", "env")
+	t.Setenv("PASSWORD_REQUIRE_CHARACTER_CLASSES", "true")
+	t.Setenv("PASSWORD_REQUIRE_SYMBOL", "false")
+	t.Setenv("ADMIN_PASSWORD_REQUIRE_CHARACTER_CLASSES", "false")
+	t.Setenv("ADMIN_PASSWORD_REQUIRE_DIGIT", "true")
+	t.Setenv("PASSWORD_DENYLIST", "password,letmein")
+
+	password := LoadConfig().Password
+	user := []bool{password.RequireLowercase, password.RequireUppercase, password.RequireDigit, password.RequireSymbol}
+	if !slices.Equal(user, []bool{true, true, true, false}) {
+		t.Errorf("got user classes %v, want every class but the overridden symbol one", user)
+	}
+	admin := []bool{password.AdminRequireLowercase, password.AdminRequireUppercase, password.AdminRequireDigit, password.AdminRequireSymbol}
+	if !slices.Equal(admin, []bool{false, false, true, false}) {
+		t.Errorf("got admin classes %v, want only the overridden digit class", admin)
+	}
+	if !slices.Equal(password.Denylist, []string{"password", "letmein"}) {
+		t.Errorf("got denylist %q, want [password letmein]", password.Denylist)
+	}
+}
+
+func TestLoadEnvFilesReportsEachFile(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env.test")
+	if err := os.WriteFile(envFile, []byte("ENV_FILE_TEST_VALUE=1\n"), 0o600); err != nil {
+		t.Fatalf("write env file: %v", err)
+	}
+	t.Setenv("CONFIG_SOURCE", "")
+	t.Setenv("ENV_FILE", envFile)
+	t.Setenv("APP_ENV", "")
+	t.Setenv("ENV_FILE_TEST_VALUE", "")
+	os.Unsetenv("ENV_FILE_TEST_VALUE")
+
+	// There is no .env next to the package's tests, so it is reported as not loaded
+	got := loadEnvFiles()
+	want := []EnvFile{{Path: envFile, Loaded: true}, {Path: ".env"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Audited entity types
+const (
+	AuditEntityProduct = "product"
+	AuditEntityUser    = "user"
+)
+
+// Audit actions
+const (
+	AuditActionCreate        = "create"
+	AuditActionUpdate        = "update"
+	AuditActionDelete        = "delete"
+	AuditActionStockChange   = "stock_change"
+	AuditActionRestore       = "restore"
+	AuditActionDeactivate    = "deactivate"
+	AuditActionReactivate    = "reactivate"
+	AuditActionHardDelete    = "hard_delete"
+	AuditActionSetPassword   = "set_password"
+	AuditActionResetPassword = "reset_password"
+)
+
+// Stock change reasons
+const (
+	StockReasonReserved           = "reserved"
+	StockReasonReservationExpired = "reservation_expired"
+)
+
+// FieldChange holds a field's value before and after a change
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges maps field names to their change, stored as JSON
+type AuditChanges map[string]FieldChange
+
+// Value implements driver.Valuer
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (c *AuditChanges) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type %T for audit changes", value)
+	}
+	return json.Unmarshal(data, c)
+}
+
+// AuditLog records a mutation of an entity and who made it
+type AuditLog struct {
+	ID          uint         `json:"id" gorm:"primarykey"`
+	EntityType  string       `json:"entity_type" gorm:"size:50;not null;index:idx_audit_logs_entity"`
+	EntityID    uint         `json:"entity_id" gorm:"not null;index:idx_audit_logs_entity"`
+	Action      string       `json:"action" gorm:"size:50;not null"`
+	ActorUserID *uint        `json:"actor_user_id"`
+	Reason      string       `json:"reason,omitempty" gorm:"size:50"` // why a change was made, e.g. a StockReason
+	Changes     AuditChanges `json:"changes" gorm:"column:changes_json;type:jsonb"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// TableName returns the table name for AuditLog entity
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
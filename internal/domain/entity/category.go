@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Category represents a product category, optionally nested under a parent category
+type Category struct {
+	ID        uint        `json:"id" gorm:"primarykey"`
+	Name      string      `json:"name" gorm:"size:100;not null;uniqueIndex"`
+	Slug      string      `json:"slug" gorm:"size:120;not null;uniqueIndex"`
+	ParentID  *uint       `json:"parent_id" gorm:"index"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	Children  []*Category `json:"children,omitempty" gorm:"-"`
+}
+
+// TableName returns the table name for Category entity
+func (Category) TableName() string {
+	return "categories"
+}
+
+// slugPattern matches lowercase words separated by single hyphens
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// slugSeparators matches runs of characters that aren't allowed in a slug
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify derives a URL-friendly slug from a name, e.g. "Home & Garden" becomes "home-garden"
+func Slugify(name string) string {
+	return strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// Validate validates the category entity
+func (c *Category) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return NewValidationError("name", "required", ErrCategoryNameRequired)
+	}
+	if len(c.Name) > 100 {
+		return NewValidationError("name", "max", ErrCategoryNameTooLong)
+	}
+	if !slugPattern.MatchString(c.Slug) || len(c.Slug) > 120 {
+		return NewValidationError("slug", "slug", ErrCategorySlugInvalid)
+	}
+	if c.ParentID != nil && *c.ParentID == c.ID && c.ID != 0 {
+		return NewValidationError("parent_id", "cycle", ErrCategoryCycle)
+	}
+	return nil
+}
@@ -4,13 +4,45 @@ import "errors"
 
 // Product-related errors
 var (
-	ErrProductNotFound        = errors.New("product not found")
-	ErrProductNameRequired    = errors.New("product name is required")
-	ErrProductNameTooShort    = errors.New("product name must be at least 3 characters")
-	ErrProductNameTooLong     = errors.New("product name must be less than 255 characters")
-	ErrProductPriceInvalid    = errors.New("product price must be greater than or equal to 0")
-	ErrProductStockInvalid    = errors.New("product stock must be greater than or equal to 0")
-	ErrProductAlreadyExists   = errors.New("product with this name already exists")
+	ErrProductNotFound          = errors.New("product not found")
+	ErrProductNameRequired      = errors.New("product name is required")
+	ErrProductNameTooShort      = errors.New("product name must be at least 3 characters")
+	ErrProductNameTooLong       = errors.New("product name must be less than 255 characters")
+	ErrProductPriceInvalid      = errors.New("product price must be greater than or equal to 0")
+	ErrProductStockInvalid      = errors.New("product stock must be greater than or equal to 0")
+	ErrLowStockThresholdInvalid = errors.New("product low stock threshold must be greater than or equal to 0")
+	ErrProductAlreadyExists     = errors.New("product with this name already exists")
+	ErrProductHasReferences     = errors.New("product is referenced by other records")
+	ErrInsufficientStock        = errors.New("insufficient stock")
+	ErrSearchQueryTooShort      = errors.New("search query is too short")
+	ErrSearchTooDeep            = errors.New("search results page is too deep")
+	ErrProductImageNotFound     = errors.New("product image not found")
+	ErrProductPriceEnding       = errors.New("product price does not have the required ending")
+	ErrProductImageURLInvalid   = errors.New("product image URL must be an absolute http or https URL")
+	ErrImageUnreachable         = errors.New("product image URL is not reachable or not an image")
+	ErrProductSKUExists         = errors.New("product with this SKU already exists")
+	ErrProductSKUInvalid        = errors.New("product SKU has an invalid format")
+	ErrConcurrentModification   = errors.New("product was changed since it was read, reload it and retry")
+)
+
+// Category-related errors
+var (
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrCategoryNameRequired   = errors.New("category name is required")
+	ErrCategoryNameTooLong    = errors.New("category name must be at most 100 characters")
+	ErrCategorySlugInvalid    = errors.New("category slug must be lowercase letters, digits and single hyphens")
+	ErrCategoryAlreadyExists  = errors.New("category with this name or slug already exists")
+	ErrCategoryCycle          = errors.New("category cannot be nested under itself or its descendants")
+	ErrCategoryInUse          = errors.New("category has subcategories or products")
+)
+
+// Webhook-related errors
+var (
+	ErrWebhookNotFound        = errors.New("webhook not found")
+	ErrWebhookURLInvalid      = errors.New("webhook URL must be an absolute http or https URL")
+	ErrWebhookSecretInvalid   = errors.New("webhook secret must be between 16 and 255 characters")
+	ErrWebhookEventsRequired  = errors.New("webhook must subscribe to at least one event")
+	ErrWebhookEventUnknown    = errors.New("webhook event type is not known")
 )
 
 // User-related errors
@@ -21,10 +53,27 @@ var (
 	ErrUserUsernameTooShort   = errors.New("username must be at least 3 characters")
 	ErrUserUsernameTooLong    = errors.New("username must be less than 50 characters")
 	ErrUserAlreadyExists      = errors.New("user with this email or username already exists")
+	ErrEmailAlreadyExists     = errors.New("an account with this email already exists")
+	ErrUsernameAlreadyExists  = errors.New("this username is already taken")
 	ErrInvalidCredentials     = errors.New("invalid email or password")
 	ErrUserInactive           = errors.New("user account is inactive")
 	ErrUnauthorized           = errors.New("unauthorized access")
 	ErrInvalidToken           = errors.New("invalid or expired token")
+	ErrEmailNotVerified       = errors.New("email address has not been verified")
+	ErrBadVerificationToken   = errors.New("verification token is invalid or expired")
+	ErrBadPasswordResetToken  = errors.New("password reset token is invalid or expired")
+	ErrTooManyPasswordResets  = errors.New("too many password reset requests, please retry later")
+	ErrUserEmailConflict      = errors.New("an active user already uses this email")
+	ErrUserUsernameConflict   = errors.New("an active user already uses this username")
+	ErrUserIDsRequired        = errors.New("at least one user ID is required")
+	ErrTooManyUserIDs         = errors.New("too many user IDs in one request")
+	ErrCannotModifySelf       = errors.New("admins cannot delete or deactivate their own account")
+	ErrPasswordTooShort       = errors.New("password is too short")
+	ErrPasswordMissingLower   = errors.New("password must contain a lowercase letter")
+	ErrPasswordMissingUpper   = errors.New("password must contain an uppercase letter")
+	ErrPasswordMissingDigit   = errors.New("password must contain a digit")
+	ErrPasswordMissingSymbol  = errors.New("password must contain a symbol")
+	ErrPasswordTooCommon      = errors.New("password is too common, please choose another")
 )
 
 // General errors
@@ -33,4 +82,8 @@ var (
 	ErrInvalidInput           = errors.New("invalid input data")
 	ErrDatabaseConnection     = errors.New("database connection error")
 	ErrValidationFailed       = errors.New("validation failed")
+	ErrTooManyImports         = errors.New("too many imports in progress")
+	ErrImportJobNotFound      = errors.New("import job not found")
+	ErrSearchReindexRunning   = errors.New("a search reindex is already running")
+	ErrReadOnlyMode           = errors.New("service is in read-only mode")
 )
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// PriceHistory records a change of a product's price
+type PriceHistory struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	ProductID uint      `json:"product_id" gorm:"not null;index:idx_price_history_product"`
+	OldPrice  float64   `json:"old_price" gorm:"type:decimal(10,2);not null"`
+	NewPrice  float64   `json:"new_price" gorm:"type:decimal(10,2);not null"`
+	ChangedBy *uint     `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at" gorm:"not null;index:idx_price_history_product"`
+}
+
+// TableName returns the table name for PriceHistory entity
+func (PriceHistory) TableName() string {
+	return "price_history"
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"net/url"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,19 +9,35 @@ import (
 
 // Product represents a product entity in the domain layer
 type Product struct {
-	ID          uint           `json:"id" gorm:"primarykey"`
-	Name        string         `json:"name" gorm:"size:255;not null" validate:"required,min=3,max=255"`
-	Description string         `json:"description" gorm:"type:text"`
-	Price       float64        `json:"price" gorm:"type:decimal(10,2);not null" validate:"required,min=0"`
-	Stock       int            `json:"stock" gorm:"default:0" validate:"min=0"`
-	Category    string         `json:"category" gorm:"size:100"`
-	ImageURL    string         `json:"image_url" gorm:"size:500"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                uint           `json:"id" gorm:"primarykey"`
+	Name              string         `json:"name" gorm:"size:255;not null" validate:"required,min=3,max=255"`
+	SKU               *string        `json:"sku" gorm:"column:sku;size:64"` // unique among live products, see migrateProductSKU
+	Description       string         `json:"description" gorm:"type:text"`
+	Price             float64        `json:"price" gorm:"type:decimal(10,2);not null" validate:"required,min=0"`
+	Stock             int            `json:"stock" gorm:"default:0" validate:"min=0"`                         // units available to sell, excluding Reserved
+	Reserved          int            `json:"reserved" gorm:"not null;default:0"`                              // units held back by reservations, see Reservation
+	LowStockThreshold int            `json:"low_stock_threshold" gorm:"not null;default:10" validate:"min=0"` // stock at or below this counts as running low
+	Category          string         `json:"category" gorm:"size:100"`                                        // free-text name, kept while products move to CategoryID
+	CategoryID        *uint          `json:"category_id" gorm:"index"`
+	ImageURL          string         `json:"image_url" gorm:"size:500"`               // mirrors the primary image for older clients
+	ImageReports      int            `json:"image_reports" gorm:"not null;default:0"` // client reports of a broken image since the last review
+	ImageReportedAt   *time.Time     `json:"image_reported_at"`
+	NeedsReview       bool           `json:"needs_review" gorm:"not null;default:false;index"`
+	IsActive          bool           `json:"is_active" gorm:"default:true"`
+	CreatedBy         *uint          `json:"created_by,omitempty"`              // user who created the product, nil when unknown
+	UpdatedBy         *uint          `json:"updated_by,omitempty"`              // user who last saved the product's details; stock and status changes don't count
+	Version           int            `json:"version" gorm:"not null;default:1"` // incremented on every change; updates must be based on the current version
+	CreatedAt         time.Time      `json:"created_at" gorm:"index"`
+	UpdatedAt         time.Time      `json:"updated_at" gorm:"index"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+	Images            []ProductImage `json:"images,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Tags              []ProductTag   `json:"tags,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Rank              *float64       `json:"rank,omitempty" gorm:"column:search_rank;->;-:migration"` // search relevance, only set on full-text search results
 }
 
+// DefaultLowStockThreshold is the low stock threshold given to new products
+const DefaultLowStockThreshold = 10
+
 // TableName returns the table name for Product entity
 func (Product) TableName() string {
 	return "products"
@@ -44,19 +61,39 @@ func (p *Product) BeforeUpdate(tx *gorm.DB) error {
 // Validate performs basic validation on the product entity
 func (p *Product) Validate() error {
 	if p.Name == "" {
-		return ErrProductNameRequired
+		return NewValidationError("name", "required", ErrProductNameRequired)
 	}
 	if len(p.Name) < 3 {
-		return ErrProductNameTooShort
+		return NewValidationError("name", "min", ErrProductNameTooShort)
 	}
 	if len(p.Name) > 255 {
-		return ErrProductNameTooLong
+		return NewValidationError("name", "max", ErrProductNameTooLong)
 	}
 	if p.Price < 0 {
-		return ErrProductPriceInvalid
+		return NewValidationError("price", "min", ErrProductPriceInvalid)
 	}
 	if p.Stock < 0 {
-		return ErrProductStockInvalid
+		return NewValidationError("stock", "min", ErrProductStockInvalid)
+	}
+	if p.LowStockThreshold < 0 {
+		return NewValidationError("low_stock_threshold", "min", ErrLowStockThresholdInvalid)
+	}
+	if p.ImageURL != "" && !isAbsoluteHTTPURL(p.ImageURL) {
+		return NewValidationError("image_url", "url", ErrProductImageURLInvalid)
 	}
 	return nil
 }
+
+// IsLowStock reports whether the product's stock is at or below its low stock threshold
+func (p *Product) IsLowStock() bool {
+	return p.Stock <= p.LowStockThreshold
+}
+
+// isAbsoluteHTTPURL reports whether raw is an absolute http or https URL with a host
+func isAbsoluteHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// ProductImage represents one image in a product's gallery
+type ProductImage struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	ProductID uint      `json:"product_id" gorm:"not null;index"`
+	URL       string    `json:"url" gorm:"size:500;not null"`
+	AltText   string    `json:"alt_text" gorm:"size:255"`
+	SortOrder int       `json:"sort_order" gorm:"not null;default:0"`
+	IsPrimary bool      `json:"is_primary" gorm:"not null;default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for ProductImage entity
+func (ProductImage) TableName() string {
+	return "product_images"
+}
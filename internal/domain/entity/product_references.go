@@ -0,0 +1,29 @@
+package entity
+
+// ProductReferences maps a kind of related record (e.g. "images") to the number of
+// records of that kind pointing at a product
+type ProductReferences map[string]int64
+
+// Total returns the total number of referencing records
+func (r ProductReferences) Total() int64 {
+	var total int64
+	for _, count := range r {
+		total += count
+	}
+	return total
+}
+
+// ProductReferencedError is returned when deleting a product that other records still reference
+type ProductReferencedError struct {
+	References ProductReferences
+}
+
+// Error implements the error interface
+func (e *ProductReferencedError) Error() string {
+	return ErrProductHasReferences.Error()
+}
+
+// Unwrap allows errors.Is to match ErrProductHasReferences
+func (e *ProductReferencedError) Unwrap() error {
+	return ErrProductHasReferences
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Limits on product tags
+const (
+	MaxProductTags   = 20 // tags a single product may have
+	MaxProductTagLen = 50 // characters in a tag
+)
+
+// ProductTag attaches a free-form label such as "sale" or "new" to a product. Tags are
+// stored normalized (see NormalizeTag) and serialized as plain strings.
+type ProductTag struct {
+	ProductID uint   `gorm:"primaryKey"`
+	Tag       string `gorm:"primaryKey;size:50;index"`
+}
+
+// TableName returns the table name for ProductTag entity
+func (ProductTag) TableName() string {
+	return "product_tags"
+}
+
+// MarshalJSON encodes the tag as its name
+func (t ProductTag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Tag)
+}
+
+// UnmarshalJSON decodes a tag from its name
+func (t *ProductTag) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &t.Tag)
+}
+
+// NormalizeTag trims and lower-cases a tag so tags match regardless of case
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// TagNames returns the names of the product's tags
+func (p *Product) TagNames() []string {
+	names := make([]string, len(p.Tags))
+	for i, tag := range p.Tags {
+		names[i] = tag.Tag
+	}
+	return names
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProductValidateImageURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"", true},
+		{"https://cdn.example.com/lamp.png", true},
+		{"http://cdn.example.com/lamp.png?size=large", true},
+		{"/images/lamp.png", false},
+		{"cdn.example.com/lamp.png", false},
+		{"ftp://cdn.example.com/lamp.png", false},
+		{"javascript:alert(1)", false},
+		{"https:///lamp.png", false},
+		{"https://cdn.example.com/%zz", false},
+	}
+	for _, tt := range tests {
+		product := &Product{Name: "Desk Lamp", Price: 10, ImageURL: tt.url}
+		err := product.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("%q: got %v, want it accepted", tt.url, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrProductImageURLInvalid) {
+			t.Errorf("%q: got %v, want %v", tt.url, err, ErrProductImageURLInvalid)
+		}
+	}
+}
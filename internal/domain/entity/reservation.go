@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Reservation holds back part of a product's stock for an owner, such as a cart, until it
+// expires. Reserving moves the quantity from the product's Stock to its Reserved units;
+// releasing an expired reservation moves it back and removes the reservation.
+type Reservation struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	ProductID uint      `json:"product_id" gorm:"not null;index"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+	OwnerID   string    `json:"owner_id" gorm:"size:255;not null"` // cart or client holding the stock
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for Reservation entity
+func (Reservation) TableName() string {
+	return "reservations"
+}
@@ -9,18 +9,24 @@ import (
 
 // User represents a user entity in the domain layer
 type User struct {
-	ID          uint           `json:"id" gorm:"primarykey"`
-	Email       string         `json:"email" gorm:"uniqueIndex;size:255;not null" validate:"required,email"`
-	Username    string         `json:"username" gorm:"uniqueIndex;size:50;not null" validate:"required,min=3,max=50"`
-	Password    string         `json:"-" gorm:"size:255;not null"`
-	FirstName   string         `json:"first_name" gorm:"size:100"`
-	LastName    string         `json:"last_name" gorm:"size:100"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	IsAdmin     bool           `json:"is_admin" gorm:"default:false"`
-	LastLoginAt *time.Time     `json:"last_login_at"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                         uint           `json:"id" gorm:"primarykey"`
+	Email                      string         `json:"email" gorm:"uniqueIndex;size:255;not null" validate:"required,email"`
+	Username                   string         `json:"username" gorm:"uniqueIndex;size:50;not null" validate:"required,min=3,max=50"`
+	Password                   string         `json:"-" gorm:"size:255;not null"`
+	FirstName                  string         `json:"first_name" gorm:"size:100"`
+	LastName                   string         `json:"last_name" gorm:"size:100"`
+	IsActive                   bool           `json:"is_active" gorm:"default:true"`
+	IsAdmin                    bool           `json:"is_admin" gorm:"default:false"`
+	LastLoginAt                *time.Time     `json:"last_login_at"`
+	EmailVerified              bool           `json:"email_verified" gorm:"default:false"`
+	EmailVerifiedAt            *time.Time     `json:"email_verified_at"`
+	VerificationTokenHash      string         `json:"-" gorm:"size:64;index"` // SHA-256 of the outstanding verification token
+	VerificationTokenExpiresAt *time.Time     `json:"-"`
+	ResetTokenHash             string         `json:"-" gorm:"size:64;index"` // SHA-256 of the outstanding password reset token
+	ResetTokenExpiresAt        *time.Time     `json:"-"`
+	CreatedAt                  time.Time      `json:"created_at"`
+	UpdatedAt                  time.Time      `json:"updated_at"`
+	DeletedAt                  gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // TableName returns the table name for User entity
@@ -43,9 +49,9 @@ func (u *User) BeforeUpdate(tx *gorm.DB) error {
 	return nil
 }
 
-// HashPassword hashes the user's password
-func (u *User) HashPassword(password string) error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// HashPassword hashes the user's password with the given bcrypt cost
+func (u *User) HashPassword(password string, cost int) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 	if err != nil {
 		return err
 	}
@@ -53,11 +59,37 @@ func (u *User) HashPassword(password string) error {
 	return nil
 }
 
+// ClearResetToken consumes the user's outstanding password reset token, if any
+func (u *User) ClearResetToken() {
+	u.ResetTokenHash = ""
+	u.ResetTokenExpiresAt = nil
+}
+
 // CheckPassword checks if the provided password matches the user's password
 func (u *User) CheckPassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
 
+// NeedsRehash reports whether the stored password hash uses a lower bcrypt cost than cost
+func (u *User) NeedsRehash(cost int) bool {
+	hashCost, err := bcrypt.Cost([]byte(u.Password))
+	return err == nil && hashCost < cost
+}
+
+// MarkEmailVerified marks the account's email as verified, consuming its verification token.
+// It leaves IsActive alone: only admins activate and deactivate accounts.
+func (u *User) MarkEmailVerified(at time.Time) {
+	u.EmailVerified = true
+	u.EmailVerifiedAt = &at
+	u.ClearVerificationToken()
+}
+
+// ClearVerificationToken revokes the user's outstanding email verification token, if any
+func (u *User) ClearVerificationToken() {
+	u.VerificationTokenHash = ""
+	u.VerificationTokenExpiresAt = nil
+}
+
 // GetFullName returns the user's full name
 func (u *User) GetFullName() string {
 	if u.FirstName == "" && u.LastName == "" {
@@ -69,16 +101,16 @@ func (u *User) GetFullName() string {
 // Validate performs basic validation on the user entity
 func (u *User) Validate() error {
 	if u.Email == "" {
-		return ErrUserEmailRequired
+		return NewValidationError("email", "required", ErrUserEmailRequired)
 	}
 	if u.Username == "" {
-		return ErrUserUsernameRequired
+		return NewValidationError("username", "required", ErrUserUsernameRequired)
 	}
 	if len(u.Username) < 3 {
-		return ErrUserUsernameTooShort
+		return NewValidationError("username", "min", ErrUserUsernameTooShort)
 	}
 	if len(u.Username) > 50 {
-		return ErrUserUsernameTooLong
+		return NewValidationError("username", "max", ErrUserUsernameTooLong)
 	}
 	return nil
 }
@@ -0,0 +1,50 @@
+package entity
+
+import "strings"
+
+// FieldViolation describes a single failed validation rule on a field
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError carries field-level detail about why input failed validation.
+// It matches ErrValidationFailed with errors.Is, as well as the sentinel error it was
+// built from (e.g. ErrProductNameRequired), so existing error checks keep working.
+type ValidationError struct {
+	Violations []FieldViolation
+	cause      error
+}
+
+// NewValidationError creates a validation error for a single field from a sentinel error
+func NewValidationError(field, rule string, cause error) *ValidationError {
+	return &ValidationError{
+		Violations: []FieldViolation{{Field: field, Rule: rule, Message: cause.Error()}},
+		cause:      cause,
+	}
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	if e.cause != nil {
+		return e.cause.Error()
+	}
+
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	if len(messages) == 0 {
+		return ErrValidationFailed.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap allows errors.Is to match both ErrValidationFailed and the underlying sentinel
+func (e *ValidationError) Unwrap() []error {
+	if e.cause != nil {
+		return []error{ErrValidationFailed, e.cause}
+	}
+	return []error{ErrValidationFailed}
+}
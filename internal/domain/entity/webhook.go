@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"   // waiting for its first or next attempt
+	WebhookDeliverySucceeded = "succeeded" // the endpoint answered with a 2xx status
+	WebhookDeliveryFailed    = "failed"    // rejected by the endpoint or out of attempts
+)
+
+// WebhookEvents lists the event types a webhook subscribes to, stored as JSON
+type WebhookEvents []string
+
+// Value implements driver.Valuer
+func (e WebhookEvents) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (e *WebhookEvents) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type %T for webhook events", value)
+	}
+	return json.Unmarshal(data, e)
+}
+
+// Webhook is an endpoint that is sent a signed POST whenever one of its subscribed events happens
+type Webhook struct {
+	ID         uint              `json:"id" gorm:"primarykey"`
+	URL        string            `json:"url" gorm:"size:2048;not null"`
+	Secret     string            `json:"-" gorm:"size:255;not null"` // signs payloads; never returned once set
+	Events     WebhookEvents     `json:"events" gorm:"type:text;not null"`
+	IsActive   bool              `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	Deliveries []WebhookDelivery `json:"-" gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for Webhook entity
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// Validate validates the webhook entity
+func (w *Webhook) Validate() error {
+	if !isAbsoluteHTTPURL(w.URL) || len(w.URL) > 2048 {
+		return NewValidationError("url", "url", ErrWebhookURLInvalid)
+	}
+	if len(w.Secret) < 16 || len(w.Secret) > 255 {
+		return NewValidationError("secret", "min", ErrWebhookSecretInvalid)
+	}
+	if len(w.Events) == 0 {
+		return NewValidationError("events", "required", ErrWebhookEventsRequired)
+	}
+	return nil
+}
+
+// Subscribes reports whether the webhook is sent events of eventType
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records one event being sent to a webhook, including its retries
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primarykey"`
+	WebhookID      uint       `json:"webhook_id" gorm:"not null;index"`
+	EventType      string     `json:"event_type" gorm:"size:50;not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"size:20;not null;index:idx_webhook_deliveries_due"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index:idx_webhook_deliveries_due"`
+	ResponseStatus *int       `json:"response_status,omitempty"` // status code of the last attempt, if it got a response
+	LastError      string     `json:"last_error,omitempty" gorm:"size:1000"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for WebhookDelivery entity
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
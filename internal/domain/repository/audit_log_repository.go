@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// AuditLogRepository defines the interface for audit log repository operations
+type AuditLogRepository interface {
+	// Create records an audit entry
+	Create(ctx context.Context, auditLog *entity.AuditLog) error
+
+	// ListByEntity retrieves the audit entries of an entity, oldest first
+	ListByEntity(ctx context.Context, entityType string, entityID uint) ([]*entity.AuditLog, error)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// CategoryRepository defines the interface for category repository operations
+type CategoryRepository interface {
+	// Create creates a new category
+	Create(ctx context.Context, category *entity.Category) error
+
+	// GetByID retrieves a category by its ID
+	GetByID(ctx context.Context, id uint) (*entity.Category, error)
+
+	// GetByName retrieves a category by its name, compared case-insensitively
+	GetByName(ctx context.Context, name string) (*entity.Category, error)
+
+	// GetAll retrieves every category ordered by name
+	GetAll(ctx context.Context) ([]*entity.Category, error)
+
+	// Update updates an existing category
+	Update(ctx context.Context, category *entity.Category) error
+
+	// Delete permanently deletes a category by its ID
+	Delete(ctx context.Context, id uint) error
+
+	// ExistsByNameOrSlug checks if a category other than excludeID uses the given name or slug.
+	// Names are compared case-insensitively.
+	ExistsByNameOrSlug(ctx context.Context, name, slug string, excludeID uint) (bool, error)
+
+	// CountChildren returns the number of direct subcategories of a category
+	CountChildren(ctx context.Context, id uint) (int64, error)
+
+	// CountProducts returns the number of products assigned to a category
+	CountProducts(ctx context.Context, id uint) (int64, error)
+}
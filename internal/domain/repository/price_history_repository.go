@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// PriceHistoryRepository defines the interface for price history repository operations
+type PriceHistoryRepository interface {
+	// Create records a price change
+	Create(ctx context.Context, entry *entity.PriceHistory) error
+
+	// ListByProduct retrieves the price changes of a product, oldest first
+	ListByProduct(ctx context.Context, productID uint) ([]*entity.PriceHistory, error)
+}
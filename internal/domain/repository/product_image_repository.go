@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// ProductImageRepository defines the interface for product image repository operations
+type ProductImageRepository interface {
+	// Create adds an image to a product's gallery
+	Create(ctx context.Context, image *entity.ProductImage) error
+
+	// ListByProduct retrieves a product's images in display order
+	ListByProduct(ctx context.Context, productID uint) ([]*entity.ProductImage, error)
+
+	// Delete removes an image from a product's gallery
+	Delete(ctx context.Context, productID, imageID uint) error
+
+	// DeleteByProduct removes every image of a product
+	DeleteByProduct(ctx context.Context, productID uint) error
+
+	// SetSortOrders sets each image's sort order to its position in imageIDs
+	SetSortOrders(ctx context.Context, productID uint, imageIDs []uint) error
+
+	// SetPrimary makes imageID the product's only primary image
+	SetPrimary(ctx context.Context, productID, imageID uint) error
+}
@@ -2,17 +2,80 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/product-management/internal/domain/entity"
 )
 
 // ProductFilter represents filtering criteria for products
 type ProductFilter struct {
-	Category    string
+	Categories  []string // matches products in any of these categories, empty for all
+	Tags        []string // distinct normalized tags products must have, empty for all
+	TagMatch    string   // TagMatchAll (the default) or TagMatchAny
 	MinPrice    *float64
 	MaxPrice    *float64
 	IsActive    *bool
 	SearchTerm  string // for searching in name or description
+	Relevance   string // how search matches are scored, one of SearchRelevanceModes, empty for weighted
+	MinScore    *float64 // drops search matches scoring below this, nil to keep every match
+	OrderBy     string // column to sort by, must be one of ProductOrderColumns
+	OrderDir    string // "asc" or "desc"
+	CreatedFrom *time.Time // inclusive bounds on created_at, either may be nil
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time // inclusive bounds on updated_at, either may be nil
+	UpdatedTo   *time.Time
+}
+
+// ProductOrderColumns lists the columns products may be sorted by
+var ProductOrderColumns = map[string]bool{
+	"name":       true,
+	"price":      true,
+	"stock":      true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// Search relevance modes. Weighted ranks name matches above description matches and exact
+// name matches above partial ones; text uses the plain full-text rank.
+const (
+	SearchRelevanceWeighted = "weighted"
+	SearchRelevanceText     = "text"
+)
+
+// SearchRelevanceModes lists the accepted search relevance modes
+var SearchRelevanceModes = map[string]bool{
+	SearchRelevanceWeighted: true,
+	SearchRelevanceText:     true,
+}
+
+// How ProductFilter.Tags are matched: products must have every tag, or at least one of them
+const (
+	TagMatchAll = "all"
+	TagMatchAny = "any"
+)
+
+// Default ordering applied when the filter does not specify one
+const (
+	DefaultProductOrderBy  = "created_at"
+	DefaultProductOrderDir = "desc"
+)
+
+// ProductIDPartition splits requested product IDs by whether they refer to a live product
+type ProductIDPartition struct {
+	Matched     []uint
+	SoftDeleted []uint
+	NotFound    []uint
+}
+
+// ProductStats summarizes the products matching a filter. Every figure is zero, and
+// ByCategory empty, when nothing matches.
+type ProductStats struct {
+	Total          int64            `json:"total"`
+	Active         int64            `json:"active"`
+	Inactive       int64            `json:"inactive"`
+	InventoryValue float64          `json:"inventory_value"` // sum of price * stock
+	AveragePrice   float64          `json:"average_price"`
+	ByCategory     map[string]int64 `json:"by_category"` // product count per free-text category, "" for none
 }
 
 // ProductRepository defines the interface for product repository operations
@@ -23,13 +86,20 @@ type ProductRepository interface {
 	// GetByID retrieves a product by its ID
 	GetByID(ctx context.Context, id uint) (*entity.Product, error)
 	
+	// GetByIDs retrieves the live products among ids, in no particular order
+	GetByIDs(ctx context.Context, ids []uint) ([]*entity.Product, error)
+	
 	// GetAll retrieves all products with optional filtering and pagination
 	GetAll(ctx context.Context, filter *ProductFilter, offset, limit int) ([]*entity.Product, error)
 	
+	// GetAfterID retrieves products with an ID greater than afterID in ascending ID order (keyset pagination)
+	GetAfterID(ctx context.Context, filter *ProductFilter, afterID uint, limit int) ([]*entity.Product, error)
+	
 	// GetTotalCount returns the total count of products with optional filtering
 	GetTotalCount(ctx context.Context, filter *ProductFilter) (int64, error)
 	
-	// Update updates an existing product
+	// Update updates an existing product, returning entity.ErrConcurrentModification if it
+	// is no longer at product.Version
 	Update(ctx context.Context, product *entity.Product) error
 	
 	// Delete soft-deletes a product by its ID
@@ -38,18 +108,105 @@ type ProductRepository interface {
 	// HardDelete permanently deletes a product by its ID
 	HardDelete(ctx context.Context, id uint) error
 	
+	// GetBySKU retrieves a live product by its SKU
+	GetBySKU(ctx context.Context, sku string) (*entity.Product, error)
+	
+	// NextSKUSequence returns the next value of the sequence used to number generated SKUs
+	NextSKUSequence(ctx context.Context) (int64, error)
+	
 	// GetByName retrieves a product by its name
 	GetByName(ctx context.Context, name string) (*entity.Product, error)
 	
-	// ExistsByName checks if a product with the given name exists
+	// ExistsByName checks if a live product with the given name exists. Soft-deleted products
+	// don't count, so their names can be reused.
 	ExistsByName(ctx context.Context, name string) (bool, error)
 	
-	// GetByCategory retrieves products by category
-	GetByCategory(ctx context.Context, category string, offset, limit int) ([]*entity.Product, error)
+	// GetByNameInCategory retrieves the live product with the given name in category
+	GetByNameInCategory(ctx context.Context, name, category string) (*entity.Product, error)
+	
+	// ExistsByNameInCategory checks if a live product other than excludeID has the given name
+	// in category, for deployments where names only need to be unique within a category
+	ExistsByNameInCategory(ctx context.Context, name, category string, excludeID uint) (bool, error)
+	
+	// ExistsByNameIncludingDeleted checks if any product, live or soft-deleted, other than
+	// excludeID has the given name, e.g. to detect conflicts before restoring a product
+	ExistsByNameIncludingDeleted(ctx context.Context, name string, excludeID uint) (bool, error)
+	
+	// SuggestNames returns names of products similar to term, most similar first
+	SuggestNames(ctx context.Context, term string, limit int) ([]string, error)
+	
+	// GetByCategory retrieves active products in any of the given categories
+	GetByCategory(ctx context.Context, categories []string, offset, limit int) ([]*entity.Product, error)
+	
+	// GetLowStock retrieves active products whose stock is at or below their low stock
+	// threshold, lowest stock first
+	GetLowStock(ctx context.Context, offset, limit int) ([]*entity.Product, error)
+	
+	// CountLowStock returns the number of active products that GetLowStock would return
+	CountLowStock(ctx context.Context) (int64, error)
+	
+	// GetImageReports retrieves products with reports of a broken image, those flagged for
+	// review first, then the most reported
+	GetImageReports(ctx context.Context, offset, limit int) ([]*entity.Product, error)
+	
+	// CountImageReports returns the number of products that GetImageReports would return
+	CountImageReports(ctx context.Context) (int64, error)
+	
+	// GetStats aggregates the products matching the filter without loading them
+	GetStats(ctx context.Context, filter *ProductFilter) (*ProductStats, error)
 	
 	// UpdateStock updates the stock quantity of a product
 	UpdateStock(ctx context.Context, id uint, stock int) error
 	
+	// DecrementStock atomically subtracts qty from a product's stock, failing with
+	// entity.ErrInsufficientStock rather than letting stock go negative. It returns the
+	// product as the same statement left it.
+	DecrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error)
+	
+	// IncrementStock atomically adds qty to a product's stock and returns the product as the
+	// same statement left it
+	IncrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error)
+
+	// ReserveStock atomically moves qty units of a product's stock to its reserved units,
+	// failing with entity.ErrInsufficientStock rather than letting stock go negative. It
+	// returns the product as the same statement left it.
+	ReserveStock(ctx context.Context, id uint, qty int) (*entity.Product, error)
+
+	// ReleaseReservedStock atomically moves qty of a product's reserved units back to its
+	// stock, undoing ReserveStock, and returns the product as the same statement left it
+	ReleaseReservedStock(ctx context.Context, id uint, qty int) (*entity.Product, error)
+	
+	// ReportBrokenImage atomically counts a report that a product's image is broken and
+	// flags the product for review once it has threshold reports
+	ReportBrokenImage(ctx context.Context, id uint, threshold int) error
+	
+	// ClearImageReports resets a product's broken image reports and its review flag
+	ClearImageReports(ctx context.Context, id uint) error
+	
+	// CountReferences counts the records of each related kind that reference a product
+	CountReferences(ctx context.Context, id uint) (entity.ProductReferences, error)
+	
+	// PartitionIDs reports which of the given IDs belong to live, soft-deleted or no products,
+	// preserving the order of ids within each group
+	PartitionIDs(ctx context.Context, ids []uint) (*ProductIDPartition, error)
+	
 	// BulkUpdateStatus updates the active status of multiple products
 	BulkUpdateStatus(ctx context.Context, ids []uint, isActive bool) error
+	
+	// GetDeleted retrieves soft-deleted products, most recently deleted first
+	GetDeleted(ctx context.Context, offset, limit int) ([]*entity.Product, error)
+	
+	// CountDeleted returns the number of soft-deleted products
+	CountDeleted(ctx context.Context) (int64, error)
+	
+	// RefreshSearchVectors recomputes the search vectors of up to limit products, deleted ones
+	// included, with IDs above afterID. It returns how many it refreshed and the highest ID
+	// among them to continue from; refreshing none means every product has been done.
+	RefreshSearchVectors(ctx context.Context, afterID uint, limit int) (count int, lastID uint, err error)
+	
+	// GetSearchIndexVersion returns the search index version the stored vectors were computed with
+	GetSearchIndexVersion(ctx context.Context) (int, error)
+	
+	// SetSearchIndexVersion records that the stored search vectors were computed with version
+	SetSearchIndexVersion(ctx context.Context, version int) error
 }
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+)
+
+// ProductTagRepository defines the interface for product tag repository operations
+type ProductTagRepository interface {
+	// SetTags replaces a product's tags with the given normalized tags
+	SetTags(ctx context.Context, productID uint, tags []string) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// ReservationRepository defines the interface for stock reservation repository operations
+type ReservationRepository interface {
+	// Create records a reservation
+	Create(ctx context.Context, reservation *entity.Reservation) error
+
+	// ListActiveByProduct retrieves the reservations of a product that expire after now,
+	// soonest to expire first
+	ListActiveByProduct(ctx context.Context, productID uint, now time.Time) ([]*entity.Reservation, error)
+
+	// LockStale retrieves up to limit reservations that expired at or before now or were
+	// created at or before createdBefore, and locks them for the rest of the transaction in
+	// ctx. Reservations locked by another transaction are skipped, so concurrent callers never
+	// claim the same reservation.
+	LockStale(ctx context.Context, now, createdBefore time.Time, limit int) ([]*entity.Reservation, error)
+
+	// Delete removes a reservation
+	Delete(ctx context.Context, id uint) error
+}
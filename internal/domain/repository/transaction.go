@@ -0,0 +1,15 @@
+package repository
+
+import "context"
+
+// TxManager runs a unit of work inside a single transaction. Repository calls made with the
+// context passed to fn take part in the transaction.
+type TxManager interface {
+	// WithinTransaction commits if fn returns nil and rolls back otherwise. Nested calls run
+	// in a savepoint of the enclosing transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// WithinSnapshot runs fn inside a read-only repeatable-read transaction, so every query
+	// made with the context passed to fn sees the same snapshot of the database
+	WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error
+}
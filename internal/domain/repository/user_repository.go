@@ -21,12 +21,18 @@ type UserRepository interface {
 	// GetByID retrieves a user by their ID
 	GetByID(ctx context.Context, id uint) (*entity.User, error)
 	
-	// GetByEmail retrieves a user by their email
+	// GetByEmail retrieves a user by their email, ignoring case and surrounding whitespace
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	
-	// GetByUsername retrieves a user by their username
+	// GetByUsername retrieves a user by their username, ignoring case and surrounding whitespace
 	GetByUsername(ctx context.Context, username string) (*entity.User, error)
 	
+	// GetByVerificationTokenHash retrieves the user holding the given email verification token hash
+	GetByVerificationTokenHash(ctx context.Context, tokenHash string) (*entity.User, error)
+	
+	// GetByResetTokenHash retrieves the user holding the given password reset token hash
+	GetByResetTokenHash(ctx context.Context, tokenHash string) (*entity.User, error)
+	
 	// GetAll retrieves all users with optional filtering and pagination
 	GetAll(ctx context.Context, filter *UserFilter, offset, limit int) ([]*entity.User, error)
 	
@@ -42,10 +48,10 @@ type UserRepository interface {
 	// HardDelete permanently deletes a user by their ID
 	HardDelete(ctx context.Context, id uint) error
 	
-	// ExistsByEmail checks if a user with the given email exists
+	// ExistsByEmail checks if a user with the given email exists, ignoring case and surrounding whitespace
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	
-	// ExistsByUsername checks if a user with the given username exists
+	// ExistsByUsername checks if a user with the given username exists, ignoring case and surrounding whitespace
 	ExistsByUsername(ctx context.Context, username string) (bool, error)
 	
 	// UpdateLastLogin updates the last login time for a user
@@ -56,4 +62,16 @@ type UserRepository interface {
 	
 	// GetAdminUsers retrieves all admin users
 	GetAdminUsers(ctx context.Context) ([]*entity.User, error)
+	
+	// GetDeletedByID retrieves a soft-deleted user by their ID
+	GetDeletedByID(ctx context.Context, id uint) (*entity.User, error)
+	
+	// GetDeleted retrieves soft-deleted users, most recently deleted first
+	GetDeleted(ctx context.Context, offset, limit int) ([]*entity.User, error)
+	
+	// CountDeleted returns the number of soft-deleted users
+	CountDeleted(ctx context.Context) (int64, error)
+	
+	// RestoreUser clears the soft-delete of a user
+	RestoreUser(ctx context.Context, id uint) error
 }
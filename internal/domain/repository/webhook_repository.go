@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// WebhookRepository defines the interface for webhook and webhook delivery operations
+type WebhookRepository interface {
+	// Create creates a new webhook
+	Create(ctx context.Context, webhook *entity.Webhook) error
+
+	// GetByID retrieves a webhook by its ID
+	GetByID(ctx context.Context, id uint) (*entity.Webhook, error)
+
+	// GetAll retrieves every webhook ordered by ID
+	GetAll(ctx context.Context) ([]*entity.Webhook, error)
+
+	// GetActive retrieves the webhooks that are currently receiving events
+	GetActive(ctx context.Context) ([]*entity.Webhook, error)
+
+	// Update updates an existing webhook
+	Update(ctx context.Context, webhook *entity.Webhook) error
+
+	// Delete permanently deletes a webhook and its delivery log by its ID
+	Delete(ctx context.Context, id uint) error
+
+	// CreateDeliveries queues deliveries to be sent
+	CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+
+	// ClaimDueDeliveries retrieves up to limit pending deliveries whose next attempt is at or
+	// before now, oldest first, and pushes their next attempt back by lease so that no other
+	// worker picks them up while they are being sent
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error)
+
+	// UpdateDelivery records the outcome of a delivery attempt
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// GetDeliveries retrieves a webhook's latest limit deliveries, newest first
+	GetDeliveries(ctx context.Context, webhookID uint, limit int) ([]*entity.WebhookDelivery, error)
+}
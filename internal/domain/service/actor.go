@@ -0,0 +1,31 @@
+package service
+
+import "context"
+
+// actorKey is the context key under which the acting user's ID is stored
+type actorKey struct{}
+
+// WithActorID returns a copy of ctx carrying the ID of the user performing the operation
+func WithActorID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorIDFromContext returns the ID of the user performing the operation, if known
+func ActorIDFromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(actorKey{}).(uint)
+	return userID, ok
+}
+
+// requestIDKey is the context key under which the ID of the request being served is stored
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request the operation serves
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the ID of the request the operation serves, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok && requestID != ""
+}
@@ -2,15 +2,17 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
 )
 
 // RegisterRequest represents a user registration request
 type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email"`
-	Username  string `json:"username" validate:"required,min=3,max=50"`
-	Password  string `json:"password" validate:"required,min=8"`
+	Username  string `json:"username" validate:"required,min=3,max=50,username"`
+	Password  string `json:"password" validate:"required"` // checked against the password policy
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 }
@@ -38,7 +40,7 @@ type AuthResponse struct {
 // PasswordChangeRequest represents a password change request
 type PasswordChangeRequest struct {
 	CurrentPassword string `json:"current_password" validate:"required"`
-	NewPassword     string `json:"new_password" validate:"required,min=8"`
+	NewPassword     string `json:"new_password" validate:"required"` // checked against the password policy
 }
 
 // Claims represents JWT claims
@@ -47,6 +49,39 @@ type Claims struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	IsAdmin  bool   `json:"is_admin"`
+
+	// Details of the validated token itself
+	Role      string    `json:"role"` // role the token was issued with
+	TokenID   string    `json:"jti"`
+	IssuedAt  time.Time `json:"issued_at"` // zero if the token has no iat claim
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UserListResponse represents a paginated list of users
+type UserListResponse struct {
+	Users []*entity.User `json:"users"`
+	PageInfo
+}
+
+// Reasons a user is skipped by a bulk deactivation
+const (
+	SkipReasonNotFound        = "not_found"
+	SkipReasonAlreadyInactive = "already_inactive"
+	SkipReasonLastAdmin       = "last_admin"
+	SkipReasonSelf            = "self"
+)
+
+// BulkDeactivateSkip reports a user a bulk deactivation left untouched and why
+type BulkDeactivateSkip struct {
+	ID     uint   `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// BulkDeactivateResult reports which requested users a bulk deactivation applied to
+type BulkDeactivateResult struct {
+	Count       int                  `json:"count"`
+	Deactivated []uint               `json:"deactivated"`
+	Skipped     []BulkDeactivateSkip `json:"skipped"`
 }
 
 // AuthService defines the interface for authentication business logic operations
@@ -80,4 +115,47 @@ type AuthService interface {
 	
 	// GetUserProfile gets user profile information
 	GetUserProfile(ctx context.Context, userID uint) (*entity.User, error)
+	
+	// ListUsers retrieves a paginated list of users with filtering (admin only)
+	ListUsers(ctx context.Context, filter *repository.UserFilter, page, pageSize int) (*UserListResponse, error)
+	
+	// CountUsers returns the number of users matching filter without fetching them (admin only)
+	CountUsers(ctx context.Context, filter *repository.UserFilter) (int64, error)
+	
+	// ListDeletedUsers retrieves a paginated list of soft-deleted users (admin only)
+	ListDeletedUsers(ctx context.Context, page, pageSize int) (*UserListResponse, error)
+	
+	// RestoreUser undoes the soft-delete of a user unless its email or username is taken (admin only)
+	RestoreUser(ctx context.Context, id uint) (*entity.User, error)
+	
+	// DeleteUser soft-deletes a user, who can later be restored; admins can't delete themselves (admin only)
+	DeleteUser(ctx context.Context, id uint) error
+	
+	// HardDeleteUser permanently deletes a user, live or soft-deleted; admins can't delete themselves (admin only)
+	HardDeleteUser(ctx context.Context, id uint) error
+	
+	// DeactivateUser stops a user from logging in while keeping them visible; admins can't
+	// deactivate themselves (admin only)
+	DeactivateUser(ctx context.Context, id uint) (*entity.User, error)
+	
+	// SetUserPassword replaces a user's password without knowing the current one (admin only)
+	SetUserPassword(ctx context.Context, id uint, password string) error
+	
+	// ReactivateUser lets a deactivated user log in again (admin only)
+	ReactivateUser(ctx context.Context, id uint) (*entity.User, error)
+	
+	// BulkDeactivateUsers deactivates several users at once, never the last active admin (admin only)
+	BulkDeactivateUsers(ctx context.Context, ids []uint) (*BulkDeactivateResult, error)
+	
+	// VerifyEmail verifies and activates the account holding the verification token
+	VerifyEmail(ctx context.Context, token string) (*entity.User, error)
+	
+	// ResendVerification issues a fresh verification link to an unverified account
+	ResendVerification(ctx context.Context, email string) error
+	
+	// ForgotPassword emails a password reset link to the account registered with email
+	ForgotPassword(ctx context.Context, email string) error
+	
+	// ResetPassword sets a new password for the account holding a password reset token
+	ResetPassword(ctx context.Context, token, password string) error
 }
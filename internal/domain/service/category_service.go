@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// CategoryRequest represents a request to create or replace a category.
+// An empty slug is derived from the name; a nil parent makes it a top-level category.
+type CategoryRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Slug     string `json:"slug" validate:"omitempty,max=120"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// CategoryService defines the interface for category business logic operations
+type CategoryService interface {
+	// CreateCategory creates a new category
+	CreateCategory(ctx context.Context, req *CategoryRequest) (*entity.Category, error)
+
+	// GetCategory retrieves a category by its ID
+	GetCategory(ctx context.Context, id uint) (*entity.Category, error)
+
+	// ListCategories retrieves every category ordered by name
+	ListCategories(ctx context.Context) ([]*entity.Category, error)
+
+	// GetCategoryTree returns the top-level categories with their subcategories nested under them
+	GetCategoryTree(ctx context.Context) ([]*entity.Category, error)
+
+	// UpdateCategory replaces a category's name, slug and parent
+	UpdateCategory(ctx context.Context, id uint, req *CategoryRequest) (*entity.Category, error)
+
+	// DeleteCategory deletes a category that has no subcategories or products
+	DeleteCategory(ctx context.Context, id uint) error
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// EmailSender delivers transactional emails to users
+type EmailSender interface {
+	// SendVerificationEmail sends user the link that verifies their email address
+	SendVerificationEmail(ctx context.Context, user *entity.User, verifyURL string) error
+
+	// SendPasswordResetEmail sends user the link that lets them choose a new password
+	SendPasswordResetEmail(ctx context.Context, user *entity.User, resetURL string) error
+}
@@ -0,0 +1,39 @@
+package service
+
+import "context"
+
+// Business event types
+const (
+	EventProductCreated      = "product.created"
+	EventProductUpdated      = "product.updated"
+	EventProductDeleted      = "product.deleted"
+	EventProductHardDeleted  = "product.hard_deleted"
+	EventProductDeactivated  = "product.deactivated"
+	EventProductStockChanged = "product.stock_changed"
+	EventProductLowStock     = "product.low_stock"
+	EventProductNeedsReview  = "product.needs_review"
+	EventUserRegistered      = "user.registered"
+	EventUserLoggedIn        = "user.logged_in"
+	EventUserLoginFailed     = "user.login_failed"
+	EventUserDeactivated     = "user.deactivated"
+	EventUserReactivated     = "user.reactivated"
+	EventUserDeleted         = "user.deleted"
+	EventUserHardDeleted     = "user.hard_deleted"
+	EventUserRestored        = "user.restored"
+)
+
+// BusinessEvent describes something that happened in the domain, such as a product being created
+type BusinessEvent struct {
+	Type       string
+	EntityType string
+	EntityID   uint  // zero when the entity is unknown, e.g. a failed login
+	ActorID    *uint // the user who caused the event, if known
+	Fields     map[string]interface{}
+}
+
+// EventLogger records business events in the application log. Implementations must not block
+// or fail the operation that raised the event.
+type EventLogger interface {
+	// LogEvent records event
+	LogEvent(ctx context.Context, event BusinessEvent)
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// ProductEvent describes a mutation of a product, such as it being created or its stock changing
+type ProductEvent struct {
+	ProductID  uint
+	Action     string // one of the entity.AuditAction values, e.g. "create" or "stock_change"
+	ActorID    *uint  // the user who made the change, if known
+	OccurredAt time.Time
+}
+
+// EventPublisher hands product events to whatever reacts to them, such as webhooks or a
+// message queue. Callers log publish errors rather than failing the operation that raised the event.
+type EventPublisher interface {
+	// Publish delivers event
+	Publish(ctx context.Context, event ProductEvent) error
+}
+
+// NopEventPublisher discards every event; it is the default when nothing subscribes to events
+type NopEventPublisher struct{}
+
+// Publish discards event
+func (NopEventPublisher) Publish(ctx context.Context, event ProductEvent) error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// NullableString is a JSON string field that tells apart its three states in a patch:
+// absent (Set is false, leave the field unchanged), null (Null is true, clear the field)
+// and a string value, which may be empty
+type NullableString struct {
+	Set   bool
+	Null  bool
+	Value string
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for keys present in the
+// document, which is how absent fields keep Set false.
+func (s *NullableString) UnmarshalJSON(data []byte) error {
+	s.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		s.Null = true
+		s.Value = ""
+		return nil
+	}
+	s.Null = false
+	return json.Unmarshal(data, &s.Value)
+}
+
+// MarshalJSON implements json.Marshaler, writing null for an absent or null value
+func (s NullableString) MarshalJSON() ([]byte, error) {
+	if !s.Set || s.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.Value)
+}
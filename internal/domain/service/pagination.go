@@ -0,0 +1,25 @@
+package service
+
+// PageInfo holds the pagination metadata shared by every paginated list response.
+// It is embedded in list responses so its fields are serialized inline.
+type PageInfo struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// NewPageInfo builds the pagination metadata for a page of a list with total items
+func NewPageInfo(total int64, page, pageSize int) PageInfo {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = int((total + int64(pageSize) - 1) / int64(pageSize))
+	}
+
+	return PageInfo{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}
+}
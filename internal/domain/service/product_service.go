@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/product-management/internal/domain/entity"
 	"github.com/product-management/internal/domain/repository"
@@ -10,15 +12,18 @@ import (
 // ProductCreateRequest represents a request to create a product
 type ProductCreateRequest struct {
 	Name        string  `json:"name" validate:"required,min=3,max=255"`
+	SKU         string  `json:"sku" validate:"max=64"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price" validate:"required,min=0"`
 	Stock       int     `json:"stock" validate:"min=0"`
 	Category    string  `json:"category"`
+	CategoryID  *uint   `json:"category_id"`
 	ImageURL    string  `json:"image_url"`
 }
 
 // ProductUpdateRequest represents a request to update a product
 type ProductUpdateRequest struct {
+	Version     *int     `json:"version" validate:"required"` // version of the product being updated
 	Name        *string  `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
 	Description *string  `json:"description,omitempty"`
 	Price       *float64 `json:"price,omitempty" validate:"omitempty,min=0"`
@@ -28,13 +33,258 @@ type ProductUpdateRequest struct {
 	IsActive    *bool    `json:"is_active,omitempty"`
 }
 
+// ProductPatchRequest represents a partial update of a product. Fields that are absent are
+// left unchanged. Description, category, image_url and sku can be cleared by sending null; an
+// empty string is rejected so a field is never blanked by accident. A non-empty image_url
+// must be an absolute http or https URL. Version is the version of the product the client
+// read; the patch is rejected if the product has changed since.
+type ProductPatchRequest struct {
+	Version           *int           `json:"version" validate:"required"`
+	Name              *string        `json:"name" validate:"omitempty,min=3,max=255"`
+	Price             *float64       `json:"price" validate:"omitempty,min=0"`
+	Stock             *int           `json:"stock" validate:"omitempty,min=0"`
+	LowStockThreshold *int           `json:"low_stock_threshold" validate:"omitempty,min=0"`
+	CategoryID        *uint          `json:"category_id"`
+	IsActive          *bool          `json:"is_active"`
+	Description       NullableString `json:"description" swaggertype:"string"`
+	Category          NullableString `json:"category" swaggertype:"string"`
+	ImageURL          NullableString `json:"image_url" swaggertype:"string"`
+	SKU               NullableString `json:"sku" swaggertype:"string"`
+}
+
 // ProductListResponse represents a paginated list of products
 type ProductListResponse struct {
+	Products []*entity.Product `json:"products"`
+	PageInfo
+}
+
+// DeletedProduct is a soft-deleted product along with when it was deleted
+type DeletedProduct struct {
+	*entity.Product
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// DeletedProductListResponse represents a paginated list of soft-deleted products
+type DeletedProductListResponse struct {
+	Products []*DeletedProduct `json:"products"`
+	PageInfo
+}
+
+// MinSearchQueryLength is the shortest search query accepted, after trimming
+const MinSearchQueryLength = 2
+
+// SearchOptions tune how search matches are scored and filtered
+type SearchOptions struct {
+	Relevance string   // one of repository.SearchRelevanceModes, empty for weighted
+	MinScore  *float64 // drops matches scoring below this, nil to keep every match
+}
+
+// ProductSearchResponse represents a paginated page of search results. When nothing
+// matches, Suggestions holds "did you mean" product names.
+type ProductSearchResponse struct {
+	ProductListResponse
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// ProductCursorResponse represents a keyset-paginated page of products
+type ProductCursorResponse struct {
 	Products   []*entity.Product `json:"products"`
-	Total      int64             `json:"total"`
-	Page       int               `json:"page"`
-	PageSize   int               `json:"page_size"`
-	TotalPages int               `json:"total_pages"`
+	Limit      int               `json:"limit"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// BulkCreateResult represents the outcome of creating a single item in a bulk request
+type BulkCreateResult struct {
+	Index   int             `json:"index"`
+	Product *entity.Product `json:"product,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// ProductImportRow represents a single parsed row of a product import file
+type ProductImportRow struct {
+	Line        int
+	Name        string
+	Description string
+	Price       float64
+	Stock       int
+	Category    string
+	ImageURL    string
+	IsActive    *bool
+}
+
+// ImportRowError describes why an import row was skipped
+type ImportRowError struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// ImportResult summarizes the outcome of a product import
+type ImportResult struct {
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Skipped int              `json:"skipped"`
+	Errors  []ImportRowError `json:"errors,omitempty"`
+}
+
+// AddRowErrors records rows rejected before reaching the import (e.g. unparseable lines)
+// as skipped, keeping errors ordered by line
+func (r *ImportResult) AddRowErrors(rowErrors []ImportRowError) {
+	if len(rowErrors) == 0 {
+		return
+	}
+	r.Skipped += len(rowErrors)
+	r.Errors = append(append([]ImportRowError(nil), rowErrors...), r.Errors...)
+	sort.SliceStable(r.Errors, func(i, j int) bool {
+		return r.Errors[i].Line < r.Errors[j].Line
+	})
+}
+
+// Import job statuses
+const (
+	ImportJobStatusPending   = "pending"
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusCompleted = "completed"
+	ImportJobStatusFailed    = "failed"
+)
+
+// ImportJob describes a product import running in the background
+type ImportJob struct {
+	ID         string        `json:"job_id"`
+	Status     string        `json:"status"`
+	Result     *ImportResult `json:"result,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	FinishedAt *time.Time    `json:"finished_at,omitempty"`
+}
+
+// SearchReindexStatus reports the progress of the latest search reindex
+type SearchReindexStatus struct {
+	Running    bool       `json:"running"`
+	Processed  int        `json:"processed"` // products whose search vectors have been recomputed so far
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// PriceUpdateRow represents a single parsed row of a price import file. The product is
+// named by SKU, or by ID when SKU is empty.
+type PriceUpdateRow struct {
+	Line  int
+	SKU   string
+	ID    uint
+	Price float64
+}
+
+// Price update row outcomes
+const (
+	PriceUpdateStatusUpdated  = "updated"
+	PriceUpdateStatusNotFound = "not_found"
+	PriceUpdateStatusInvalid  = "invalid"
+)
+
+// PriceUpdateResult describes the outcome of a single price import row
+type PriceUpdateResult struct {
+	Line     int     `json:"line"`
+	ID       uint    `json:"id,omitempty"`
+	SKU      string  `json:"sku,omitempty"`
+	Status   string  `json:"status"`
+	OldPrice float64 `json:"old_price,omitempty"`
+	NewPrice float64 `json:"new_price,omitempty"`
+	Reason   string  `json:"reason,omitempty"`
+}
+
+// PriceImportResult summarizes a price import
+type PriceImportResult struct {
+	DryRun   bool                `json:"dry_run"`
+	Updated  int                 `json:"updated"`
+	NotFound int                 `json:"not_found"`
+	Invalid  int                 `json:"invalid"`
+	Rows     []PriceUpdateResult `json:"rows"`
+}
+
+// AddProductImageRequest represents add product image request data
+type AddProductImageRequest struct {
+	URL       string `json:"url" validate:"required,url,max=500"`
+	AltText   string `json:"alt_text" validate:"max=255"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// SetProductTagsRequest represents set product tags request data. The tags replace the
+// product's current ones; an empty list removes them all.
+type SetProductTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// ReorderProductImagesRequest represents reorder product images request data
+type ReorderProductImagesRequest struct {
+	ImageIDs       []uint `json:"image_ids" validate:"required,min=1,unique"`
+	PrimaryImageID *uint  `json:"primary_image_id"`
+}
+
+// ProductBatchResult holds the products found for a batch lookup, in the order their IDs were
+// requested, and the requested IDs that match no live product
+type ProductBatchResult struct {
+	Products []*entity.Product `json:"products"`
+	NotFound []uint            `json:"not_found"`
+}
+
+// BulkStatusResult reports which requested IDs a bulk status update applied to.
+// Soft-deleted and unknown products are left untouched.
+type BulkStatusResult struct {
+	Updated     []uint `json:"updated"`
+	SoftDeleted []uint `json:"soft_deleted"`
+	NotFound    []uint `json:"not_found"`
+}
+
+// ReserveStockRequest represents a request to hold back part of a product's stock
+type ReserveStockRequest struct {
+	Quantity  int       `json:"quantity" validate:"required,gt=0"`
+	OwnerID   string    `json:"owner_id" validate:"required,max=255"` // cart or client holding the stock
+	ExpiresAt time.Time `json:"expires_at" validate:"required"`
+}
+
+// ProductReservations lists a product's unexpired stock reservations
+type ProductReservations struct {
+	ProductID     uint                  `json:"product_id"`
+	TotalReserved int                   `json:"total_reserved"`
+	Reservations  []*entity.Reservation `json:"reservations"`
+}
+
+// ProductDiff reports how a product's audited fields differ between two points in time, as
+// reconstructed from its audit trail. Fields are reported with a nil side when the product
+// didn't exist at that point.
+type ProductDiff struct {
+	ProductID     uint                `json:"product_id"`
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	ExistedAtFrom bool                `json:"existed_at_from"`
+	ExistedAtTo   bool                `json:"existed_at_to"`
+	Changes       entity.AuditChanges `json:"changes"`
+}
+
+// ExportOptions controls which part of the catalog an export covers and how it is read
+type ExportOptions struct {
+	// SinceID resumes an export after the last product ID a client received
+	SinceID uint
+	// Limit caps the number of exported products; zero exports everything
+	Limit int
+	// Consistent reads the whole export from a single repeatable-read snapshot. Otherwise
+	// each batch sees the latest committed data, so concurrent writes may be partially visible.
+	Consistent bool
+}
+
+// ImportKind* identify the type of an import operation
+const (
+	ImportKindProducts = "products"
+	ImportKindPrices   = "prices"
+)
+
+// ActiveImport describes an import operation that is currently running
+type ActiveImport struct {
+	ID        uint64    `json:"id"`
+	Kind      string    `json:"kind"`
+	Rows      int       `json:"rows"`
+	StartedAt time.Time `json:"started_at"`
 }
 
 // ProductService defines the interface for product business logic operations
@@ -42,27 +292,120 @@ type ProductService interface {
 	// CreateProduct creates a new product
 	CreateProduct(ctx context.Context, req *ProductCreateRequest) (*entity.Product, error)
 	
+	// BulkCreateProducts creates several products in one transaction. Unless continueOnError is set,
+	// any failing item rolls back the whole batch.
+	BulkCreateProducts(ctx context.Context, reqs []*ProductCreateRequest, continueOnError bool) ([]*BulkCreateResult, error)
+	
 	// GetProductByID retrieves a product by its ID
 	GetProductByID(ctx context.Context, id uint) (*entity.Product, error)
 	
+	// GetProductBySKU retrieves a live product by its SKU
+	GetProductBySKU(ctx context.Context, sku string) (*entity.Product, error)
+	
+	// GetProductsByIDs retrieves several products at once, reporting the IDs not found
+	GetProductsByIDs(ctx context.Context, ids []uint) (*ProductBatchResult, error)
+	
 	// GetProducts retrieves a paginated list of products with filtering
 	GetProducts(ctx context.Context, filter *repository.ProductFilter, page, pageSize int) (*ProductListResponse, error)
 	
+	// CountProducts returns the number of products matching filter without fetching them
+	CountProducts(ctx context.Context, filter *repository.ProductFilter) (int64, error)
+	
+	// GetStats aggregates the products matching filter, e.g. for a dashboard summary
+	GetStats(ctx context.Context, filter *repository.ProductFilter) (*repository.ProductStats, error)
+	
+	// GetProductsCursor retrieves a page of products after the given opaque cursor
+	GetProductsCursor(ctx context.Context, filter *repository.ProductFilter, cursor string, limit int) (*ProductCursorResponse, error)
+	
+	// ExportProducts streams the products matching the filter to fn in ascending ID order
+	ExportProducts(ctx context.Context, filter *repository.ProductFilter, opts ExportOptions, fn func(*entity.Product) error) error
+	
+	// ImportProducts creates or updates products by name from parsed import rows
+	ImportProducts(ctx context.Context, rows []*ProductImportRow) (*ImportResult, error)
+	
+	// ImportPrices updates the prices of existing products in a single transaction.
+	// With dryRun set, rows are matched and validated but nothing is written.
+	ImportPrices(ctx context.Context, rows []*PriceUpdateRow, dryRun bool) (*PriceImportResult, error)
+
+	// StartImportJob queues a product import to run in the background. Rows already rejected
+	// while parsing are reported as skipped in the job's result.
+	StartImportJob(ctx context.Context, rows []*ProductImportRow, rowErrors []ImportRowError) (*ImportJob, error)
+
+	// GetImportJob returns the current state of a background import
+	GetImportJob(ctx context.Context, id string) (*ImportJob, error)
+
+	// AddProductImage appends an image to a product's gallery
+	AddProductImage(ctx context.Context, productID uint, req *AddProductImageRequest) (*entity.ProductImage, error)
+
+	// SetProductTags replaces a product's tags
+	SetProductTags(ctx context.Context, productID uint, req *SetProductTagsRequest) (*entity.Product, error)
+
+	// DeleteProductImage removes an image from a product's gallery
+	DeleteProductImage(ctx context.Context, productID, imageID uint) error
+
+	// ReorderProductImages sets the display order of a product's images
+	ReorderProductImages(ctx context.Context, productID uint, req *ReorderProductImagesRequest) ([]*entity.ProductImage, error)
+
+	// GetProductHistory returns the audit trail of a product, oldest first
+	GetProductHistory(ctx context.Context, id uint) ([]*entity.AuditLog, error)
+
+	// ListActiveImports returns the imports that are currently running
+	ListActiveImports(ctx context.Context) []ActiveImport
+	
 	// UpdateProduct updates an existing product
 	UpdateProduct(ctx context.Context, id uint, req *ProductUpdateRequest) (*entity.Product, error)
 	
+	// PatchProduct applies a partial update that can clear optional string fields
+	PatchProduct(ctx context.Context, id uint, req *ProductPatchRequest) (*entity.Product, error)
+	
 	// DeleteProduct deletes a product by its ID
 	DeleteProduct(ctx context.Context, id uint) error
 	
-	// GetProductsByCategory retrieves products by category
-	GetProductsByCategory(ctx context.Context, category string, page, pageSize int) (*ProductListResponse, error)
+	// GetProductsByCategory retrieves products in any of the given categories
+	GetProductsByCategory(ctx context.Context, categories []string, page, pageSize int) (*ProductListResponse, error)
+	
+	// GetLowStockProducts retrieves active products at or below their low stock threshold
+	GetLowStockProducts(ctx context.Context, page, pageSize int) (*ProductListResponse, error)
+	
+	// ReportBrokenImage records a client's report that a product's image is broken
+	ReportBrokenImage(ctx context.Context, id uint) error
+	
+	// GetImageReports retrieves products whose image has been reported broken
+	GetImageReports(ctx context.Context, page, pageSize int) (*ProductListResponse, error)
+	
+	// ClearImageReports resets a product's broken image reports and review flag
+	ClearImageReports(ctx context.Context, id uint) error
 	
 	// SearchProducts searches for products by name or description
-	SearchProducts(ctx context.Context, searchTerm string, page, pageSize int) (*ProductListResponse, error)
+	SearchProducts(ctx context.Context, searchTerm string, page, pageSize int) (*ProductSearchResponse, error)
+	
+	// SearchProductsWithOptions searches products, scoring and filtering matches as opts requests
+	SearchProductsWithOptions(ctx context.Context, searchTerm string, opts SearchOptions, page, pageSize int) (*ProductSearchResponse, error)
 	
 	// UpdateProductStock updates the stock quantity of a product
 	UpdateProductStock(ctx context.Context, id uint, stock int) error
 	
-	// BulkUpdateProductStatus updates the active status of multiple products
-	BulkUpdateProductStatus(ctx context.Context, ids []uint, isActive bool) error
+	// DecrementStock atomically removes qty units from a product's stock
+	DecrementStock(ctx context.Context, id uint, qty int) error
+	
+	// IncrementStock atomically adds qty units to a product's stock
+	IncrementStock(ctx context.Context, id uint, qty int) error
+	
+	// BulkUpdateProductStatus updates the active status of the live products among ids and
+	// reports which IDs were updated, soft-deleted or unknown
+	BulkUpdateProductStatus(ctx context.Context, ids []uint, isActive bool) (*BulkStatusResult, error)
+	
+	// ReserveStock moves part of a product's stock to its reserved units until the
+	// reservation expires
+	ReserveStock(ctx context.Context, productID uint, req *ReserveStockRequest) (*entity.Reservation, error)
+	
+	// GetProductReservations returns a product's unexpired reservations and the total they hold
+	GetProductReservations(ctx context.Context, id uint) (*ProductReservations, error)
+	
+	// GetPriceHistory returns the price changes of a product, oldest first
+	GetPriceHistory(ctx context.Context, id uint) ([]*entity.PriceHistory, error)
+	
+	// GetProductDiff reconstructs a product at from and to from its audit trail and reports
+	// the fields that differ
+	GetProductDiff(ctx context.Context, id uint, from, to time.Time) (*ProductDiff, error)
 }
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// ProductValidator enforces a deployment-specific business rule on a product before it is
+// saved. It runs after the built-in validation, so product is already structurally valid.
+type ProductValidator interface {
+	// ValidateProduct returns an error, usually an *entity.ValidationError, if product breaks the rule
+	ValidateProduct(ctx context.Context, product *entity.Product) error
+}
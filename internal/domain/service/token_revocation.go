@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationList remembers revoked tokens by their ID until they would have expired anyway
+type TokenRevocationList interface {
+	// Revoke marks the token with the given ID as revoked until expiresAt
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// IsRevoked reports whether the token with the given ID has been revoked
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
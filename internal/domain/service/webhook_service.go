@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// WebhookRequest represents a request to create or replace a webhook
+type WebhookRequest struct {
+	URL string `json:"url" validate:"required,max=2048"`
+	// Secret signs payloads; it is required on create, and leaving it empty on update keeps the current one
+	Secret   string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	Events   []string `json:"events" validate:"required,min=1,dive,required"`
+	IsActive *bool    `json:"is_active"` // defaults to true
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook for a product event
+type WebhookPayload struct {
+	Event      string    `json:"event"`
+	ProductID  uint      `json:"product_id"`
+	ActorID    *uint     `json:"actor_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// WebhookSender POSTs signed payloads to webhook endpoints
+type WebhookSender interface {
+	// Send POSTs delivery's payload to webhook, signed with its secret, and returns the
+	// response status code. An error means no response was received.
+	Send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error)
+}
+
+// WebhookService defines the interface for webhook business logic operations
+type WebhookService interface {
+	// CreateWebhook creates a new webhook
+	CreateWebhook(ctx context.Context, req *WebhookRequest) (*entity.Webhook, error)
+
+	// GetWebhook retrieves a webhook by its ID
+	GetWebhook(ctx context.Context, id uint) (*entity.Webhook, error)
+
+	// ListWebhooks retrieves every webhook
+	ListWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+
+	// UpdateWebhook replaces a webhook's URL, events and active flag, and its secret if one is given
+	UpdateWebhook(ctx context.Context, id uint, req *WebhookRequest) (*entity.Webhook, error)
+
+	// DeleteWebhook deletes a webhook along with its delivery log
+	DeleteWebhook(ctx context.Context, id uint) error
+
+	// ListDeliveries retrieves a webhook's most recent deliveries, newest first
+	ListDeliveries(ctx context.Context, id uint) ([]*entity.WebhookDelivery, error)
+}
@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss is returned by Get when the key is not cached
+var ErrCacheMiss = errors.New("cache miss")
+
+// Cache is a minimal key/value cache with per-entry expiry
+type Cache interface {
+	// Get returns the value stored under key, or ErrCacheMiss if there is none
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value under key for the given ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the given keys, ignoring keys that don't exist
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry is a cached value with its expiry time
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache, useful as a fake in tests and for single-instance setups
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// Get returns the value stored under key if it hasn't expired
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+// Set stores value under key for the given ttl; a zero ttl never expires
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return nil
+}
+
+// Delete removes the given keys
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCache implements Cache on top of Redis
+type redisCache struct {
+	client redis.UniversalClient
+}
+
+// NewRedisCache creates a Redis-backed cache
+func NewRedisCache(client redis.UniversalClient) Cache {
+	return &redisCache{
+		client: client,
+	}
+}
+
+// Get returns the value stored under key
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	return value, err
+}
+
+// Set stores value under key for the given ttl
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete removes the given keys
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
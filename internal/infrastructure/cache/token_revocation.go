@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/product-management/internal/domain/service"
+)
+
+// revokedTokenKeyPrefix namespaces revoked token IDs within a shared cache
+const revokedTokenKeyPrefix = "revoked_token:"
+
+// tokenRevocationList keeps revoked token IDs in a Cache, each expiring with its token
+type tokenRevocationList struct {
+	cache Cache
+}
+
+// NewTokenRevocationList creates a token revocation list stored in cache. With a shared cache
+// such as Redis, a token revoked on one instance is rejected by all of them.
+func NewTokenRevocationList(cache Cache) service.TokenRevocationList {
+	return &tokenRevocationList{cache: cache}
+}
+
+// Revoke marks the token as revoked until it expires
+func (l *tokenRevocationList) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		// Already expired, so it is rejected anyway
+		return nil
+	}
+	return l.cache.Set(ctx, revokedTokenKeyPrefix+tokenID, []byte{1}, ttl)
+}
+
+// IsRevoked reports whether the token has been revoked
+func (l *tokenRevocationList) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	_, err := l.cache.Get(ctx, revokedTokenKeyPrefix+tokenID)
+	if errors.Is(err, ErrCacheMiss) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
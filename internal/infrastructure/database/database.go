@@ -1,23 +1,33 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"github.com/product-management/internal/config"
 	"github.com/product-management/internal/domain/entity"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 // Database wraps the GORM database connection
 type Database struct {
 	DB *gorm.DB
+
+	logger                *slog.Logger
+	caseInsensitiveUnique bool
+	namesPerCategory      bool
 }
 
-// NewDatabase creates a new database connection
-func NewDatabase(cfg *config.Config) (*Database, error) {
+// NewDatabase creates a new database connection logging connection and migration progress to
+// logger, or the default logger if it is nil
+func NewDatabase(cfg *config.Config, logger *slog.Logger) (*Database, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=UTC",
 		cfg.Database.Host,
@@ -29,25 +39,35 @@ func NewDatabase(cfg *config.Config) (*Database, error) {
 	)
 
 	// Configure GORM logger
-	var logLevel logger.LogLevel
+	var logLevel gormlogger.LogLevel
 	switch cfg.Log.Level {
 	case "debug":
-		logLevel = logger.Info
+		logLevel = gormlogger.Info
 	case "info":
-		logLevel = logger.Warn
+		logLevel = gormlogger.Warn
 	case "warn":
-		logLevel = logger.Error
+		logLevel = gormlogger.Error
 	case "error":
-		logLevel = logger.Silent
+		logLevel = gormlogger.Silent
 	default:
-		logLevel = logger.Info
+		logLevel = gormlogger.Info
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
+	pool, err := parsePoolConfig(cfg.Database.Pool)
+	if err != nil {
+		return nil, err
+	}
+	retry, err := parseConnectRetry(cfg.Database.Connect)
+	if err != nil {
+		return nil, err
+	}
+
+	// The database may still be booting, e.g. when started alongside the app by docker-compose
+	db, err := connectWithRetry(retry, logger, time.Sleep, func() (*gorm.DB, error) {
+		return dial(dsn, logLevel)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, err
 	}
 
 	// Configure connection pool
@@ -56,29 +76,261 @@ func NewDatabase(cfg *config.Config) (*Database, error) {
 		return nil, fmt.Errorf("failed to get database instance: %w", err)
 	}
 
-	// Set connection pool parameters
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetMaxIdleConns(pool.maxIdleConns)
+	sqlDB.SetMaxOpenConns(pool.maxOpenConns)
+	sqlDB.SetConnMaxLifetime(pool.connMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.connMaxIdleTime)
+	logger.Info("Database pool configured",
+		slog.Int("max_open_conns", pool.maxOpenConns),
+		slog.Int("max_idle_conns", pool.maxIdleConns),
+		slog.Duration("conn_max_lifetime", pool.connMaxLifetime),
+		slog.Duration("conn_max_idle_time", pool.connMaxIdleTime))
+
+	return &Database{
+		DB:                    db,
+		logger:                logger,
+		caseInsensitiveUnique: cfg.Database.CaseInsensitiveUnique,
+		namesPerCategory:      cfg.Product.NameUniquePerCategory,
+	}, nil
+}
+
+// connectPingTimeout bounds the ping that checks a new connection is usable
+const connectPingTimeout = 5 * time.Second
+
+// maxConnectDelay caps the backoff between connection attempts
+const maxConnectDelay = 30 * time.Second
+
+// connectRetry is a validated connection retry configuration
+type connectRetry struct {
+	attempts  int
+	baseDelay time.Duration
+}
+
+// parseConnectRetry parses and validates the connection retry configuration
+func parseConnectRetry(cfg config.DatabaseConnectConfig) (connectRetry, error) {
+	if cfg.Attempts < 1 {
+		return connectRetry{}, fmt.Errorf("invalid DB_CONNECT_ATTEMPTS %d: must be at least 1", cfg.Attempts)
+	}
+	baseDelay, err := time.ParseDuration(cfg.BaseDelay)
+	if err != nil || baseDelay < 0 {
+		return connectRetry{}, fmt.Errorf("invalid DB_CONNECT_BASE_DELAY %q: must be a non-negative duration", cfg.BaseDelay)
+	}
+	return connectRetry{attempts: cfg.Attempts, baseDelay: baseDelay}, nil
+}
+
+// dial opens a connection to dsn and pings it
+func dial(dsn string, logLevel gormlogger.LogLevel) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(logLevel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), connectPingTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// connectWithRetry calls connect until it succeeds or retry.attempts calls have failed,
+// sleeping with exponential backoff from retry.baseDelay in between. The last error is
+// returned wrapped once every attempt has failed. Failed attempts are logged to logger.
+func connectWithRetry(retry connectRetry, logger *slog.Logger, sleep func(time.Duration), connect func() (*gorm.DB, error)) (*gorm.DB, error) {
+	delay := retry.baseDelay
+	var err error
+	for attempt := 1; attempt <= retry.attempts; attempt++ {
+		var db *gorm.DB
+		db, err = connect()
+		if err == nil {
+			return db, nil
+		}
+		if attempt == retry.attempts {
+			break
+		}
+
+		logger.Warn("Database connection attempt failed, retrying",
+			slog.Int("attempt", attempt),
+			slog.Int("attempts", retry.attempts),
+			slog.Duration("delay", delay),
+			slog.Any("error", err))
+		sleep(delay)
+		delay = min(delay*2, maxConnectDelay)
+	}
+	return nil, fmt.Errorf("failed to connect to database after %d attempt(s): %w", retry.attempts, err)
+}
+
+// poolConfig is a validated connection pool configuration
+type poolConfig struct {
+	maxIdleConns    int
+	maxOpenConns    int
+	connMaxLifetime time.Duration
+	connMaxIdleTime time.Duration
+}
+
+// parsePoolConfig parses and validates the connection pool configuration
+func parsePoolConfig(cfg config.DatabasePoolConfig) (*poolConfig, error) {
+	if cfg.MaxOpenConns < 0 {
+		return nil, fmt.Errorf("invalid DB_MAX_OPEN_CONNS %d: must be 0 (unlimited) or positive", cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns < 0 {
+		return nil, fmt.Errorf("invalid DB_MAX_IDLE_CONNS %d: must not be negative", cfg.MaxIdleConns)
+	}
+	// database/sql would silently lower the idle limit, so make the mismatch visible instead
+	if cfg.MaxOpenConns > 0 && cfg.MaxIdleConns > cfg.MaxOpenConns {
+		return nil, fmt.Errorf("invalid DB_MAX_IDLE_CONNS %d: must not exceed DB_MAX_OPEN_CONNS %d", cfg.MaxIdleConns, cfg.MaxOpenConns)
+	}
+
+	lifetime, err := time.ParseDuration(cfg.ConnMaxLifetime)
+	if err != nil || lifetime < 0 {
+		return nil, fmt.Errorf("invalid DB_CONN_MAX_LIFETIME %q: must be a non-negative duration", cfg.ConnMaxLifetime)
+	}
+	idleTime, err := time.ParseDuration(cfg.ConnMaxIdleTime)
+	if err != nil || idleTime < 0 {
+		return nil, fmt.Errorf("invalid DB_CONN_MAX_IDLE_TIME %q: must be a non-negative duration", cfg.ConnMaxIdleTime)
+	}
 
-	return &Database{DB: db}, nil
+	return &poolConfig{
+		maxIdleConns:    cfg.MaxIdleConns,
+		maxOpenConns:    cfg.MaxOpenConns,
+		connMaxLifetime: lifetime,
+		connMaxIdleTime: idleTime,
+	}, nil
 }
 
 // AutoMigrate runs database migrations
 func (d *Database) AutoMigrate() error {
-	log.Println("Running database migrations...")
+	d.logger.Info("Running database migrations")
+	
+	// pg_trgm powers search suggestions; without it suggestions are simply omitted
+	if err := d.DB.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		d.logger.Warn("Could not enable pg_trgm extension, search suggestions will be unavailable", slog.Any("error", err))
+	}
+	
+	// Accounts created before email verification existed count as verified
+	backfillEmailVerified := !d.DB.Migrator().HasColumn(&entity.User{}, "EmailVerified")
 	
 	err := d.DB.AutoMigrate(
 		&entity.User{},
+		&entity.Category{},
 		&entity.Product{},
+		&entity.ProductImage{},
+		&entity.ProductTag{},
+		&entity.AuditLog{},
+		&entity.PriceHistory{},
+		&entity.Reservation{},
+		&entity.Webhook{},
+		&entity.WebhookDelivery{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 	
-	log.Println("Database migrations completed successfully")
+	if backfillEmailVerified {
+		if err := d.DB.Exec("UPDATE users SET email_verified = true").Error; err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+	}
+	
+	if err := d.migrateProductSearch(); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	if err := d.migrateProductSKU(); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	
+	if d.caseInsensitiveUnique {
+		if err := d.migrateCaseInsensitiveUsers(); err != nil {
+			return fmt.Errorf("failed to run migrations (users differing only by case must be merged first): %w", err)
+		}
+	}
+
+	if d.namesPerCategory {
+		if err := d.migrateProductNamesPerCategory(); err != nil {
+			return fmt.Errorf("failed to run migrations (products sharing a name within a category must be renamed first): %w", err)
+		}
+	}
+	
+	d.logger.Info("Database migrations completed successfully")
+	return nil
+}
+
+// migrateCaseInsensitiveUsers adds unique indexes on lowercased user emails and usernames, so
+// duplicates differing only by case are rejected by the database itself
+func (d *Database) migrateCaseInsensitiveUsers() error {
+	statements := []string{
+		`CREATE UNIQUE INDEX IF NOT EXISTS ` + UserEmailLowerIndex + ` ON users (LOWER(email))`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS ` + UserUsernameLowerIndex + ` ON users (LOWER(username))`,
+	}
+	for _, statement := range statements {
+		if err := d.DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchIndexVersion identifies how product search vectors are computed: the expression in
+// migrateProductSearch and the text search configuration it uses. Bump it when either
+// changes so deployments reindexing on startup recompute the vectors already stored.
+const SearchIndexVersion = 1
+
+// migrateProductSearch adds the generated full-text search column over product names
+// (weighted higher) and descriptions, its GIN index, and the search index version marker.
+// A new marker starts at the current version since the column was computed with it.
+func (d *Database) migrateProductSearch() error {
+	statements := []string{
+		`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(description, '')), 'B')
+			) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)`,
+		`CREATE TABLE IF NOT EXISTS ` + SearchIndexVersionTable + ` (
+			id smallint PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+			version integer NOT NULL,
+			updated_at timestamptz NOT NULL DEFAULT now()
+		)`,
+	}
+	for _, statement := range statements {
+		if err := d.DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return d.DB.Exec(`INSERT INTO `+SearchIndexVersionTable+` (id, version) VALUES (1, ?) ON CONFLICT (id) DO NOTHING`, SearchIndexVersion).Error
+}
+
+// migrateProductSKU adds the partial unique index on product SKUs and the sequence numbering
+// generated SKUs. Products without a SKU and soft-deleted products are left out of the index,
+// so SKUs stay optional and can be reused like names.
+func (d *Database) migrateProductSKU() error {
+	statements := []string{
+		`CREATE UNIQUE INDEX IF NOT EXISTS ` + ProductSKUIndex + ` ON products (sku) WHERE sku IS NOT NULL AND deleted_at IS NULL`,
+		`CREATE SEQUENCE IF NOT EXISTS ` + ProductSKUSequence,
+	}
+	for _, statement := range statements {
+		if err := d.DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// migrateProductNamesPerCategory adds the partial unique index on product names within a
+// category. Soft-deleted products are left out of the index, so their names can be reused.
+func (d *Database) migrateProductNamesPerCategory() error {
+	return d.DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ` + ProductNameCategoryIndex +
+		` ON products (name, category) WHERE deleted_at IS NULL`).Error
+}
+
 // Close closes the database connection
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
@@ -93,14 +345,14 @@ func (d *Database) GetDB() *gorm.DB {
 	return d.DB
 }
 
-// HealthCheck checks if the database connection is healthy
-func (d *Database) HealthCheck() error {
+// HealthCheck checks if the database connection is healthy, giving up when ctx is done
+func (d *Database) HealthCheck(ctx context.Context) error {
 	sqlDB, err := d.DB.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get database instance: %w", err)
 	}
 	
-	if err := sqlDB.Ping(); err != nil {
+	if err := sqlDB.PingContext(ctx); err != nil {
 		return fmt.Errorf("database ping failed: %w", err)
 	}
 	
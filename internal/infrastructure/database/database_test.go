@@ -0,0 +1,42 @@
+package database
+
+import (
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestMigrateCaseInsensitiveUsersIndexesLowercasedValues(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=unused sslmode=disable"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("open dry-run database: %v", err)
+	}
+	var statements []string
+	err = db.Callback().Raw().After("gorm:raw").Register("test:record_raw", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	if err := (&Database{DB: db}).migrateCaseInsensitiveUsers(); err != nil {
+		t.Fatalf("migrateCaseInsensitiveUsers: %v", err)
+	}
+
+	want := []string{
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email))",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_lower ON users (LOWER(username))",
+	}
+	if len(statements) != len(want) {
+		t.Fatalf("got statements %q, want %q", statements, want)
+	}
+	for i := range want {
+		if statements[i] != want[i] {
+			t.Errorf("got %s, want %s", statements[i], want[i])
+		}
+	}
+}
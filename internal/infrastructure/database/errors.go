@@ -0,0 +1,45 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode is the Postgres SQLSTATE for a unique constraint violation
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation reports whether err was caused by a unique constraint or index violation
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// IsUniqueViolationOf reports whether err was caused by a violation of the named unique index
+func IsUniqueViolationOf(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == index
+}
+
+// Unique indexes on user emails and usernames. The lowercased ones exist when uniqueness
+// ignores case, see migrateCaseInsensitiveUsers.
+const (
+	UserEmailIndex         = "idx_users_email"
+	UserUsernameIndex      = "idx_users_username"
+	UserEmailLowerIndex    = "idx_users_email_lower"
+	UserUsernameLowerIndex = "idx_users_username_lower"
+)
+
+// ProductSKUIndex is the partial unique index keeping the SKUs of live products unique
+const ProductSKUIndex = "idx_products_sku"
+
+// ProductNameCategoryIndex is the partial unique index keeping the names of live products
+// unique within their category, created when names are unique per category
+const ProductNameCategoryIndex = "idx_products_name_category"
+
+// ProductSKUSequence numbers the SKUs generated for products created without one
+const ProductSKUSequence = "product_sku_seq"
+
+// SearchIndexVersionTable holds the single row recording which SearchIndexVersion the stored
+// product search vectors were computed with
+const SearchIndexVersionTable = "search_index_versions"
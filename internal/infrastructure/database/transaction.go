@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key under which the active transaction is stored
+type txKey struct{}
+
+// TxManager runs functions inside a database transaction that repositories pick up from the context
+type TxManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager creates a new transaction manager
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn inside a transaction. Repositories called with the context passed
+// to fn share the transaction, which is committed if fn returns nil and rolled back otherwise.
+// If ctx already carries a transaction, fn runs in a savepoint of it, so a failing nested
+// unit of work can be rolled back without aborting the outer one.
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return DBFromContext(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// WithinSnapshot runs fn inside a read-only repeatable-read transaction. If ctx already carries
+// a transaction, fn simply joins it and sees that transaction's view instead.
+func (m *TxManager) WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	if InTransaction(ctx) {
+		return fn(ctx)
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// DBFromContext returns the transaction carried by ctx, or db when there is none,
+// bound to ctx either way
+func DBFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// InTransaction reports whether ctx carries an active transaction
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
@@ -0,0 +1,59 @@
+// Package events delivers domain events to their subscribers
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/product-management/internal/domain/service"
+)
+
+// Subscriber reacts to a product event. Subscribers run on the publishing request, so anything
+// slow, such as calling a webhook, should be handed off to a goroutine or queue.
+type Subscriber func(ctx context.Context, event service.ProductEvent) error
+
+// InProcessPublisher fans product events out to the subscribers registered with it
+type InProcessPublisher struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+// NewInProcessPublisher creates a publisher with no subscribers
+func NewInProcessPublisher() *InProcessPublisher {
+	return &InProcessPublisher{}
+}
+
+// Subscribe registers subscriber to receive every event published from now on
+func (p *InProcessPublisher) Subscribe(subscriber Subscriber) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers = append(p.subscribers, subscriber)
+}
+
+// Publish delivers event to every subscriber in the order they subscribed. A failing or
+// panicking subscriber doesn't stop the others; their errors are returned together.
+func (p *InProcessPublisher) Publish(ctx context.Context, event service.ProductEvent) error {
+	p.mu.RLock()
+	subscribers := p.subscribers
+	p.mu.RUnlock()
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		if err := deliver(ctx, subscriber, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver calls subscriber, turning a panic into an error
+func deliver(ctx context.Context, subscriber Subscriber, event service.ProductEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event subscriber panicked: %v", r)
+		}
+	}()
+	return subscriber(ctx, event)
+}
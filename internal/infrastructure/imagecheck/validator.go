@@ -0,0 +1,60 @@
+// Package imagecheck verifies that product image URLs point at reachable images
+package imagecheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// reachabilityValidator sends a HEAD request to a product's image URL before it is saved
+type reachabilityValidator struct {
+	client *http.Client
+}
+
+// NewReachabilityValidator creates a product validator requiring a non-empty ImageURL to
+// answer a HEAD request within timeout with a 2xx status and an image content type
+func NewReachabilityValidator(timeout time.Duration) service.ProductValidator {
+	return &reachabilityValidator{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// ValidateProduct checks the product's image URL, skipping products without one
+func (v *reachabilityValidator) ValidateProduct(ctx context.Context, product *entity.Product) error {
+	if product.ImageURL == "" {
+		return nil
+	}
+
+	if err := v.check(ctx, product.ImageURL); err != nil {
+		return entity.NewValidationError("image_url", "reachable", fmt.Errorf("%w: %v", entity.ErrImageUnreachable, err))
+	}
+	return nil
+}
+
+// check sends the HEAD request and inspects the response
+func (v *reachabilityValidator) check(ctx context.Context, imageURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, imageURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "image/") {
+		return fmt.Errorf("content type %q", contentType)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package imagecheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestReachabilityValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("got a %s request, want HEAD", r.Method)
+		}
+		switch r.URL.Path {
+		case "/lamp.png":
+			w.Header().Set("Content-Type", "image/png")
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+		case "/slow.png":
+			time.Sleep(200 * time.Millisecond)
+			w.Header().Set("Content-Type", "image/png")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	validator := NewReachabilityValidator(50 * time.Millisecond)
+	tests := []struct {
+		imageURL string
+		valid    bool
+	}{
+		{"", true},
+		{server.URL + "/lamp.png", true},
+		{server.URL + "/page.html", false},
+		{server.URL + "/missing.png", false},
+		{server.URL + "/slow.png", false},
+	}
+	for _, tt := range tests {
+		err := validator.ValidateProduct(context.Background(), &entity.Product{ImageURL: tt.imageURL})
+		if tt.valid && err != nil {
+			t.Errorf("%q: got %v, want it accepted", tt.imageURL, err)
+		}
+		if !tt.valid && !errors.Is(err, entity.ErrImageUnreachable) {
+			t.Errorf("%q: got %v, want %v", tt.imageURL, err, entity.ErrImageUnreachable)
+		}
+	}
+}
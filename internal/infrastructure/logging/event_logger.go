@@ -0,0 +1,83 @@
+// Package logging writes application logs
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/product-management/internal/domain/service"
+)
+
+// NewLogger creates a structured logger writing to w in the given format ("json" or "text")
+// at the given level ("debug", "info", "warn" or "error", defaulting to info). Records logged
+// with a context carrying a request ID are tagged with it as request_id.
+func NewLogger(w io.Writer, format, level string) *slog.Logger {
+	var slogLevel slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		slogLevel = slog.LevelDebug
+	case "warn":
+		slogLevel = slog.LevelWarn
+	case "error":
+		slogLevel = slog.LevelError
+	default:
+		slogLevel = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: slogLevel}
+	if strings.ToLower(format) == "text" {
+		return slog.New(requestIDHandler{slog.NewTextHandler(w, opts)})
+	}
+	return slog.New(requestIDHandler{slog.NewJSONHandler(w, opts)})
+}
+
+// requestIDHandler adds the request ID in a record's context to the record
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID, ok := service.RequestIDFromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
+
+// eventLogger logs business events as structured records at info level
+type eventLogger struct {
+	logger *slog.Logger
+}
+
+// NewEventLogger creates an event logger writing to logger
+func NewEventLogger(logger *slog.Logger) service.EventLogger {
+	return &eventLogger{logger: logger}
+}
+
+// LogEvent logs event with its type, entity, actor and fields as attributes
+func (l *eventLogger) LogEvent(ctx context.Context, event service.BusinessEvent) {
+	attrs := []slog.Attr{
+		slog.String("event", event.Type),
+		slog.String("entity_type", event.EntityType),
+	}
+	if event.EntityID != 0 {
+		attrs = append(attrs, slog.Uint64("entity_id", uint64(event.EntityID)))
+	}
+	if event.ActorID != nil {
+		attrs = append(attrs, slog.Uint64("actor_id", uint64(*event.ActorID)))
+	}
+	for key, value := range event.Fields {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelInfo, "business event", attrs...)
+}
@@ -0,0 +1,37 @@
+// Package mail provides email delivery implementations
+package mail
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// logSender writes emails to the application log instead of delivering them. It stands in
+// for a real mail provider in development; the logged links grant account access.
+type logSender struct {
+	logger *slog.Logger
+}
+
+// NewLogSender creates an email sender that logs emails to logger, or the default logger if it
+// is nil, instead of sending them
+func NewLogSender(logger *slog.Logger) service.EmailSender {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &logSender{logger: logger}
+}
+
+// SendVerificationEmail logs the verification link for user
+func (s *logSender) SendVerificationEmail(ctx context.Context, user *entity.User, verifyURL string) error {
+	s.logger.InfoContext(ctx, "Verification email", slog.String("email", user.Email), slog.String("url", verifyURL))
+	return nil
+}
+
+// SendPasswordResetEmail logs the password reset link for user
+func (s *logSender) SendPasswordResetEmail(ctx context.Context, user *entity.User, resetURL string) error {
+	s.logger.InfoContext(ctx, "Password reset email", slog.String("email", user.Email), slog.String("url", resetURL))
+	return nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// auditLogRepositoryImpl implements the AuditLogRepository interface
+type auditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &auditLogRepositoryImpl{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *auditLogRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
+// Create records an audit entry
+func (r *auditLogRepositoryImpl) Create(ctx context.Context, auditLog *entity.AuditLog) error {
+	if err := r.conn(ctx).Create(auditLog).Error; err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	return nil
+}
+
+// ListByEntity retrieves the audit entries of an entity, oldest first
+func (r *auditLogRepositoryImpl) ListByEntity(ctx context.Context, entityType string, entityID uint) ([]*entity.AuditLog, error) {
+	var auditLogs []*entity.AuditLog
+	err := r.conn(ctx).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at ASC, id ASC").
+		Find(&auditLogs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	return auditLogs, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/cache"
+	"github.com/product-management/internal/infrastructure/database"
+)
+
+// cachedProductRepository caches GetByID results in front of another ProductRepository.
+// Cache failures are logged and fall through to the wrapped repository, so the cache is
+// never a hard dependency.
+type cachedProductRepository struct {
+	repository.ProductRepository
+	cache  cache.Cache
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+// NewCachedProductRepository wraps repo with a read-through cache for products by ID, logging
+// cache failures to logger, or the default logger if it is nil
+func NewCachedProductRepository(repo repository.ProductRepository, c cache.Cache, ttl time.Duration, logger *slog.Logger) repository.ProductRepository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &cachedProductRepository{
+		ProductRepository: repo,
+		cache:             c,
+		ttl:               ttl,
+		logger:            logger,
+	}
+}
+
+// productCacheKey returns the cache key for a product ID
+func productCacheKey(id uint) string {
+	return fmt.Sprintf("product:%d", id)
+}
+
+// GetByID returns the cached product, loading and caching it from the wrapped repository on a miss.
+// Reads inside a transaction bypass the cache so uncommitted rows are never cached.
+func (r *cachedProductRepository) GetByID(ctx context.Context, id uint) (*entity.Product, error) {
+	if database.InTransaction(ctx) {
+		return r.ProductRepository.GetByID(ctx, id)
+	}
+
+	key := productCacheKey(id)
+	data, err := r.cache.Get(ctx, key)
+	if err == nil {
+		var product entity.Product
+		if err := json.Unmarshal(data, &product); err == nil {
+			return &product, nil
+		}
+		r.logger.WarnContext(ctx, "Discarding undecodable cache entry", slog.String("key", key))
+	} else if !errors.Is(err, cache.ErrCacheMiss) {
+		r.logger.WarnContext(ctx, "Failed to read from cache", slog.String("key", key), slog.Any("error", err))
+	}
+
+	product, err := r.ProductRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(product); err == nil {
+		if err := r.cache.Set(ctx, key, data, r.ttl); err != nil {
+			r.logger.WarnContext(ctx, "Failed to write to cache", slog.String("key", key), slog.Any("error", err))
+		}
+	}
+
+	return product, nil
+}
+
+// Update updates a product and evicts it from the cache
+func (r *cachedProductRepository) Update(ctx context.Context, product *entity.Product) error {
+	defer r.invalidate(ctx, product.ID)
+	return r.ProductRepository.Update(ctx, product)
+}
+
+// Delete soft-deletes a product and evicts it from the cache
+func (r *cachedProductRepository) Delete(ctx context.Context, id uint) error {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.Delete(ctx, id)
+}
+
+// HardDelete permanently deletes a product and evicts it from the cache
+func (r *cachedProductRepository) HardDelete(ctx context.Context, id uint) error {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.HardDelete(ctx, id)
+}
+
+// UpdateStock updates a product's stock and evicts it from the cache
+func (r *cachedProductRepository) UpdateStock(ctx context.Context, id uint, stock int) error {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.UpdateStock(ctx, id, stock)
+}
+
+// DecrementStock decrements a product's stock and evicts it from the cache
+func (r *cachedProductRepository) DecrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.DecrementStock(ctx, id, qty)
+}
+
+// IncrementStock increments a product's stock and evicts it from the cache
+func (r *cachedProductRepository) IncrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.IncrementStock(ctx, id, qty)
+}
+
+// ReserveStock reserves part of a product's stock and evicts it from the cache
+func (r *cachedProductRepository) ReserveStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.ReserveStock(ctx, id, qty)
+}
+
+// ReleaseReservedStock returns reserved units to a product's stock and evicts it from the cache
+func (r *cachedProductRepository) ReleaseReservedStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.ReleaseReservedStock(ctx, id, qty)
+}
+
+// ReportBrokenImage counts a broken image report and evicts the product from the cache
+func (r *cachedProductRepository) ReportBrokenImage(ctx context.Context, id uint, threshold int) error {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.ReportBrokenImage(ctx, id, threshold)
+}
+
+// ClearImageReports resets a product's image reports and evicts it from the cache
+func (r *cachedProductRepository) ClearImageReports(ctx context.Context, id uint) error {
+	defer r.invalidate(ctx, id)
+	return r.ProductRepository.ClearImageReports(ctx, id)
+}
+
+// BulkUpdateStatus updates the status of several products and evicts them from the cache
+func (r *cachedProductRepository) BulkUpdateStatus(ctx context.Context, ids []uint, isActive bool) error {
+	defer r.invalidate(ctx, ids...)
+	return r.ProductRepository.BulkUpdateStatus(ctx, ids, isActive)
+}
+
+// invalidate evicts the given products from the cache. Eviction runs even when the write
+// fails, since a partially applied write may still have changed the row.
+func (r *cachedProductRepository) invalidate(ctx context.Context, ids ...uint) {
+	if len(ids) == 0 {
+		return
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = productCacheKey(id)
+	}
+	// Evict even if the request was cancelled mid-write
+	if err := r.cache.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to evict products from cache", slog.Any("ids", ids), slog.Any("error", err))
+	}
+}
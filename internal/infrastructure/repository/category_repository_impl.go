@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// categoryRepositoryImpl implements the CategoryRepository interface
+type categoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository creates a new category repository
+func NewCategoryRepository(db *gorm.DB) repository.CategoryRepository {
+	return &categoryRepositoryImpl{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *categoryRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
+// Create creates a new category
+func (r *categoryRepositoryImpl) Create(ctx context.Context, category *entity.Category) error {
+	if err := r.conn(ctx).Create(category).Error; err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a category by its ID
+func (r *categoryRepositoryImpl) GetByID(ctx context.Context, id uint) (*entity.Category, error) {
+	var category entity.Category
+	if err := r.conn(ctx).First(&category, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category by ID: %w", err)
+	}
+	return &category, nil
+}
+
+// GetByName retrieves a category by its name, compared case-insensitively
+func (r *categoryRepositoryImpl) GetByName(ctx context.Context, name string) (*entity.Category, error) {
+	var category entity.Category
+	if err := r.conn(ctx).Where("LOWER(name) = LOWER(?)", name).First(&category).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category by name: %w", err)
+	}
+	return &category, nil
+}
+
+// GetAll retrieves every category ordered by name
+func (r *categoryRepositoryImpl) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	var categories []*entity.Category
+	if err := r.conn(ctx).Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return categories, nil
+}
+
+// Update updates an existing category
+func (r *categoryRepositoryImpl) Update(ctx context.Context, category *entity.Category) error {
+	if err := r.conn(ctx).Save(category).Error; err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	return nil
+}
+
+// Delete permanently deletes a category by its ID
+func (r *categoryRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	result := r.conn(ctx).Delete(&entity.Category{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete category: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// ExistsByNameOrSlug checks if a category other than excludeID uses the given name or slug
+func (r *categoryRepositoryImpl) ExistsByNameOrSlug(ctx context.Context, name, slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.conn(ctx).Model(&entity.Category{}).
+		Where("(LOWER(name) = LOWER(?) OR slug = ?) AND id <> ?", name, slug, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check category existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CountChildren returns the number of direct subcategories of a category
+func (r *categoryRepositoryImpl) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	if err := r.conn(ctx).Model(&entity.Category{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count subcategories: %w", err)
+	}
+	return count, nil
+}
+
+// CountProducts returns the number of products assigned to a category
+func (r *categoryRepositoryImpl) CountProducts(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	if err := r.conn(ctx).Model(&entity.Product{}).Where("category_id = ?", id).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count category products: %w", err)
+	}
+	return count, nil
+}
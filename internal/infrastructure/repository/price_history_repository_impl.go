@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// priceHistoryRepositoryImpl implements the PriceHistoryRepository interface
+type priceHistoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPriceHistoryRepository creates a new price history repository
+func NewPriceHistoryRepository(db *gorm.DB) repository.PriceHistoryRepository {
+	return &priceHistoryRepositoryImpl{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *priceHistoryRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
+// Create records a price change
+func (r *priceHistoryRepositoryImpl) Create(ctx context.Context, entry *entity.PriceHistory) error {
+	if err := r.conn(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create price history: %w", err)
+	}
+	return nil
+}
+
+// ListByProduct retrieves the price changes of a product, oldest first
+func (r *priceHistoryRepositoryImpl) ListByProduct(ctx context.Context, productID uint) ([]*entity.PriceHistory, error) {
+	var entries []*entity.PriceHistory
+	err := r.conn(ctx).
+		Where("product_id = ?", productID).
+		Order("changed_at ASC, id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDeletedProductQueriesOnlySeeSoftDeletedProducts(t *testing.T) {
+	db := newDryRunDB(t)
+	queries := recordQueries(t, db)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	if _, err := repo.GetDeleted(ctx, 20, 10); err != nil {
+		t.Fatalf("GetDeleted: %v", err)
+	}
+	if _, err := repo.CountDeleted(ctx); err != nil {
+		t.Fatalf("CountDeleted: %v", err)
+	}
+
+	if len(*queries) != 2 {
+		t.Fatalf("got queries %q, want 2", *queries)
+	}
+	for _, query := range *queries {
+		if !strings.Contains(query, "deleted_at IS NOT NULL") || strings.Contains(query, `"products"."deleted_at" IS NULL`) {
+			t.Errorf("got %s, want only soft-deleted products", query)
+		}
+	}
+	if list := (*queries)[0]; !strings.Contains(list, "ORDER BY deleted_at DESC, id DESC LIMIT 10 OFFSET 20") {
+		t.Errorf("got %s, want the most recently deleted first, paginated", list)
+	}
+}
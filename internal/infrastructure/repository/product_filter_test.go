@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// filterSQL returns the SQL listing the products that match filter
+func filterSQL(t *testing.T, filter *repository.ProductFilter) string {
+	t.Helper()
+	r := &productRepositoryImpl{db: newDryRunDB(t)}
+	return r.db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var products []*entity.Product
+		return r.applyFilter(tx.Model(&entity.Product{}), filter).Find(&products)
+	})
+}
+
+func TestApplyFilterBoundsDateRangesInclusively(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	sql := filterSQL(t, &repository.ProductFilter{CreatedFrom: &from, UpdatedTo: &to})
+	for _, want := range []string{"created_at >= '2026-01-01 00:00:00'", "updated_at <= '2026-02-01 00:00:00'"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("got %s, want it to contain %s", sql, want)
+		}
+	}
+	for _, unwanted := range []string{"created_at <=", "updated_at >="} {
+		if strings.Contains(sql, unwanted) {
+			t.Errorf("got %s, want no %s bound for an open end", sql, unwanted)
+		}
+	}
+}
+
+func TestApplyFilterMatchesAnyOfSeveralCategories(t *testing.T) {
+	sql := filterSQL(t, &repository.ProductFilter{Categories: []string{"Lighting", "Office"}})
+	if !strings.Contains(sql, "category IN ('Lighting','Office')") {
+		t.Errorf("got %s, want products in either category", sql)
+	}
+
+	if sql := filterSQL(t, &repository.ProductFilter{}); strings.Contains(sql, "category") {
+		t.Errorf("got %s, want no category condition without categories", sql)
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// productImageRepositoryImpl implements the ProductImageRepository interface
+type productImageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewProductImageRepository creates a new product image repository
+func NewProductImageRepository(db *gorm.DB) repository.ProductImageRepository {
+	return &productImageRepositoryImpl{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *productImageRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
+// Create adds an image to a product's gallery
+func (r *productImageRepositoryImpl) Create(ctx context.Context, image *entity.ProductImage) error {
+	if err := r.conn(ctx).Create(image).Error; err != nil {
+		return fmt.Errorf("failed to create product image: %w", err)
+	}
+	return nil
+}
+
+// ListByProduct retrieves a product's images in display order
+func (r *productImageRepositoryImpl) ListByProduct(ctx context.Context, productID uint) ([]*entity.ProductImage, error) {
+	var images []*entity.ProductImage
+	err := r.conn(ctx).
+		Where("product_id = ?", productID).
+		Order("sort_order ASC, id ASC").
+		Find(&images).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product images: %w", err)
+	}
+	return images, nil
+}
+
+// Delete removes an image from a product's gallery
+func (r *productImageRepositoryImpl) Delete(ctx context.Context, productID, imageID uint) error {
+	result := r.conn(ctx).Where("product_id = ?", productID).Delete(&entity.ProductImage{}, imageID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete product image: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrProductImageNotFound
+	}
+	return nil
+}
+
+// DeleteByProduct removes every image of a product
+func (r *productImageRepositoryImpl) DeleteByProduct(ctx context.Context, productID uint) error {
+	if err := r.conn(ctx).Where("product_id = ?", productID).Delete(&entity.ProductImage{}).Error; err != nil {
+		return fmt.Errorf("failed to delete product images: %w", err)
+	}
+	return nil
+}
+
+// SetSortOrders sets each image's sort order to its position in imageIDs
+func (r *productImageRepositoryImpl) SetSortOrders(ctx context.Context, productID uint, imageIDs []uint) error {
+	for i, imageID := range imageIDs {
+		err := r.conn(ctx).Model(&entity.ProductImage{}).
+			Where("id = ? AND product_id = ?", imageID, productID).
+			Update("sort_order", i).Error
+		if err != nil {
+			return fmt.Errorf("failed to reorder product images: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetPrimary makes imageID the product's only primary image
+func (r *productImageRepositoryImpl) SetPrimary(ctx context.Context, productID, imageID uint) error {
+	err := r.conn(ctx).Model(&entity.ProductImage{}).
+		Where("product_id = ?", productID).
+		Update("is_primary", gorm.Expr("id = ?", imageID)).Error
+	if err != nil {
+		return fmt.Errorf("failed to set primary product image: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExistsByNameIgnoresSoftDeletedProducts(t *testing.T) {
+	db := newDryRunDB(t)
+	queries := recordQueries(t, db)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	if _, err := repo.ExistsByName(ctx, "Desk Lamp"); err != nil {
+		t.Fatalf("ExistsByName: %v", err)
+	}
+	if _, err := repo.ExistsByNameIncludingDeleted(ctx, "Desk Lamp", 7); err != nil {
+		t.Fatalf("ExistsByNameIncludingDeleted: %v", err)
+	}
+
+	if len(*queries) != 2 {
+		t.Fatalf("got queries %q, want 2", *queries)
+	}
+	live, all := (*queries)[0], (*queries)[1]
+	if !strings.Contains(live, `"products"."deleted_at" IS NULL`) {
+		t.Errorf("ExistsByName: got %s, want soft-deleted products excluded", live)
+	}
+	if strings.Contains(all, "deleted_at") || !strings.Contains(all, "id <> $2") {
+		t.Errorf("ExistsByNameIncludingDeleted: got %s, want every product but the excluded one counted", all)
+	}
+}
@@ -3,12 +3,27 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/product-management/internal/domain/entity"
 	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// productReferenceTables maps each kind of record that can reference a product to its table.
+// Every listed table is expected to have a product_id column; register new relations here
+// so they are reported before a product is deleted. Product reviews and stock movements will
+// belong here too, but neither has a table yet.
+var productReferenceTables = map[string]string{
+	"images":        "product_images",
+	"price_history": "price_history",
+	"reservations":  "reservations",
+}
+
 // productRepositoryImpl implements the ProductRepository interface
 type productRepositoryImpl struct {
 	db *gorm.DB
@@ -21,9 +36,23 @@ func NewProductRepository(db *gorm.DB) repository.ProductRepository {
 	}
 }
 
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *productRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
 // Create creates a new product
 func (r *productRepositoryImpl) Create(ctx context.Context, product *entity.Product) error {
-	if err := r.db.WithContext(ctx).Create(product).Error; err != nil {
+	if product.Version == 0 {
+		product.Version = 1
+	}
+	if err := r.conn(ctx).Create(product).Error; err != nil {
+		if database.IsUniqueViolationOf(err, database.ProductSKUIndex) {
+			return entity.ErrProductSKUExists
+		}
+		if database.IsUniqueViolationOf(err, database.ProductNameCategoryIndex) {
+			return entity.ErrProductAlreadyExists
+		}
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 	return nil
@@ -32,7 +61,15 @@ func (r *productRepositoryImpl) Create(ctx context.Context, product *entity.Prod
 // GetByID retrieves a product by its ID
 func (r *productRepositoryImpl) GetByID(ctx context.Context, id uint) (*entity.Product, error) {
 	var product entity.Product
-	if err := r.db.WithContext(ctx).First(&product, id).Error; err != nil {
+	err := r.conn(ctx).
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, id ASC")
+		}).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tag ASC")
+		}).
+		First(&product, id).Error
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entity.ErrProductNotFound
 		}
@@ -41,15 +78,52 @@ func (r *productRepositoryImpl) GetByID(ctx context.Context, id uint) (*entity.P
 	return &product, nil
 }
 
+// GetByIDs retrieves the live products among ids with their images and tags
+func (r *productRepositoryImpl) GetByIDs(ctx context.Context, ids []uint) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.conn(ctx).
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, id ASC")
+		}).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tag ASC")
+		}).
+		Where("id IN ?", ids).
+		Find(&products).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products by IDs: %w", err)
+	}
+	return products, nil
+}
+
 // GetAll retrieves all products with optional filtering and pagination
 func (r *productRepositoryImpl) GetAll(ctx context.Context, filter *repository.ProductFilter, offset, limit int) ([]*entity.Product, error) {
 	var products []*entity.Product
-	query := r.db.WithContext(ctx)
+	query := r.conn(ctx)
 
 	if filter != nil {
 		query = r.applyFilter(query, filter)
 	}
 
+	// Search matches are scored, and ordered by relevance unless another order was requested
+	score, scoreArgs, ranked := "", []interface{}(nil), false
+	if filter != nil {
+		score, scoreArgs, ranked = searchScore(filter)
+	}
+	if ranked {
+		query = query.Select("products.*, ("+score+") AS search_rank", scoreArgs...)
+	}
+
+	var err error
+	if ranked && filter.OrderBy == "" {
+		query = query.Order("search_rank DESC, id DESC")
+	} else {
+		query, err = r.applyOrder(query, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if err := query.Offset(offset).Limit(limit).Find(&products).Error; err != nil {
 		return nil, fmt.Errorf("failed to get products: %w", err)
 	}
@@ -57,10 +131,26 @@ func (r *productRepositoryImpl) GetAll(ctx context.Context, filter *repository.P
 	return products, nil
 }
 
+// GetAfterID retrieves products with an ID greater than afterID in ascending ID order
+func (r *productRepositoryImpl) GetAfterID(ctx context.Context, filter *repository.ProductFilter, afterID uint, limit int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	query := r.conn(ctx)
+
+	if filter != nil {
+		query = r.applyFilter(query, filter)
+	}
+
+	if err := query.Where("id > ?", afterID).Order("id asc").Limit(limit).Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to get products after ID: %w", err)
+	}
+
+	return products, nil
+}
+
 // GetTotalCount returns the total count of products with optional filtering
 func (r *productRepositoryImpl) GetTotalCount(ctx context.Context, filter *repository.ProductFilter) (int64, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&entity.Product{})
+	query := r.conn(ctx).Model(&entity.Product{})
 
 	if filter != nil {
 		query = r.applyFilter(query, filter)
@@ -73,17 +163,40 @@ func (r *productRepositoryImpl) GetTotalCount(ctx context.Context, filter *repos
 	return count, nil
 }
 
-// Update updates an existing product
+// Update saves a product and moves it to the next version, provided it is still at the version
+// it was read at. It returns entity.ErrConcurrentModification if the product has changed since,
+// so one writer never silently overwrites another's changes.
 func (r *productRepositoryImpl) Update(ctx context.Context, product *entity.Product) error {
-	if err := r.db.WithContext(ctx).Save(product).Error; err != nil {
-		return fmt.Errorf("failed to update product: %w", err)
+	version := product.Version
+	product.Version = version + 1
+
+	// Images and tags are managed through their own repositories, and image reports and
+	// reserved units are counted atomically, so never write back stale copies
+	result := r.conn(ctx).Model(product).
+		Where("version = ?", version).
+		Select("*").
+		Omit(append([]string{clause.Associations, "reserved"}, imageReportColumns...)...).
+		Updates(product)
+	if result.Error != nil {
+		product.Version = version
+		if database.IsUniqueViolationOf(result.Error, database.ProductSKUIndex) {
+			return entity.ErrProductSKUExists
+		}
+		if database.IsUniqueViolationOf(result.Error, database.ProductNameCategoryIndex) {
+			return entity.ErrProductAlreadyExists
+		}
+		return fmt.Errorf("failed to update product: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		product.Version = version
+		return entity.ErrConcurrentModification
 	}
 	return nil
 }
 
 // Delete soft-deletes a product by its ID
 func (r *productRepositoryImpl) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&entity.Product{}, id).Error; err != nil {
+	if err := r.conn(ctx).Delete(&entity.Product{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
 	return nil
@@ -91,16 +204,37 @@ func (r *productRepositoryImpl) Delete(ctx context.Context, id uint) error {
 
 // HardDelete permanently deletes a product by its ID
 func (r *productRepositoryImpl) HardDelete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Unscoped().Delete(&entity.Product{}, id).Error; err != nil {
+	if err := r.conn(ctx).Unscoped().Delete(&entity.Product{}, id).Error; err != nil {
 		return fmt.Errorf("failed to hard delete product: %w", err)
 	}
 	return nil
 }
 
+// GetBySKU retrieves a live product by its SKU
+func (r *productRepositoryImpl) GetBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	var product entity.Product
+	if err := r.conn(ctx).Where("sku = ?", sku).First(&product).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product by SKU: %w", err)
+	}
+	return &product, nil
+}
+
+// NextSKUSequence returns the next value of the product SKU sequence
+func (r *productRepositoryImpl) NextSKUSequence(ctx context.Context) (int64, error) {
+	var next int64
+	if err := r.conn(ctx).Raw("SELECT nextval('" + database.ProductSKUSequence + "')").Scan(&next).Error; err != nil {
+		return 0, fmt.Errorf("failed to get next SKU sequence value: %w", err)
+	}
+	return next, nil
+}
+
 // GetByName retrieves a product by its name
 func (r *productRepositoryImpl) GetByName(ctx context.Context, name string) (*entity.Product, error) {
 	var product entity.Product
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&product).Error; err != nil {
+	if err := r.conn(ctx).Where("name = ?", name).First(&product).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entity.ErrProductNotFound
 		}
@@ -109,20 +243,75 @@ func (r *productRepositoryImpl) GetByName(ctx context.Context, name string) (*en
 	return &product, nil
 }
 
-// ExistsByName checks if a product with the given name exists
+// ExistsByName checks if a live product with the given name exists. The default soft-delete
+// scope applies, so soft-deleted products don't block reusing their name.
 func (r *productRepositoryImpl) ExistsByName(ctx context.Context, name string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&entity.Product{}).Where("name = ?", name).Count(&count).Error; err != nil {
+	if err := r.conn(ctx).Model(&entity.Product{}).Where("name = ?", name).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check product existence by name: %w", err)
 	}
 	return count > 0, nil
 }
 
-// GetByCategory retrieves products by category
-func (r *productRepositoryImpl) GetByCategory(ctx context.Context, category string, offset, limit int) ([]*entity.Product, error) {
+// GetByNameInCategory retrieves the live product with the given name in category
+func (r *productRepositoryImpl) GetByNameInCategory(ctx context.Context, name, category string) (*entity.Product, error) {
+	var product entity.Product
+	if err := r.conn(ctx).Where("name = ? AND category = ?", name, category).First(&product).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product by name in category: %w", err)
+	}
+	return &product, nil
+}
+
+// ExistsByNameInCategory checks if a live product other than excludeID has the given name in
+// category
+func (r *productRepositoryImpl) ExistsByNameInCategory(ctx context.Context, name, category string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.conn(ctx).Model(&entity.Product{}).
+		Where("name = ? AND category = ? AND id <> ?", name, category, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check product existence by name in category: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ExistsByNameIncludingDeleted checks if any product, live or soft-deleted, other than
+// excludeID has the given name
+func (r *productRepositoryImpl) ExistsByNameIncludingDeleted(ctx context.Context, name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.conn(ctx).Unscoped().Model(&entity.Product{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check product existence by name including deleted: %w", err)
+	}
+	return count > 0, nil
+}
+
+// suggestionMinSimilarity is the minimum pg_trgm similarity for a name to be suggested
+const suggestionMinSimilarity = 0.2
+
+// SuggestNames returns names of products similar to term using trigram similarity
+func (r *productRepositoryImpl) SuggestNames(ctx context.Context, term string, limit int) ([]string, error) {
+	var names []string
+	if err := r.conn(ctx).Model(&entity.Product{}).
+		Where("is_active = ? AND similarity(name, ?) > ?", true, term, suggestionMinSimilarity).
+		Order(gorm.Expr("similarity(name, ?) DESC", term)).
+		Limit(limit).
+		Pluck("name", &names).Error; err != nil {
+		return nil, fmt.Errorf("failed to suggest product names: %w", err)
+	}
+	return names, nil
+}
+
+// GetByCategory retrieves active products in any of the given categories
+func (r *productRepositoryImpl) GetByCategory(ctx context.Context, categories []string, offset, limit int) ([]*entity.Product, error) {
 	var products []*entity.Product
-	if err := r.db.WithContext(ctx).
-		Where("category = ? AND is_active = ?", category, true).
+	if err := r.conn(ctx).
+		Where("category IN ? AND is_active = ?", categories, true).
 		Offset(offset).Limit(limit).
 		Find(&products).Error; err != nil {
 		return nil, fmt.Errorf("failed to get products by category: %w", err)
@@ -130,17 +319,362 @@ func (r *productRepositoryImpl) GetByCategory(ctx context.Context, category stri
 	return products, nil
 }
 
+// lowStockCondition matches active products at or below their own low stock threshold
+const lowStockCondition = "is_active = ? AND stock <= low_stock_threshold"
+
+// GetLowStock retrieves active products whose stock is at or below their low stock threshold
+func (r *productRepositoryImpl) GetLowStock(ctx context.Context, offset, limit int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	if err := r.conn(ctx).
+		Where(lowStockCondition, true).
+		Order("stock ASC, id ASC").
+		Offset(offset).Limit(limit).
+		Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to get low stock products: %w", err)
+	}
+	return products, nil
+}
+
+// CountLowStock returns the number of active products at or below their low stock threshold
+func (r *productRepositoryImpl) CountLowStock(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.conn(ctx).Model(&entity.Product{}).Where(lowStockCondition, true).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count low stock products: %w", err)
+	}
+	return count, nil
+}
+
+// nextVersion moves a product to its next version in statements that change it without going
+// through Update, so edits based on the version before them are rejected. Image reports don't
+// count as they are never written by Update.
+var nextVersion = gorm.Expr("version + 1")
+
+// imageReportColumns are the columns written only by ReportBrokenImage and ClearImageReports
+var imageReportColumns = []string{"image_reports", "image_reported_at", "needs_review"}
+
+// GetImageReports retrieves products with broken image reports, flagged ones first
+func (r *productRepositoryImpl) GetImageReports(ctx context.Context, offset, limit int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	if err := r.conn(ctx).
+		Where("image_reports > 0").
+		Order("needs_review DESC, image_reports DESC, id ASC").
+		Offset(offset).Limit(limit).
+		Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to get products with image reports: %w", err)
+	}
+	return products, nil
+}
+
+// CountImageReports returns the number of products with broken image reports
+func (r *productRepositoryImpl) CountImageReports(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.conn(ctx).Model(&entity.Product{}).Where("image_reports > 0").Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count products with image reports: %w", err)
+	}
+	return count, nil
+}
+
+// GetStats aggregates the products matching the filter in the database
+func (r *productRepositoryImpl) GetStats(ctx context.Context, filter *repository.ProductFilter) (*repository.ProductStats, error) {
+	base := func() *gorm.DB {
+		query := r.conn(ctx).Model(&entity.Product{})
+		if filter != nil {
+			query = r.applyFilter(query, filter)
+		}
+		return query
+	}
+
+	var totals struct {
+		Total          int64
+		Active         int64
+		InventoryValue float64
+		AveragePrice   float64
+	}
+	err := base().
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE is_active) AS active,
+			COALESCE(SUM(price * stock), 0) AS inventory_value,
+			COALESCE(AVG(price), 0) AS average_price`).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate product stats: %w", err)
+	}
+
+	var categories []struct {
+		Category string
+		Count    int64
+	}
+	if err := base().Select("category, COUNT(*) AS count").Group("category").Scan(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to count products per category: %w", err)
+	}
+
+	stats := &repository.ProductStats{
+		Total:          totals.Total,
+		Active:         totals.Active,
+		Inactive:       totals.Total - totals.Active,
+		InventoryValue: totals.InventoryValue,
+		AveragePrice:   totals.AveragePrice,
+		ByCategory:     make(map[string]int64, len(categories)),
+	}
+	for _, category := range categories {
+		stats.ByCategory[category.Category] = category.Count
+	}
+	return stats, nil
+}
+
 // UpdateStock updates the stock quantity of a product
 func (r *productRepositoryImpl) UpdateStock(ctx context.Context, id uint, stock int) error {
-	if err := r.db.WithContext(ctx).Model(&entity.Product{}).Where("id = ?", id).Update("stock", stock).Error; err != nil {
+	if err := r.conn(ctx).Model(&entity.Product{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"stock":   stock,
+		"version": nextVersion,
+	}).Error; err != nil {
 		return fmt.Errorf("failed to update product stock: %w", err)
 	}
 	return nil
 }
 
+// DecrementStock atomically subtracts qty from a product's stock and returns the product as
+// updated
+func (r *productRepositoryImpl) DecrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	// The stock guard lives in the same statement so concurrent decrements can't oversell
+	product, err := r.adjustStock(ctx, "id = ? AND stock >= ?", []interface{}{id, qty}, map[string]interface{}{
+		"stock": gorm.Expr("stock - ?", qty),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrement product stock: %w", err)
+	}
+	if product == nil {
+		return nil, r.stockGuardError(ctx, id, entity.ErrInsufficientStock)
+	}
+	return product, nil
+}
+
+// IncrementStock atomically adds qty to a product's stock and returns the product as updated
+func (r *productRepositoryImpl) IncrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	product, err := r.adjustStock(ctx, "id = ?", []interface{}{id}, map[string]interface{}{
+		"stock": gorm.Expr("stock + ?", qty),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment product stock: %w", err)
+	}
+	if product == nil {
+		return nil, entity.ErrProductNotFound
+	}
+	return product, nil
+}
+
+// ReserveStock atomically moves qty units of a product's stock to its reserved units and
+// returns the product as updated
+func (r *productRepositoryImpl) ReserveStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	product, err := r.adjustStock(ctx, "id = ? AND stock >= ?", []interface{}{id, qty}, map[string]interface{}{
+		"stock":    gorm.Expr("stock - ?", qty),
+		"reserved": gorm.Expr("reserved + ?", qty),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve product stock: %w", err)
+	}
+	if product == nil {
+		return nil, r.stockGuardError(ctx, id, entity.ErrInsufficientStock)
+	}
+	return product, nil
+}
+
+// ReleaseReservedStock atomically moves qty of a product's reserved units back to its stock
+// and returns the product as updated
+func (r *productRepositoryImpl) ReleaseReservedStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	// Only units that were reserved can be released, so a release never adds stock
+	product, err := r.adjustStock(ctx, "id = ? AND reserved >= ?", []interface{}{id, qty}, map[string]interface{}{
+		"stock":    gorm.Expr("stock + ?", qty),
+		"reserved": gorm.Expr("reserved - ?", qty),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to release reserved product stock: %w", err)
+	}
+	if product == nil {
+		return nil, r.stockGuardError(ctx, id, fmt.Errorf("product %d has fewer than %d reserved units to release", id, qty))
+	}
+	return product, nil
+}
+
+// adjustStock applies a relative stock change to the product matching where in a single
+// statement, returning the row as updated, or nil if no product matched
+func (r *productRepositoryImpl) adjustStock(ctx context.Context, where string, args []interface{}, updates map[string]interface{}) (*entity.Product, error) {
+	updates["version"] = nextVersion
+
+	var products []*entity.Product
+	result := r.conn(ctx).Model(&products).
+		Clauses(clause.Returning{}).
+		Where(where, args...).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(products) == 0 {
+		return nil, nil
+	}
+	return products[0], nil
+}
+
+// stockGuardError explains why a guarded stock change matched no row: the product is gone,
+// or guardErr when it exists but failed the guard
+func (r *productRepositoryImpl) stockGuardError(ctx context.Context, id uint, guardErr error) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return guardErr
+}
+
+// ReportBrokenImage atomically counts a broken image report, flagging the product for review
+// in the same statement once it reaches threshold reports
+func (r *productRepositoryImpl) ReportBrokenImage(ctx context.Context, id uint, threshold int) error {
+	result := r.conn(ctx).Model(&entity.Product{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"image_reports":     gorm.Expr("image_reports + 1"),
+			"image_reported_at": time.Now(),
+			"needs_review":      gorm.Expr("needs_review OR image_reports + 1 >= ?", threshold),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to report broken product image: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrProductNotFound
+	}
+	return nil
+}
+
+// ClearImageReports resets a product's broken image reports and review flag
+func (r *productRepositoryImpl) ClearImageReports(ctx context.Context, id uint) error {
+	result := r.conn(ctx).Model(&entity.Product{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"image_reports":     0,
+			"image_reported_at": nil,
+			"needs_review":      false,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to clear product image reports: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrProductNotFound
+	}
+	return nil
+}
+
+// CountReferences counts the records of each related kind that reference a product
+func (r *productRepositoryImpl) CountReferences(ctx context.Context, id uint) (entity.ProductReferences, error) {
+	references := make(entity.ProductReferences, len(productReferenceTables))
+	for kind, table := range productReferenceTables {
+		var count int64
+		if err := r.conn(ctx).Table(table).Where("product_id = ?", id).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to count product %s: %w", kind, err)
+		}
+		references[kind] = count
+	}
+	return references, nil
+}
+
+// PartitionIDs reports which of the given IDs belong to live, soft-deleted or no products
+func (r *productRepositoryImpl) PartitionIDs(ctx context.Context, ids []uint) (*repository.ProductIDPartition, error) {
+	var rows []struct {
+		ID        uint
+		DeletedAt gorm.DeletedAt
+	}
+	err := r.conn(ctx).Unscoped().Model(&entity.Product{}).
+		Select("id, deleted_at").
+		Where("id IN ?", ids).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up product IDs: %w", err)
+	}
+
+	deleted := make(map[uint]bool, len(rows))
+	for _, row := range rows {
+		deleted[row.ID] = row.DeletedAt.Valid
+	}
+
+	partition := &repository.ProductIDPartition{}
+	for _, id := range ids {
+		isDeleted, found := deleted[id]
+		switch {
+		case !found:
+			partition.NotFound = append(partition.NotFound, id)
+		case isDeleted:
+			partition.SoftDeleted = append(partition.SoftDeleted, id)
+		default:
+			partition.Matched = append(partition.Matched, id)
+		}
+	}
+	return partition, nil
+}
+
+// GetDeleted retrieves soft-deleted products, most recently deleted first
+func (r *productRepositoryImpl) GetDeleted(ctx context.Context, offset, limit int) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := r.conn(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&products).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted products: %w", err)
+	}
+	return products, nil
+}
+
+// CountDeleted returns the number of soft-deleted products
+func (r *productRepositoryImpl) CountDeleted(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.conn(ctx).Unscoped().Model(&entity.Product{}).Where("deleted_at IS NOT NULL").Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count deleted products: %w", err)
+	}
+	return count, nil
+}
+
+// RefreshSearchVectors recomputes the generated search_vector column of the next batch of
+// products by setting it to its default, i.e. its expression. Only the batch's rows are
+// locked, and only until the statement commits.
+func (r *productRepositoryImpl) RefreshSearchVectors(ctx context.Context, afterID uint, limit int) (int, uint, error) {
+	var ids []uint
+	err := r.conn(ctx).Raw(`WITH batch AS (SELECT id FROM products WHERE id > ? ORDER BY id LIMIT ?)
+		UPDATE products SET search_vector = DEFAULT FROM batch WHERE products.id = batch.id
+		RETURNING products.id`, afterID, limit).Scan(&ids).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to refresh product search vectors: %w", err)
+	}
+
+	lastID := afterID
+	for _, id := range ids {
+		lastID = max(lastID, id)
+	}
+	return len(ids), lastID, nil
+}
+
+// GetSearchIndexVersion returns the version recorded in the search index version marker
+func (r *productRepositoryImpl) GetSearchIndexVersion(ctx context.Context) (int, error) {
+	var version int
+	if err := r.conn(ctx).Raw(`SELECT version FROM ` + database.SearchIndexVersionTable + ` WHERE id = 1`).Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to read search index version: %w", err)
+	}
+	return version, nil
+}
+
+// SetSearchIndexVersion updates the search index version marker
+func (r *productRepositoryImpl) SetSearchIndexVersion(ctx context.Context, version int) error {
+	err := r.conn(ctx).Exec(`INSERT INTO `+database.SearchIndexVersionTable+` (id, version, updated_at) VALUES (1, ?, now())
+		ON CONFLICT (id) DO UPDATE SET version = EXCLUDED.version, updated_at = EXCLUDED.updated_at`, version).Error
+	if err != nil {
+		return fmt.Errorf("failed to record search index version: %w", err)
+	}
+	return nil
+}
+
 // BulkUpdateStatus updates the active status of multiple products
 func (r *productRepositoryImpl) BulkUpdateStatus(ctx context.Context, ids []uint, isActive bool) error {
-	if err := r.db.WithContext(ctx).Model(&entity.Product{}).Where("id IN ?", ids).Update("is_active", isActive).Error; err != nil {
+	if err := r.conn(ctx).Model(&entity.Product{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"is_active": isActive,
+		"version":   nextVersion,
+	}).Error; err != nil {
 		return fmt.Errorf("failed to bulk update product status: %w", err)
 	}
 	return nil
@@ -148,8 +682,18 @@ func (r *productRepositoryImpl) BulkUpdateStatus(ctx context.Context, ids []uint
 
 // applyFilter applies filters to the query
 func (r *productRepositoryImpl) applyFilter(query *gorm.DB, filter *repository.ProductFilter) *gorm.DB {
-	if filter.Category != "" {
-		query = query.Where("category = ?", filter.Category)
+	if len(filter.Categories) > 0 {
+		query = query.Where("category IN ?", filter.Categories)
+	}
+
+	if len(filter.Tags) > 0 {
+		tagged := query.Session(&gorm.Session{NewDB: true}).Model(&entity.ProductTag{}).
+			Select("product_id").Where("tag IN ?", filter.Tags)
+		// Tags are unique per product, so having every tag means matching as many rows as tags
+		if filter.TagMatch != repository.TagMatchAny {
+			tagged = tagged.Group("product_id").Having("COUNT(*) = ?", len(filter.Tags))
+		}
+		query = query.Where("products.id IN (?)", tagged)
 	}
 	
 	if filter.MinPrice != nil {
@@ -164,10 +708,121 @@ func (r *productRepositoryImpl) applyFilter(query *gorm.DB, filter *repository.P
 		query = query.Where("is_active = ?", *filter.IsActive)
 	}
 	
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	
+	if filter.UpdatedFrom != nil {
+		query = query.Where("updated_at >= ?", *filter.UpdatedFrom)
+	}
+	
+	if filter.UpdatedTo != nil {
+		query = query.Where("updated_at <= ?", *filter.UpdatedTo)
+	}
+	
 	if filter.SearchTerm != "" {
-		searchPattern := "%" + filter.SearchTerm + "%"
-		query = query.Where("name ILIKE ? OR description ILIKE ?", searchPattern, searchPattern)
+		if tsQuery, ok := fullTextQuery(filter.SearchTerm); ok {
+			query = query.Where("search_vector @@ to_tsquery(?, ?)", searchConfig, tsQuery)
+		} else {
+			searchPattern := "%" + filter.SearchTerm + "%"
+			query = query.Where("name ILIKE ? OR description ILIKE ?", searchPattern, searchPattern)
+		}
+		if filter.MinScore != nil {
+			if score, args, ok := searchScore(filter); ok {
+				query = query.Where("("+score+") >= ?", append(args, *filter.MinScore)...)
+			}
+		}
 	}
 	
 	return query
 }
+
+// searchConfig is the Postgres text search configuration used for search_vector
+const searchConfig = "english"
+
+// minFullTextTermLength is the shortest search term matched with full-text search;
+// shorter terms fall back to a substring (ILIKE) match
+const minFullTextTermLength = 3
+
+// fullTextQuery converts a search term into a to_tsquery expression that requires every word
+// and matches them as prefixes, e.g. "lapt pro" becomes "lapt:* & pro:*". Operators in the
+// input are stripped. It reports false when the term is too short for full-text search.
+func fullTextQuery(term string) (string, bool) {
+	if len([]rune(strings.TrimSpace(term))) < minFullTextTermLength {
+		return "", false
+	}
+
+	words := strings.FieldsFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "", false
+	}
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & "), true
+}
+
+// searchScore returns the SQL expression scoring how well a product matches the filter's search
+// term, with its arguments, and reports false when matches are not scored. In weighted mode
+// name matches (weight A) count more than description matches (weight B), and an exact or
+// prefix match on the whole name adds a bonus; terms too short for full-text search are scored
+// on whether the name contains them. Text mode scores full-text matches with the plain rank.
+func searchScore(filter *repository.ProductFilter) (string, []interface{}, bool) {
+	tsQuery, fullText := fullTextQuery(filter.SearchTerm)
+
+	if filter.Relevance == repository.SearchRelevanceText {
+		if !fullText {
+			return "", nil, false
+		}
+		return "ts_rank(search_vector, to_tsquery(?, ?))", []interface{}{searchConfig, tsQuery}, true
+	}
+
+	term := strings.TrimSpace(filter.SearchTerm)
+	if term == "" {
+		return "", nil, false
+	}
+
+	var score string
+	var args []interface{}
+	if fullText {
+		score = "ts_rank('{0.1, 0.2, 0.4, 1.0}', search_vector, to_tsquery(?, ?))"
+		args = []interface{}{searchConfig, tsQuery}
+	} else {
+		score = "CASE WHEN name ILIKE ? THEN 1.0 ELSE 0.4 END"
+		args = []interface{}{"%" + filter.SearchTerm + "%"}
+	}
+	score += " + CASE WHEN lower(name) = lower(?) THEN 1.0 WHEN name ILIKE ? THEN 0.5 ELSE 0 END"
+	args = append(args, term, term+"%")
+	return score, args, true
+}
+
+// applyOrder applies the filter's ordering to the query, defaulting to created_at desc
+func (r *productRepositoryImpl) applyOrder(query *gorm.DB, filter *repository.ProductFilter) (*gorm.DB, error) {
+	orderBy := repository.DefaultProductOrderBy
+	orderDir := repository.DefaultProductOrderDir
+
+	if filter != nil && filter.OrderBy != "" {
+		orderBy = strings.ToLower(filter.OrderBy)
+	}
+	if filter != nil && filter.OrderDir != "" {
+		orderDir = strings.ToLower(filter.OrderDir)
+	}
+
+	// Only allowlisted columns may be interpolated into the ORDER BY clause
+	if !repository.ProductOrderColumns[orderBy] {
+		return nil, entity.ErrInvalidInput
+	}
+	if orderDir != "asc" && orderDir != "desc" {
+		return nil, entity.ErrInvalidInput
+	}
+
+	// Tie-break on id so pages stay stable when the sort column has duplicates
+	return query.Order(fmt.Sprintf("%s %s, id %s", orderBy, orderDir, orderDir)), nil
+}
@@ -0,0 +1,67 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/product-management/internal/config"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/infrastructure/database"
+)
+
+// newIntegrationDB connects to and migrates the database configured by the DB_* variables.
+// Run with: go test -tags integration ./internal/infrastructure/repository/
+func newIntegrationDB(t *testing.T) *database.Database {
+	t.Helper()
+	db, err := database.NewDatabase(config.LoadConfig(), nil)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.AutoMigrate(); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+func TestConcurrentDecrementsNeverOversell(t *testing.T) {
+	db := newIntegrationDB(t)
+	repo := NewProductRepository(db.GetDB())
+	ctx := context.Background()
+
+	product := &entity.Product{Name: "Oversell Test Lamp", Price: 10, Stock: 10, IsActive: true}
+	if err := repo.Create(ctx, product); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() { repo.HardDelete(ctx, product.ID) })
+
+	var sold atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.DecrementStock(ctx, product.ID, 1)
+			switch {
+			case err == nil:
+				sold.Add(1)
+			case !errors.Is(err, entity.ErrInsufficientStock):
+				t.Errorf("DecrementStock: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stored, err := repo.GetByID(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.Stock != 0 || sold.Load() != 10 {
+		t.Errorf("got stock=%d after %d sales, want 0 after 10", stored.Stock, sold.Load())
+	}
+}
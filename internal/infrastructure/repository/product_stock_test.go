@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+func TestDecrementStockGuardsTheStockInTheUpdate(t *testing.T) {
+	db := newDryRunDB(t)
+	var statement string
+	err := db.Callback().Update().After("gorm:update").Register("test:record_update", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	repo := NewProductRepository(db.Session(&gorm.Session{SkipDefaultTransaction: true}))
+
+	// A dry run updates no rows and reads back an empty product, as for an existing product
+	// with too little stock
+	_, err = repo.DecrementStock(context.Background(), 7, 3)
+	if !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Errorf("got %v, want %v when the guard matches no row", err, entity.ErrInsufficientStock)
+	}
+
+	for _, want := range []string{`"stock"=stock - $`, "WHERE (id = $", "AND stock >= $", "RETURNING"} {
+		if !strings.Contains(statement, want) {
+			t.Errorf("got %s, want the check and the decrement in one statement containing %s", statement, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// productTagRepositoryImpl implements the ProductTagRepository interface
+type productTagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewProductTagRepository creates a new product tag repository
+func NewProductTagRepository(db *gorm.DB) repository.ProductTagRepository {
+	return &productTagRepositoryImpl{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *productTagRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
+// SetTags replaces a product's tags with the given normalized tags. Callers should run it
+// in a transaction so the product is never seen without its tags.
+func (r *productTagRepositoryImpl) SetTags(ctx context.Context, productID uint, tags []string) error {
+	if err := r.conn(ctx).Where("product_id = ?", productID).Delete(&entity.ProductTag{}).Error; err != nil {
+		return fmt.Errorf("failed to clear product tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	rows := make([]*entity.ProductTag, len(tags))
+	for i, tag := range tags {
+		rows[i] = &entity.ProductTag{ProductID: productID, Tag: tag}
+	}
+	if err := r.conn(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to set product tags: %w", err)
+	}
+	return nil
+}
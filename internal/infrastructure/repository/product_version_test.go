@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+func TestUpdateOnlySavesTheVersionItWasReadAt(t *testing.T) {
+	db := newDryRunDB(t)
+	var statement string
+	err := db.Callback().Update().After("gorm:update").Register("test:record_update", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	repo := NewProductRepository(db.Session(&gorm.Session{SkipDefaultTransaction: true}))
+
+	// A dry run updates no rows, just like an update of a product saved by someone else since
+	product := &entity.Product{ID: 7, Name: "Desk Lamp", Price: 10, Version: 3}
+	if err := repo.Update(context.Background(), product); !errors.Is(err, entity.ErrConcurrentModification) {
+		t.Fatalf("got %v, want %v when no row matches the version", err, entity.ErrConcurrentModification)
+	}
+
+	if !strings.Contains(statement, `"version"=$`) || !strings.Contains(statement, "version = $") {
+		t.Errorf("got %s, want the version both set and matched", statement)
+	}
+	if product.Version != 3 {
+		t.Errorf("got version %d after the rejected update, want it restored to 3", product.Version)
+	}
+}
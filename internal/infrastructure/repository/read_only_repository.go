@@ -0,0 +1,508 @@
+package repository
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+)
+
+// ReadOnlyMode is a switch shared by the read-only repository decorators. While it is
+// enabled their write methods fail with entity.ErrReadOnlyMode and reads pass through.
+type ReadOnlyMode struct {
+	enabled atomic.Bool
+}
+
+// NewReadOnlyMode creates a read-only switch in the given initial state
+func NewReadOnlyMode(enabled bool) *ReadOnlyMode {
+	m := &ReadOnlyMode{}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether writes are currently rejected
+func (m *ReadOnlyMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Set turns read-only mode on or off
+func (m *ReadOnlyMode) Set(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// check returns entity.ErrReadOnlyMode while read-only mode is enabled
+func (m *ReadOnlyMode) check() error {
+	if m.Enabled() {
+		return entity.ErrReadOnlyMode
+	}
+	return nil
+}
+
+// readOnlyProductRepository rejects product writes while read-only mode is enabled
+type readOnlyProductRepository struct {
+	repository.ProductRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyProductRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyProductRepository(repo repository.ProductRepository, mode *ReadOnlyMode) repository.ProductRepository {
+	return &readOnlyProductRepository{
+		ProductRepository: repo,
+		mode:              mode,
+	}
+}
+
+// Create creates a product unless read-only mode is enabled
+func (r *readOnlyProductRepository) Create(ctx context.Context, product *entity.Product) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.Create(ctx, product)
+}
+
+// Update updates a product unless read-only mode is enabled
+func (r *readOnlyProductRepository) Update(ctx context.Context, product *entity.Product) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.Update(ctx, product)
+}
+
+// Delete soft-deletes a product unless read-only mode is enabled
+func (r *readOnlyProductRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.Delete(ctx, id)
+}
+
+// RefreshSearchVectors recomputes product search vectors unless read-only mode is enabled
+func (r *readOnlyProductRepository) RefreshSearchVectors(ctx context.Context, afterID uint, limit int) (int, uint, error) {
+	if err := r.mode.check(); err != nil {
+		return 0, 0, err
+	}
+	return r.ProductRepository.RefreshSearchVectors(ctx, afterID, limit)
+}
+
+// SetSearchIndexVersion records the search index version unless read-only mode is enabled
+func (r *readOnlyProductRepository) SetSearchIndexVersion(ctx context.Context, version int) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.SetSearchIndexVersion(ctx, version)
+}
+
+// NextSKUSequence advances the SKU sequence unless read-only mode is enabled
+func (r *readOnlyProductRepository) NextSKUSequence(ctx context.Context) (int64, error) {
+	if err := r.mode.check(); err != nil {
+		return 0, err
+	}
+	return r.ProductRepository.NextSKUSequence(ctx)
+}
+
+// HardDelete permanently deletes a product unless read-only mode is enabled
+func (r *readOnlyProductRepository) HardDelete(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.HardDelete(ctx, id)
+}
+
+// UpdateStock sets a product's stock unless read-only mode is enabled
+func (r *readOnlyProductRepository) UpdateStock(ctx context.Context, id uint, stock int) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.UpdateStock(ctx, id, stock)
+}
+
+// DecrementStock removes stock from a product unless read-only mode is enabled
+func (r *readOnlyProductRepository) DecrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	if err := r.mode.check(); err != nil {
+		return nil, err
+	}
+	return r.ProductRepository.DecrementStock(ctx, id, qty)
+}
+
+// IncrementStock adds stock to a product unless read-only mode is enabled
+func (r *readOnlyProductRepository) IncrementStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	if err := r.mode.check(); err != nil {
+		return nil, err
+	}
+	return r.ProductRepository.IncrementStock(ctx, id, qty)
+}
+
+// ReserveStock reserves part of a product's stock unless read-only mode is enabled
+func (r *readOnlyProductRepository) ReserveStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	if err := r.mode.check(); err != nil {
+		return nil, err
+	}
+	return r.ProductRepository.ReserveStock(ctx, id, qty)
+}
+
+// ReleaseReservedStock returns reserved units to a product's stock unless read-only mode is
+// enabled
+func (r *readOnlyProductRepository) ReleaseReservedStock(ctx context.Context, id uint, qty int) (*entity.Product, error) {
+	if err := r.mode.check(); err != nil {
+		return nil, err
+	}
+	return r.ProductRepository.ReleaseReservedStock(ctx, id, qty)
+}
+
+// ReportBrokenImage counts a broken image report unless read-only mode is enabled
+func (r *readOnlyProductRepository) ReportBrokenImage(ctx context.Context, id uint, threshold int) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.ReportBrokenImage(ctx, id, threshold)
+}
+
+// ClearImageReports resets a product's image reports unless read-only mode is enabled
+func (r *readOnlyProductRepository) ClearImageReports(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.ClearImageReports(ctx, id)
+}
+
+// BulkUpdateStatus updates the active status of products unless read-only mode is enabled
+func (r *readOnlyProductRepository) BulkUpdateStatus(ctx context.Context, ids []uint, isActive bool) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductRepository.BulkUpdateStatus(ctx, ids, isActive)
+}
+
+// readOnlyProductImageRepository rejects product image writes while read-only mode is enabled
+type readOnlyProductImageRepository struct {
+	repository.ProductImageRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyProductImageRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyProductImageRepository(repo repository.ProductImageRepository, mode *ReadOnlyMode) repository.ProductImageRepository {
+	return &readOnlyProductImageRepository{
+		ProductImageRepository: repo,
+		mode:                   mode,
+	}
+}
+
+// Create adds an image unless read-only mode is enabled
+func (r *readOnlyProductImageRepository) Create(ctx context.Context, image *entity.ProductImage) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductImageRepository.Create(ctx, image)
+}
+
+// Delete removes an image unless read-only mode is enabled
+func (r *readOnlyProductImageRepository) Delete(ctx context.Context, productID, imageID uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductImageRepository.Delete(ctx, productID, imageID)
+}
+
+// DeleteByProduct removes a product's images unless read-only mode is enabled
+func (r *readOnlyProductImageRepository) DeleteByProduct(ctx context.Context, productID uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductImageRepository.DeleteByProduct(ctx, productID)
+}
+
+// SetSortOrders reorders a product's images unless read-only mode is enabled
+func (r *readOnlyProductImageRepository) SetSortOrders(ctx context.Context, productID uint, imageIDs []uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductImageRepository.SetSortOrders(ctx, productID, imageIDs)
+}
+
+// SetPrimary marks a product's primary image unless read-only mode is enabled
+func (r *readOnlyProductImageRepository) SetPrimary(ctx context.Context, productID, imageID uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductImageRepository.SetPrimary(ctx, productID, imageID)
+}
+
+// readOnlyProductTagRepository rejects product tag writes while read-only mode is enabled
+type readOnlyProductTagRepository struct {
+	repository.ProductTagRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyProductTagRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyProductTagRepository(repo repository.ProductTagRepository, mode *ReadOnlyMode) repository.ProductTagRepository {
+	return &readOnlyProductTagRepository{
+		ProductTagRepository: repo,
+		mode:                 mode,
+	}
+}
+
+// SetTags replaces a product's tags unless read-only mode is enabled
+func (r *readOnlyProductTagRepository) SetTags(ctx context.Context, productID uint, tags []string) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ProductTagRepository.SetTags(ctx, productID, tags)
+}
+
+// readOnlyCategoryRepository rejects category writes while read-only mode is enabled
+type readOnlyCategoryRepository struct {
+	repository.CategoryRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyCategoryRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyCategoryRepository(repo repository.CategoryRepository, mode *ReadOnlyMode) repository.CategoryRepository {
+	return &readOnlyCategoryRepository{
+		CategoryRepository: repo,
+		mode:               mode,
+	}
+}
+
+// Create creates a category unless read-only mode is enabled
+func (r *readOnlyCategoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.CategoryRepository.Create(ctx, category)
+}
+
+// Update updates a category unless read-only mode is enabled
+func (r *readOnlyCategoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.CategoryRepository.Update(ctx, category)
+}
+
+// Delete deletes a category unless read-only mode is enabled
+func (r *readOnlyCategoryRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.CategoryRepository.Delete(ctx, id)
+}
+
+// readOnlyUserRepository rejects user writes while read-only mode is enabled. Recording the
+// last login is still allowed so users can sign in during maintenance.
+type readOnlyUserRepository struct {
+	repository.UserRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyUserRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyUserRepository(repo repository.UserRepository, mode *ReadOnlyMode) repository.UserRepository {
+	return &readOnlyUserRepository{
+		UserRepository: repo,
+		mode:           mode,
+	}
+}
+
+// Create creates a user unless read-only mode is enabled
+func (r *readOnlyUserRepository) Create(ctx context.Context, user *entity.User) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.UserRepository.Create(ctx, user)
+}
+
+// Update updates a user unless read-only mode is enabled
+func (r *readOnlyUserRepository) Update(ctx context.Context, user *entity.User) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.UserRepository.Update(ctx, user)
+}
+
+// Delete soft-deletes a user unless read-only mode is enabled
+func (r *readOnlyUserRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.UserRepository.Delete(ctx, id)
+}
+
+// HardDelete permanently deletes a user unless read-only mode is enabled
+func (r *readOnlyUserRepository) HardDelete(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.UserRepository.HardDelete(ctx, id)
+}
+
+// RestoreUser clears a user's soft-delete unless read-only mode is enabled
+func (r *readOnlyUserRepository) RestoreUser(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.UserRepository.RestoreUser(ctx, id)
+}
+
+// UpdatePassword changes a user's password unless read-only mode is enabled
+func (r *readOnlyUserRepository) UpdatePassword(ctx context.Context, id uint, hashedPassword string) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.UserRepository.UpdatePassword(ctx, id, hashedPassword)
+}
+
+// UpdateLastLogin records a login unless read-only mode is enabled
+func (r *readOnlyUserRepository) UpdateLastLogin(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.UserRepository.UpdateLastLogin(ctx, id)
+}
+
+// readOnlyWebhookRepository rejects webhook and delivery writes while read-only mode is
+// enabled. Queued deliveries wait until it is turned off again.
+type readOnlyWebhookRepository struct {
+	repository.WebhookRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyWebhookRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyWebhookRepository(repo repository.WebhookRepository, mode *ReadOnlyMode) repository.WebhookRepository {
+	return &readOnlyWebhookRepository{
+		WebhookRepository: repo,
+		mode:              mode,
+	}
+}
+
+// Create creates a webhook unless read-only mode is enabled
+func (r *readOnlyWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.WebhookRepository.Create(ctx, webhook)
+}
+
+// Update updates a webhook unless read-only mode is enabled
+func (r *readOnlyWebhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.WebhookRepository.Update(ctx, webhook)
+}
+
+// Delete deletes a webhook unless read-only mode is enabled
+func (r *readOnlyWebhookRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.WebhookRepository.Delete(ctx, id)
+}
+
+// CreateDeliveries queues webhook deliveries unless read-only mode is enabled
+func (r *readOnlyWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.WebhookRepository.CreateDeliveries(ctx, deliveries)
+}
+
+// ClaimDueDeliveries claims due deliveries for sending unless read-only mode is enabled, as
+// claiming leases them and every attempt is recorded
+func (r *readOnlyWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+	if err := r.mode.check(); err != nil {
+		return nil, err
+	}
+	return r.WebhookRepository.ClaimDueDeliveries(ctx, now, lease, limit)
+}
+
+// UpdateDelivery records a delivery attempt unless read-only mode is enabled
+func (r *readOnlyWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.WebhookRepository.UpdateDelivery(ctx, delivery)
+}
+
+// readOnlyReservationRepository rejects reservation writes while read-only mode is enabled
+type readOnlyReservationRepository struct {
+	repository.ReservationRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyReservationRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyReservationRepository(repo repository.ReservationRepository, mode *ReadOnlyMode) repository.ReservationRepository {
+	return &readOnlyReservationRepository{
+		ReservationRepository: repo,
+		mode:                  mode,
+	}
+}
+
+// Create records a reservation unless read-only mode is enabled
+func (r *readOnlyReservationRepository) Create(ctx context.Context, reservation *entity.Reservation) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ReservationRepository.Create(ctx, reservation)
+}
+
+// LockStale claims stale reservations for release unless read-only mode is enabled, so the
+// releaser doesn't lock rows it isn't allowed to release
+func (r *readOnlyReservationRepository) LockStale(ctx context.Context, now, createdBefore time.Time, limit int) ([]*entity.Reservation, error) {
+	if err := r.mode.check(); err != nil {
+		return nil, err
+	}
+	return r.ReservationRepository.LockStale(ctx, now, createdBefore, limit)
+}
+
+// Delete removes a reservation unless read-only mode is enabled
+func (r *readOnlyReservationRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.ReservationRepository.Delete(ctx, id)
+}
+
+// readOnlyAuditLogRepository rejects audit log writes while read-only mode is enabled
+type readOnlyAuditLogRepository struct {
+	repository.AuditLogRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyAuditLogRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyAuditLogRepository(repo repository.AuditLogRepository, mode *ReadOnlyMode) repository.AuditLogRepository {
+	return &readOnlyAuditLogRepository{
+		AuditLogRepository: repo,
+		mode:               mode,
+	}
+}
+
+// Create records an audit log entry unless read-only mode is enabled
+func (r *readOnlyAuditLogRepository) Create(ctx context.Context, auditLog *entity.AuditLog) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.AuditLogRepository.Create(ctx, auditLog)
+}
+
+// readOnlyPriceHistoryRepository rejects price history writes while read-only mode is enabled
+type readOnlyPriceHistoryRepository struct {
+	repository.PriceHistoryRepository
+	mode *ReadOnlyMode
+}
+
+// NewReadOnlyPriceHistoryRepository wraps repo so its writes are rejected while mode is enabled
+func NewReadOnlyPriceHistoryRepository(repo repository.PriceHistoryRepository, mode *ReadOnlyMode) repository.PriceHistoryRepository {
+	return &readOnlyPriceHistoryRepository{
+		PriceHistoryRepository: repo,
+		mode:                   mode,
+	}
+}
+
+// Create records a price change unless read-only mode is enabled
+func (r *readOnlyPriceHistoryRepository) Create(ctx context.Context, entry *entity.PriceHistory) error {
+	if err := r.mode.check(); err != nil {
+		return err
+	}
+	return r.PriceHistoryRepository.Create(ctx, entry)
+}
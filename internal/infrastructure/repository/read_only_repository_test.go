@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// TestReadOnlyRepositoriesRejectEveryWrite wraps no repository at all, so a write that got
+// past its decorator would panic rather than return entity.ErrReadOnlyMode
+func TestReadOnlyRepositoriesRejectEveryWrite(t *testing.T) {
+	ctx := context.Background()
+	mode := NewReadOnlyMode(true)
+	products := NewReadOnlyProductRepository(nil, mode)
+	images := NewReadOnlyProductImageRepository(nil, mode)
+	tags := NewReadOnlyProductTagRepository(nil, mode)
+	categories := NewReadOnlyCategoryRepository(nil, mode)
+	users := NewReadOnlyUserRepository(nil, mode)
+	webhooks := NewReadOnlyWebhookRepository(nil, mode)
+	reservations := NewReadOnlyReservationRepository(nil, mode)
+	audit := NewReadOnlyAuditLogRepository(nil, mode)
+	prices := NewReadOnlyPriceHistoryRepository(nil, mode)
+
+	writes := map[string]func() error{
+		"Product.Create":               func() error { return products.Create(ctx, &entity.Product{}) },
+		"Product.Update":               func() error { return products.Update(ctx, &entity.Product{}) },
+		"Product.Delete":               func() error { return products.Delete(ctx, 1) },
+		"Product.HardDelete":           func() error { return products.HardDelete(ctx, 1) },
+		"Product.NextSKUSequence":      func() error { _, err := products.NextSKUSequence(ctx); return err },
+		"Product.UpdateStock":          func() error { return products.UpdateStock(ctx, 1, 1) },
+		"Product.DecrementStock":       func() error { _, err := products.DecrementStock(ctx, 1, 1); return err },
+		"Product.IncrementStock":       func() error { _, err := products.IncrementStock(ctx, 1, 1); return err },
+		"Product.ReserveStock":         func() error { _, err := products.ReserveStock(ctx, 1, 1); return err },
+		"Product.ReleaseReservedStock": func() error { _, err := products.ReleaseReservedStock(ctx, 1, 1); return err },
+		"Product.ReportBrokenImage":    func() error { return products.ReportBrokenImage(ctx, 1, 3) },
+		"Product.ClearImageReports":    func() error { return products.ClearImageReports(ctx, 1) },
+		"Product.BulkUpdateStatus":     func() error { return products.BulkUpdateStatus(ctx, []uint{1}, false) },
+		"Product.RefreshSearchVectors": func() error { _, _, err := products.RefreshSearchVectors(ctx, 0, 10); return err },
+		"Product.SetSearchIndexVersion": func() error {
+			return products.SetSearchIndexVersion(ctx, 1)
+		},
+		"Image.Create":          func() error { return images.Create(ctx, &entity.ProductImage{}) },
+		"Image.Delete":          func() error { return images.Delete(ctx, 1, 1) },
+		"Image.DeleteByProduct": func() error { return images.DeleteByProduct(ctx, 1) },
+		"Image.SetSortOrders":   func() error { return images.SetSortOrders(ctx, 1, []uint{1}) },
+		"Image.SetPrimary":      func() error { return images.SetPrimary(ctx, 1, 1) },
+		"Tag.SetTags":           func() error { return tags.SetTags(ctx, 1, nil) },
+		"Category.Create":       func() error { return categories.Create(ctx, &entity.Category{}) },
+		"Category.Update":       func() error { return categories.Update(ctx, &entity.Category{}) },
+		"Category.Delete":       func() error { return categories.Delete(ctx, 1) },
+		"User.Create":           func() error { return users.Create(ctx, &entity.User{}) },
+		"User.Update":           func() error { return users.Update(ctx, &entity.User{}) },
+		"User.Delete":           func() error { return users.Delete(ctx, 1) },
+		"User.HardDelete":       func() error { return users.HardDelete(ctx, 1) },
+		"User.RestoreUser":      func() error { return users.RestoreUser(ctx, 1) },
+		"User.UpdatePassword":   func() error { return users.UpdatePassword(ctx, 1, "hash") },
+		"User.UpdateLastLogin":  func() error { return users.UpdateLastLogin(ctx, 1) },
+		"Webhook.Create":        func() error { return webhooks.Create(ctx, &entity.Webhook{}) },
+		"Webhook.Update":        func() error { return webhooks.Update(ctx, &entity.Webhook{}) },
+		"Webhook.Delete":        func() error { return webhooks.Delete(ctx, 1) },
+		"Webhook.CreateDeliveries": func() error {
+			return webhooks.CreateDeliveries(ctx, []*entity.WebhookDelivery{{}})
+		},
+		"Webhook.ClaimDueDeliveries": func() error {
+			_, err := webhooks.ClaimDueDeliveries(ctx, time.Now(), time.Minute, 10)
+			return err
+		},
+		"Webhook.UpdateDelivery": func() error { return webhooks.UpdateDelivery(ctx, &entity.WebhookDelivery{}) },
+		"Reservation.Create":     func() error { return reservations.Create(ctx, &entity.Reservation{}) },
+		"Reservation.LockStale": func() error {
+			_, err := reservations.LockStale(ctx, time.Now(), time.Time{}, 10)
+			return err
+		},
+		"Reservation.Delete": func() error { return reservations.Delete(ctx, 1) },
+		"AuditLog.Create":    func() error { return audit.Create(ctx, &entity.AuditLog{}) },
+		"PriceHistory.Create": func() error {
+			return prices.Create(ctx, &entity.PriceHistory{})
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			if err := write(); !errors.Is(err, entity.ErrReadOnlyMode) {
+				t.Errorf("got %v, want %v", err, entity.ErrReadOnlyMode)
+			}
+		})
+	}
+}
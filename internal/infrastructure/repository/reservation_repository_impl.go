@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reservationRepositoryImpl implements the ReservationRepository interface
+type reservationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReservationRepository creates a new reservation repository
+func NewReservationRepository(db *gorm.DB) repository.ReservationRepository {
+	return &reservationRepositoryImpl{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *reservationRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
+// Create records a reservation
+func (r *reservationRepositoryImpl) Create(ctx context.Context, reservation *entity.Reservation) error {
+	if err := r.conn(ctx).Create(reservation).Error; err != nil {
+		return fmt.Errorf("failed to create reservation: %w", err)
+	}
+	return nil
+}
+
+// ListActiveByProduct retrieves the reservations of a product that expire after now
+func (r *reservationRepositoryImpl) ListActiveByProduct(ctx context.Context, productID uint, now time.Time) ([]*entity.Reservation, error) {
+	var reservations []*entity.Reservation
+	err := r.conn(ctx).
+		Where("product_id = ? AND expires_at > ?", productID, now).
+		Order("expires_at ASC, id ASC").
+		Find(&reservations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// LockStale retrieves and locks up to limit expired or too old reservations, skipping locked ones
+func (r *reservationRepositoryImpl) LockStale(ctx context.Context, now, createdBefore time.Time, limit int) ([]*entity.Reservation, error) {
+	var reservations []*entity.Reservation
+	err := r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("expires_at <= ? OR created_at <= ?", now, createdBefore).
+		Order("expires_at ASC, id ASC").
+		Limit(limit).
+		Find(&reservations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock stale reservations: %w", err)
+	}
+	return reservations, nil
+}
+
+// Delete removes a reservation
+func (r *reservationRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	if err := r.conn(ctx).Delete(&entity.Reservation{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete reservation: %w", err)
+	}
+	return nil
+}
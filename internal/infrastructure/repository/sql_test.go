@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunDB returns a Postgres gorm.DB that never connects, for asserting the SQL that
+// repository methods build with ToSQL
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=unused sslmode=disable"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("open dry-run database: %v", err)
+	}
+	return db
+}
+
+// recordQueries records the SQL of every query db runs, for methods that execute their query
+// rather than returning it
+func recordQueries(t *testing.T, db *gorm.DB) *[]string {
+	t.Helper()
+	var queries []string
+	err := db.Callback().Query().After("gorm:query").Register("test:record_queries", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	return &queries
+}
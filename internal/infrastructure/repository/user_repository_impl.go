@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/product-management/internal/domain/entity"
 	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
 	"gorm.io/gorm"
 )
 
@@ -22,18 +24,40 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 	}
 }
 
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *userRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
 // Create creates a new user
 func (r *userRepositoryImpl) Create(ctx context.Context, user *entity.User) error {
-	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+	if err := r.conn(ctx).Create(user).Error; err != nil {
+		// The database enforces unique emails and usernames, ignoring case when configured to
+		if database.IsUniqueViolation(err) {
+			return userConflictError(err)
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 	return nil
 }
 
+// userConflictError maps a unique violation on users to the field that collided, falling back
+// to entity.ErrUserAlreadyExists for indexes it doesn't know
+func userConflictError(err error) error {
+	switch {
+	case database.IsUniqueViolationOf(err, database.UserEmailIndex), database.IsUniqueViolationOf(err, database.UserEmailLowerIndex):
+		return entity.ErrEmailAlreadyExists
+	case database.IsUniqueViolationOf(err, database.UserUsernameIndex), database.IsUniqueViolationOf(err, database.UserUsernameLowerIndex):
+		return entity.ErrUsernameAlreadyExists
+	default:
+		return entity.ErrUserAlreadyExists
+	}
+}
+
 // GetByID retrieves a user by their ID
 func (r *userRepositoryImpl) GetByID(ctx context.Context, id uint) (*entity.User, error) {
 	var user entity.User
-	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+	if err := r.conn(ctx).First(&user, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entity.ErrUserNotFound
 		}
@@ -42,10 +66,10 @@ func (r *userRepositoryImpl) GetByID(ctx context.Context, id uint) (*entity.User
 	return &user, nil
 }
 
-// GetByEmail retrieves a user by their email
+// GetByEmail retrieves a user by their email, ignoring case and surrounding whitespace
 func (r *userRepositoryImpl) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.conn(ctx).Where("LOWER(email) = LOWER(?)", strings.TrimSpace(email)).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entity.ErrUserNotFound
 		}
@@ -54,10 +78,10 @@ func (r *userRepositoryImpl) GetByEmail(ctx context.Context, email string) (*ent
 	return &user, nil
 }
 
-// GetByUsername retrieves a user by their username
+// GetByUsername retrieves a user by their username, ignoring case and surrounding whitespace
 func (r *userRepositoryImpl) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
 	var user entity.User
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+	if err := r.conn(ctx).Where("LOWER(username) = LOWER(?)", strings.TrimSpace(username)).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entity.ErrUserNotFound
 		}
@@ -66,10 +90,34 @@ func (r *userRepositoryImpl) GetByUsername(ctx context.Context, username string)
 	return &user, nil
 }
 
+// GetByVerificationTokenHash retrieves the user holding the given email verification token hash
+func (r *userRepositoryImpl) GetByVerificationTokenHash(ctx context.Context, tokenHash string) (*entity.User, error) {
+	var user entity.User
+	if err := r.conn(ctx).Where("verification_token_hash = ?", tokenHash).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by verification token: %w", err)
+	}
+	return &user, nil
+}
+
+// GetByResetTokenHash retrieves the user holding the given password reset token hash
+func (r *userRepositoryImpl) GetByResetTokenHash(ctx context.Context, tokenHash string) (*entity.User, error) {
+	var user entity.User
+	if err := r.conn(ctx).Where("reset_token_hash = ?", tokenHash).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by password reset token: %w", err)
+	}
+	return &user, nil
+}
+
 // GetAll retrieves all users with optional filtering and pagination
 func (r *userRepositoryImpl) GetAll(ctx context.Context, filter *repository.UserFilter, offset, limit int) ([]*entity.User, error) {
 	var users []*entity.User
-	query := r.db.WithContext(ctx)
+	query := r.conn(ctx)
 
 	if filter != nil {
 		query = r.applyFilter(query, filter)
@@ -85,7 +133,7 @@ func (r *userRepositoryImpl) GetAll(ctx context.Context, filter *repository.User
 // GetTotalCount returns the total count of users with optional filtering
 func (r *userRepositoryImpl) GetTotalCount(ctx context.Context, filter *repository.UserFilter) (int64, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&entity.User{})
+	query := r.conn(ctx).Model(&entity.User{})
 
 	if filter != nil {
 		query = r.applyFilter(query, filter)
@@ -100,7 +148,10 @@ func (r *userRepositoryImpl) GetTotalCount(ctx context.Context, filter *reposito
 
 // Update updates an existing user
 func (r *userRepositoryImpl) Update(ctx context.Context, user *entity.User) error {
-	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+	if err := r.conn(ctx).Save(user).Error; err != nil {
+		if database.IsUniqueViolation(err) {
+			return userConflictError(err)
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 	return nil
@@ -108,7 +159,7 @@ func (r *userRepositoryImpl) Update(ctx context.Context, user *entity.User) erro
 
 // Delete soft-deletes a user by their ID
 func (r *userRepositoryImpl) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&entity.User{}, id).Error; err != nil {
+	if err := r.conn(ctx).Delete(&entity.User{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 	return nil
@@ -116,25 +167,77 @@ func (r *userRepositoryImpl) Delete(ctx context.Context, id uint) error {
 
 // HardDelete permanently deletes a user by their ID
 func (r *userRepositoryImpl) HardDelete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Unscoped().Delete(&entity.User{}, id).Error; err != nil {
+	if err := r.conn(ctx).Unscoped().Delete(&entity.User{}, id).Error; err != nil {
 		return fmt.Errorf("failed to hard delete user: %w", err)
 	}
 	return nil
 }
 
-// ExistsByEmail checks if a user with the given email exists
+// GetDeletedByID retrieves a soft-deleted user by their ID
+func (r *userRepositoryImpl) GetDeletedByID(ctx context.Context, id uint) (*entity.User, error) {
+	var user entity.User
+	if err := r.conn(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&user, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted user by ID: %w", err)
+	}
+	return &user, nil
+}
+
+// GetDeleted retrieves soft-deleted users, most recently deleted first
+func (r *userRepositoryImpl) GetDeleted(ctx context.Context, offset, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	err := r.conn(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted users: %w", err)
+	}
+	return users, nil
+}
+
+// CountDeleted returns the number of soft-deleted users
+func (r *userRepositoryImpl) CountDeleted(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.conn(ctx).Unscoped().Model(&entity.User{}).Where("deleted_at IS NOT NULL").Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count deleted users: %w", err)
+	}
+	return count, nil
+}
+
+// RestoreUser clears the soft-delete of a user
+func (r *userRepositoryImpl) RestoreUser(ctx context.Context, id uint) error {
+	result := r.conn(ctx).Unscoped().Model(&entity.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		if database.IsUniqueViolation(result.Error) {
+			return entity.ErrUserAlreadyExists
+		}
+		return fmt.Errorf("failed to restore user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrUserNotFound
+	}
+	return nil
+}
+
+// ExistsByEmail checks if a user with the given email exists, ignoring case and surrounding whitespace
 func (r *userRepositoryImpl) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&entity.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := r.conn(ctx).Model(&entity.User{}).Where("LOWER(email) = LOWER(?)", strings.TrimSpace(email)).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check user existence by email: %w", err)
 	}
 	return count > 0, nil
 }
 
-// ExistsByUsername checks if a user with the given username exists
+// ExistsByUsername checks if a user with the given username exists, ignoring case and surrounding whitespace
 func (r *userRepositoryImpl) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&entity.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+	if err := r.conn(ctx).Model(&entity.User{}).Where("LOWER(username) = LOWER(?)", strings.TrimSpace(username)).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check user existence by username: %w", err)
 	}
 	return count > 0, nil
@@ -143,7 +246,7 @@ func (r *userRepositoryImpl) ExistsByUsername(ctx context.Context, username stri
 // UpdateLastLogin updates the last login time for a user
 func (r *userRepositoryImpl) UpdateLastLogin(ctx context.Context, id uint) error {
 	now := time.Now()
-	if err := r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Update("last_login_at", &now).Error; err != nil {
+	if err := r.conn(ctx).Model(&entity.User{}).Where("id = ?", id).Update("last_login_at", &now).Error; err != nil {
 		return fmt.Errorf("failed to update last login: %w", err)
 	}
 	return nil
@@ -151,7 +254,7 @@ func (r *userRepositoryImpl) UpdateLastLogin(ctx context.Context, id uint) error
 
 // UpdatePassword updates the password for a user
 func (r *userRepositoryImpl) UpdatePassword(ctx context.Context, id uint, hashedPassword string) error {
-	if err := r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Update("password", hashedPassword).Error; err != nil {
+	if err := r.conn(ctx).Model(&entity.User{}).Where("id = ?", id).Update("password", hashedPassword).Error; err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 	return nil
@@ -160,7 +263,7 @@ func (r *userRepositoryImpl) UpdatePassword(ctx context.Context, id uint, hashed
 // GetAdminUsers retrieves all admin users
 func (r *userRepositoryImpl) GetAdminUsers(ctx context.Context) ([]*entity.User, error) {
 	var users []*entity.User
-	if err := r.db.WithContext(ctx).Where("is_admin = ? AND is_active = ?", true, true).Find(&users).Error; err != nil {
+	if err := r.conn(ctx).Where("is_admin = ? AND is_active = ?", true, true).Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to get admin users: %w", err)
 	}
 	return users, nil
@@ -0,0 +1,32 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestLowerEmailIndexRejectsEmailsDifferingOnlyByCase(t *testing.T) {
+	db := newIntegrationDB(t)
+	if err := db.GetDB().Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email))").Error; err != nil {
+		t.Fatalf("create index: %v", err)
+	}
+	repo := NewUserRepository(db.GetDB())
+	ctx := context.Background()
+
+	// The emails are stored as given, bypassing the normalization registration applies
+	first := &entity.User{Email: "Case.Test@Example.com", Username: "case-test-first", Password: "unused"}
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() { db.GetDB().Unscoped().Delete(&entity.User{}, first.ID) })
+
+	second := &entity.User{Email: "case.test@example.com", Username: "case-test-second", Password: "unused"}
+	if err := repo.Create(ctx, second); !errors.Is(err, entity.ErrEmailAlreadyExists) {
+		t.Errorf("got %v, want %v", err, entity.ErrEmailAlreadyExists)
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// failInserts makes every insert through db fail with a Postgres unique violation on index,
+// the error the driver returns when a unique index rejects a row
+func failInserts(t *testing.T, db *gorm.DB, index string) {
+	t.Helper()
+	err := db.Callback().Create().Replace("gorm:create", func(tx *gorm.DB) {
+		tx.AddError(&pgconn.PgError{Code: "23505", ConstraintName: index})
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+}
+
+func TestCreateUserMapsUniqueViolations(t *testing.T) {
+	tests := []struct {
+		index string
+		want  error
+	}{
+		{database.UserEmailLowerIndex, entity.ErrEmailAlreadyExists},
+		{database.UserUsernameLowerIndex, entity.ErrUsernameAlreadyExists},
+		{"idx_users_something_else", entity.ErrUserAlreadyExists},
+	}
+	for _, tt := range tests {
+		db := newDryRunDB(t)
+		failInserts(t, db, tt.index)
+		repo := NewUserRepository(db.Session(&gorm.Session{SkipDefaultTransaction: true}))
+
+		err := repo.Create(context.Background(), &entity.User{Email: "a@x.com", Username: "alice"})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.index, err, tt.want)
+		}
+	}
+}
+
+func TestUserConflictErrorMapsIndexesToFields(t *testing.T) {
+	tests := []struct {
+		index string
+		want  error
+	}{
+		{database.UserEmailIndex, entity.ErrEmailAlreadyExists},
+		{database.UserEmailLowerIndex, entity.ErrEmailAlreadyExists},
+		{database.UserUsernameIndex, entity.ErrUsernameAlreadyExists},
+		{database.UserUsernameLowerIndex, entity.ErrUsernameAlreadyExists},
+		{"idx_users_something_else", entity.ErrUserAlreadyExists},
+	}
+	for _, tt := range tests {
+		err := userConflictError(&pgconn.PgError{Code: "23505", ConstraintName: tt.index})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.index, err, tt.want)
+		}
+	}
+}
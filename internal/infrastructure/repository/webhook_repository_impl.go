@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookRepositoryImpl implements the WebhookRepository interface
+type webhookRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookRepositoryImpl{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, falling back to the repository's connection
+func (r *webhookRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return database.DBFromContext(ctx, r.db)
+}
+
+// Create creates a new webhook
+func (r *webhookRepositoryImpl) Create(ctx context.Context, webhook *entity.Webhook) error {
+	if err := r.conn(ctx).Create(webhook).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook by its ID
+func (r *webhookRepositoryImpl) GetByID(ctx context.Context, id uint) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	if err := r.conn(ctx).First(&webhook, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entity.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook by ID: %w", err)
+	}
+	return &webhook, nil
+}
+
+// GetAll retrieves every webhook ordered by ID
+func (r *webhookRepositoryImpl) GetAll(ctx context.Context) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	if err := r.conn(ctx).Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// GetActive retrieves the webhooks that are currently receiving events
+func (r *webhookRepositoryImpl) GetActive(ctx context.Context) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	if err := r.conn(ctx).Where("is_active = ?", true).Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// Update updates an existing webhook
+func (r *webhookRepositoryImpl) Update(ctx context.Context, webhook *entity.Webhook) error {
+	if err := r.conn(ctx).Omit(clause.Associations).Save(webhook).Error; err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+// Delete permanently deletes a webhook by its ID; its deliveries go with it through their
+// ON DELETE CASCADE
+func (r *webhookRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	result := r.conn(ctx).Delete(&entity.Webhook{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// CreateDeliveries queues deliveries to be sent
+func (r *webhookRepositoryImpl) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.conn(ctx).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries locks up to limit due pending deliveries, skipping ones another worker
+// holds, and moves their next attempt lease into the future before releasing the lock
+func (r *webhookRepositoryImpl) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			delivery.NextAttemptAt = now.Add(lease)
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&entity.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (r *webhookRepositoryImpl) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := r.conn(ctx).Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// GetDeliveries retrieves a webhook's latest limit deliveries, newest first
+func (r *webhookRepositoryImpl) GetDeliveries(ctx context.Context, webhookID uint, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	err := r.conn(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,71 @@
+// Package webhook sends webhook deliveries over HTTP
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// Request headers sent with every delivery
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body keyed with the
+	// webhook's secret, so receivers can check the request came from us
+	SignatureHeader = "X-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery" // the same on every retry, so receivers can drop duplicates
+)
+
+// maxResponseBody is how much of a response is read before the connection is released
+const maxResponseBody = 64 << 10
+
+// Sign returns the X-Signature value for body signed with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// httpSender POSTs deliveries with a shared HTTP client
+type httpSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a webhook sender giving each attempt up to timeout to complete
+func NewHTTPSender(timeout time.Duration) service.WebhookSender {
+	return &httpSender{client: &http.Client{Timeout: timeout}}
+}
+
+// Send POSTs delivery's payload to webhook's URL and returns the response status code
+func (s *httpSender) Send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "product-management-webhooks")
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, nil
+}
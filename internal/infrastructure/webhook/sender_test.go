@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestSendSignsTheDelivery(t *testing.T) {
+	const secret = "0123456789abcdef"
+	var got *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	webhook := &entity.Webhook{URL: server.URL, Secret: secret}
+	delivery := &entity.WebhookDelivery{ID: 42, EventType: "product.created", Payload: `{"event":"product.created","product_id":7}`}
+	status, err := NewHTTPSender(time.Second).Send(context.Background(), webhook, delivery)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if status != http.StatusAccepted {
+		t.Errorf("got status %d, want %d", status, http.StatusAccepted)
+	}
+	if string(body) != delivery.Payload {
+		t.Errorf("got body %q, want the payload", body)
+	}
+	if signature := got.Header.Get(SignatureHeader); signature != Sign(secret, []byte(delivery.Payload)) {
+		t.Errorf("got signature %q, want the payload's HMAC keyed with the secret", signature)
+	}
+	if event, id := got.Header.Get(EventHeader), got.Header.Get(DeliveryHeader); event != "product.created" || id != "42" {
+		t.Errorf("got event %q and delivery %q, want product.created and 42", event, id)
+	}
+}
+
+func TestSignDependsOnTheSecret(t *testing.T) {
+	body := []byte(`{"event":"product.deleted"}`)
+	if Sign("0123456789abcdef", body) == Sign("fedcba9876543210", body) {
+		t.Error("got the same signature for different secrets")
+	}
+	if Sign("0123456789abcdef", body) != Sign("0123456789abcdef", body) {
+		t.Error("got different signatures for the same secret and body")
+	}
+}
+
+func TestSendReportsUnreachableEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	webhook := &entity.Webhook{URL: server.URL, Secret: "0123456789abcdef"}
+	if _, err := NewHTTPSender(time.Second).Send(context.Background(), webhook, &entity.WebhookDelivery{Payload: "{}"}); err == nil {
+		t.Error("got no error for a closed endpoint")
+	}
+}
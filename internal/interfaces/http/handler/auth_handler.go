@@ -1,13 +1,14 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
 	"github.com/product-management/internal/domain/service"
-	"github.com/product-management/internal/usecase"
 )
 
 // AuthHandler handles HTTP requests for authentication
@@ -37,11 +38,10 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req service.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Details: err.Error(),
-		})
+		if respondIfBodyTooLarge(c, err) {
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
 		return
 	}
 
@@ -69,11 +69,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req service.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Details: err.Error(),
-		})
+		if respondIfBodyTooLarge(c, err) {
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
 		return
 	}
 
@@ -98,16 +97,13 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Security BearerAuth
 // @Router /api/v1/auth/profile [get]
 func (h *AuthHandler) GetProfile(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User ID not found in context",
-		})
+	userID, ok := GetUserID(c)
+	if !ok {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "User ID not found in context")
 		return
 	}
 
-	user, err := h.authService.GetUserProfile(c.Request.Context(), userID.(uint))
+	user, err := h.authService.GetUserProfile(c.Request.Context(), userID)
 	if err != nil {
 		handleAuthError(c, err)
 		return
@@ -116,6 +112,40 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// GetTokenInfo godoc
+// @Summary Get token details
+// @Description Get the claims and expiry of the access token used for the request, read from the already validated claims. The token itself is not returned.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} TokenInfoResponse
+// @Failure 401 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/auth/token/info [get]
+func (h *AuthHandler) GetTokenInfo(c *gin.Context) {
+	claims, ok := GetClaims(c)
+	if !ok {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Token claims not found in context")
+		return
+	}
+
+	info := TokenInfoResponse{
+		UserID:    claims.UserID,
+		Username:  claims.Username,
+		Role:      claims.Role,
+		IsAdmin:   claims.IsAdmin,
+		TokenID:   claims.TokenID,
+		ExpiresAt: claims.ExpiresAt,
+	}
+	if !claims.IssuedAt.IsZero() {
+		info.IssuedAt = &claims.IssuedAt
+	}
+	if remaining := time.Until(claims.ExpiresAt); remaining > 0 {
+		info.RemainingSeconds = int64(remaining.Seconds())
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
 // UpdateProfile godoc
 // @Summary Update user profile
 // @Description Update the profile information of the authenticated user
@@ -127,26 +157,26 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Security BearerAuth
 // @Router /api/v1/auth/profile [put]
 func (h *AuthHandler) UpdateProfile(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User ID not found in context",
-		})
+	userID, ok := GetUserID(c)
+	if !ok {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "User ID not found in context")
 		return
 	}
 
+	// Unknown keys are rejected rather than ignored, so typos don't look like successful updates
 	var req ProfileUpdateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Details: err.Error(),
-		})
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		if respondIfBodyTooLarge(c, err) {
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
 		return
 	}
 
@@ -162,7 +192,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		updates["username"] = *req.Username
 	}
 
-	user, err := h.authService.UpdateProfile(c.Request.Context(), userID.(uint), updates)
+	user, err := h.authService.UpdateProfile(c.Request.Context(), userID, updates)
 	if err != nil {
 		handleAuthError(c, err)
 		return
@@ -185,26 +215,22 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 // @Security BearerAuth
 // @Router /api/v1/auth/change-password [post]
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User ID not found in context",
-		})
+	userID, ok := GetUserID(c)
+	if !ok {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "User ID not found in context")
 		return
 	}
 
 	var req service.PasswordChangeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Details: err.Error(),
-		})
+		if respondIfBodyTooLarge(c, err) {
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
 		return
 	}
 
-	err := h.authService.ChangePassword(c.Request.Context(), userID.(uint), &req)
+	err := h.authService.ChangePassword(c.Request.Context(), userID, &req)
 	if err != nil {
 		handleAuthError(c, err)
 		return
@@ -230,11 +256,10 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Details: err.Error(),
-		})
+		if respondIfBodyTooLarge(c, err) {
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body: "+err.Error())
 		return
 	}
 
@@ -261,10 +286,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	// Extract token from header
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" || len(authHeader) < 8 {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "Invalid authorization header",
-		})
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid authorization header")
 		return
 	}
 
@@ -296,17 +318,12 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // @Security BearerAuth
 // @Router /api/v1/auth/users/{id} [get]
 func (h *AuthHandler) GetUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid user ID",
-		})
+	id, ok := parseIDParam(c, "id", "user")
+	if !ok {
 		return
 	}
 
-	user, err := h.authService.GetUserByID(c.Request.Context(), uint(id))
+	user, err := h.authService.GetUserByID(c.Request.Context(), id)
 	if err != nil {
 		handleAuthError(c, err)
 		return
@@ -317,99 +334,306 @@ func (h *AuthHandler) GetUser(c *gin.Context) {
 
 // handleAuthError handles different types of authentication errors
 func handleAuthError(c *gin.Context, err error) {
+	if respondIfReadOnly(c, err) {
+		return
+	}
+	if validationErr, ok := asValidationError(err); ok {
+		respondValidationError(c, validationErr)
+		return
+	}
+
 	switch err {
 	case entity.ErrUserNotFound:
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "Not Found",
-			Message: err.Error(),
-		})
-	case entity.ErrUserAlreadyExists:
-		c.JSON(http.StatusConflict, ErrorResponse{
-			Error:   "Conflict",
-			Message: err.Error(),
-		})
-	case entity.ErrInvalidCredentials:
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
-	case entity.ErrUserInactive:
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondDomainError(c, http.StatusNotFound, err)
+	case entity.ErrEmailAlreadyExists, entity.ErrUsernameAlreadyExists, entity.ErrUserAlreadyExists,
+		entity.ErrUserEmailConflict, entity.ErrUserUsernameConflict:
+		respondDomainError(c, http.StatusConflict, err)
+	case entity.ErrInvalidCredentials, entity.ErrUserInactive:
+		respondDomainError(c, http.StatusUnauthorized, err)
+	case entity.ErrEmailNotVerified, entity.ErrCannotModifySelf:
+		respondDomainError(c, http.StatusForbidden, err)
+	case entity.ErrTooManyPasswordResets:
+		respondDomainError(c, http.StatusTooManyRequests, err)
+	case entity.ErrBadVerificationToken, entity.ErrBadPasswordResetToken:
+		respondDomainError(c, http.StatusBadRequest, err)
 	case entity.ErrUnauthorized, entity.ErrInvalidToken:
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondDomainError(c, http.StatusUnauthorized, err)
 	case entity.ErrUserEmailRequired, entity.ErrUserUsernameRequired, entity.ErrUserUsernameTooShort,
 		 entity.ErrUserUsernameTooLong, entity.ErrInvalidInput, entity.ErrValidationFailed:
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "Bad Request",
-			Message: err.Error(),
-		})
+		respondDomainError(c, http.StatusBadRequest, err)
 	default:
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "An unexpected error occurred",
-			Details: err.Error(),
-		})
+		respondInternalError(c, err)
 	}
 }
 
-// Login handles user login
-func Login(authService *usecase.AuthUseCase) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var req usecase.LoginRequest
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
+// VerifyEmail handles activating an account from the token in its verification link
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	user, err := h.authService.VerifyEmail(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		handleAuthError(c, err)
+		return
+	}
 
-		response, err := authService.Login(&req)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-			return
-		}
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Email verified",
+		Data:    user,
+	})
+}
+
+// ResendVerification handles sending a fresh verification link. The response is the same
+// whether or not the address belongs to an unverified account.
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusOK, response)
+	if err := h.authService.ResendVerification(c.Request.Context(), req.Email); err != nil {
+		handleAuthError(c, err)
+		return
 	}
+
+	c.JSON(http.StatusAccepted, SuccessResponse{
+		Message: "If the account exists and is unverified, a verification email has been sent",
+	})
 }
 
-// Register handles user registration
-func Register(authService *usecase.AuthUseCase) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var req usecase.RegisterRequest
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
+// ForgotPassword handles emailing a password reset link. The response is the same whether or
+// not the address belongs to an account; only requests past the throttle get 429.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, err)
+		return
+	}
 
-		user, err := authService.Register(&req)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
+	if err := h.authService.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "If an account exists for this email, a password reset link has been sent",
+	})
+}
+
+// ResetPassword handles setting a new password with the token from a reset link
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, err)
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Password has been reset",
+	})
+}
+
+// ListUsers handles listing users with filtering and pagination (admin only)
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+	if err != nil {
+		respondInvalidPagination(c, err)
+		return
+	}
+
+	filter, ok := parseUserFilter(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.authService.ListUsers(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	setPaginationHeaders(c, response.PageInfo)
+	c.JSON(http.StatusOK, response)
+}
+
+// CountUsers handles counting the users that match the list filters, without fetching them (admin only)
+func (h *AuthHandler) CountUsers(c *gin.Context) {
+	filter, ok := parseUserFilter(c)
+	if !ok {
+		return
+	}
+
+	total, err := h.authService.CountUsers(c.Request.Context(), filter)
+	if err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CountResponse{Total: total})
+}
+
+// parseUserFilter builds the user list filter from the query string, writing a 400 response
+// and returning false if a value is invalid
+func parseUserFilter(c *gin.Context) (*repository.UserFilter, bool) {
+	isActive, err := parseOptionalBool(c, "is_active")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid is_active value")
+		return nil, false
+	}
+
+	isAdmin, err := parseOptionalBool(c, "is_admin")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid is_admin value")
+		return nil, false
+	}
+
+	return &repository.UserFilter{
+		IsActive:   isActive,
+		IsAdmin:    isAdmin,
+		SearchTerm: c.Query("search"),
+	}, true
+}
+
+// ListDeletedUsers handles listing soft-deleted users (admin only)
+func (h *AuthHandler) ListDeletedUsers(c *gin.Context) {
+	page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+	if err != nil {
+		respondInvalidPagination(c, err)
+		return
+	}
+
+	response, err := h.authService.ListDeletedUsers(c.Request.Context(), page, pageSize)
+	if err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	setPaginationHeaders(c, response.PageInfo)
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreUser handles undoing the soft-delete of a user (admin only)
+func (h *AuthHandler) RestoreUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
 
-		c.JSON(http.StatusCreated, user)
+	user, err := h.authService.RestoreUser(requestContext(c), id)
+	if err != nil {
+		handleAuthError(c, err)
+		return
 	}
+
+	c.JSON(http.StatusOK, user)
 }
 
-// GetUserProfile handles getting user profile
-func GetUserProfile(authService *usecase.AuthUseCase) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// This would typically get the user from the JWT token context
-		// For now, we'll return a placeholder response
-		c.JSON(http.StatusOK, gin.H{"message": "User profile endpoint"})
+// DeleteUser handles soft-deleting a user, who can later be restored (admin only)
+func (h *AuthHandler) DeleteUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DeleteUser(requestContext(c), id); err != nil {
+		handleAuthError(c, err)
+		return
 	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "User deleted successfully",
+	})
 }
 
-// UpdateUserProfile handles updating user profile
-func UpdateUserProfile(authService *usecase.AuthUseCase) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// This would typically update the user profile
-		// For now, we'll return a placeholder response
-		c.JSON(http.StatusOK, gin.H{"message": "User profile updated"})
+// HardDeleteUser handles permanently deleting a user, live or soft-deleted (admin only)
+func (h *AuthHandler) HardDeleteUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.authService.HardDeleteUser(requestContext(c), id); err != nil {
+		handleAuthError(c, err)
+		return
 	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "User permanently deleted",
+	})
+}
+
+// DeactivateUser handles stopping a user from logging in without deleting them (admin only)
+func (h *AuthHandler) DeactivateUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	user, err := h.authService.DeactivateUser(requestContext(c), id)
+	if err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ReactivateUser handles letting a deactivated user log in again (admin only)
+func (h *AuthHandler) ReactivateUser(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	user, err := h.authService.ReactivateUser(requestContext(c), id)
+	if err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// SetUserPassword handles setting a user's password, e.g. after a lockout. Admin accounts must
+// satisfy the stricter admin password policy (admin only).
+func (h *AuthHandler) SetUserPassword(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	var req SetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, err)
+		return
+	}
+
+	if err := h.authService.SetUserPassword(requestContext(c), id, req.Password); err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Password updated successfully",
+	})
+}
+
+// BulkDeactivateUsers handles deactivating several users at once, e.g. when offboarding (admin only)
+func (h *AuthHandler) BulkDeactivateUsers(c *gin.Context) {
+	var req BulkDeactivateUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleBindError(c, err)
+		return
+	}
+
+	result, err := h.authService.BulkDeactivateUsers(requestContext(c), req.UserIDs)
+	if err != nil {
+		handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/usecase"
+)
+
+func init() {
+	// Report binding failures by JSON field name rather than Go struct field name
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+	}
+}
+
+// asValidationError extracts field-level validation detail from a binding or domain error
+func asValidationError(err error) (*entity.ValidationError, bool) {
+	var validationErr *entity.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return usecase.TranslateValidationErrors(validationErrs), true
+	}
+
+	return nil, false
+}
+
+// respondValidationError sends a 422 response listing every failed field
+func respondValidationError(c *gin.Context, validationErr *entity.ValidationError) {
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+		ErrorResponse: ErrorResponse{
+			Error:     "Unprocessable Entity",
+			Code:      errorCode(entity.ErrValidationFailed),
+			Message:   entity.ErrValidationFailed.Error(),
+			RequestID: GetRequestID(c),
+		},
+		Errors: validationErr.Violations,
+	})
+}
+
+// handleBindError responds to a failed ShouldBindJSON call: field-level validation
+// failures get a structured 422, malformed bodies a plain 400
+func handleBindError(c *gin.Context, err error) {
+	if respondIfBodyTooLarge(c, err) {
+		return
+	}
+	if validationErr, ok := asValidationError(err); ok {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+}
+
+// parseOptionalBool parses a boolean query parameter, returning nil when it is absent
+func parseOptionalBool(c *gin.Context, key string) (*bool, error) {
+	value, ok := c.GetQuery(key)
+	if !ok {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/usecase"
+)
+
+// ListCategories handles listing every category
+func ListCategories(categoryService *usecase.CategoryUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		categories, err := categoryService.ListCategories(c.Request.Context())
+		if err != nil {
+			handleCategoryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"categories": categories})
+	}
+}
+
+// GetCategoryTree handles returning the category hierarchy
+func GetCategoryTree(categoryService *usecase.CategoryUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tree, err := categoryService.GetCategoryTree(c.Request.Context())
+		if err != nil {
+			handleCategoryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"categories": tree})
+	}
+}
+
+// GetCategory handles getting a single category
+func GetCategory(categoryService *usecase.CategoryUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "category")
+		if !ok {
+			return
+		}
+
+		category, err := categoryService.GetCategory(c.Request.Context(), id)
+		if err != nil {
+			handleCategoryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, category)
+	}
+}
+
+// CreateCategory handles creating a new category
+func CreateCategory(categoryService *usecase.CategoryUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req service.CategoryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		category, err := categoryService.CreateCategory(c.Request.Context(), &req)
+		if err != nil {
+			handleCategoryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, category)
+	}
+}
+
+// UpdateCategory handles replacing a category's name, slug and parent
+func UpdateCategory(categoryService *usecase.CategoryUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "category")
+		if !ok {
+			return
+		}
+
+		var req service.CategoryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		category, err := categoryService.UpdateCategory(c.Request.Context(), id, &req)
+		if err != nil {
+			handleCategoryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, category)
+	}
+}
+
+// DeleteCategory handles deleting a category
+func DeleteCategory(categoryService *usecase.CategoryUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "category")
+		if !ok {
+			return
+		}
+
+		if err := categoryService.DeleteCategory(c.Request.Context(), id); err != nil {
+			handleCategoryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+	}
+}
+
+// handleCategoryError handles different types of category errors
+func handleCategoryError(c *gin.Context, err error) {
+	if respondIfReadOnly(c, err) {
+		return
+	}
+	if validationErr, ok := asValidationError(err); ok {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, entity.ErrCategoryNotFound):
+		respondDomainError(c, http.StatusNotFound, err)
+	case errors.Is(err, entity.ErrCategoryAlreadyExists), errors.Is(err, entity.ErrCategoryInUse):
+		respondDomainError(c, http.StatusConflict, err)
+	default:
+		respondInternalError(c, err)
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondConditionalJSON sends body as a 200 JSON response carrying a weak ETag derived from
+// its content and, unless lastModified is zero, a Last-Modified header. Clients that send back
+// a matching If-None-Match, or an If-Modified-Since no older than lastModified, get an empty
+// 304 Not Modified instead, so polling for unchanged resources costs no body. The body is
+// shaped for the request's API version.
+func respondConditionalJSON(c *gin.Context, body interface{}, lastModified time.Time) {
+	body, err := versionedBody(c, body)
+	if err != nil {
+		_ = c.Error(err)
+		RespondError(c, http.StatusInternalServerError, CodeInternalError, "Failed to encode response")
+		return
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		_ = c.Error(err)
+		RespondError(c, http.StatusInternalServerError, CodeInternalError, "Failed to encode response")
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	// Responses depend on the caller, so shared caches must not keep them and clients revalidate
+	c.Header("Cache-Control", "private, no-cache")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// notModified evaluates the request's conditional headers against the current validators.
+// If-None-Match takes precedence, so If-Modified-Since only counts when it is absent.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have whole-second precision
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/service"
+)
+
+// Context keys shared between middleware and handlers
+const (
+	RequestIDKey    = "request_id"
+	RequestIDHeader = "X-Request-ID"
+	UserIDKey       = "user_id"
+	IsAdminKey      = "is_admin"
+	ClaimsKey       = "claims"
+	APIVersionKey   = "api_version"
+	LoggerKey       = "logger"
+)
+
+// ConfirmDeleteHeader must be set to "true" to permanently delete a product
+const ConfirmDeleteHeader = "X-Confirm-Delete"
+
+// GetRequestID returns the request ID attached to the context, if any
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(RequestIDKey)
+}
+
+// GetLogger returns the logger requests are logged to, or the default logger outside of a
+// request. Log with the request's context so records are tagged with its request ID.
+func GetLogger(c *gin.Context) *slog.Logger {
+	if logger, ok := c.Value(LoggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// GetUserID returns the authenticated user's ID, if any
+func GetUserID(c *gin.Context) (uint, bool) {
+	value, ok := c.Get(UserIDKey)
+	if !ok {
+		return 0, false
+	}
+	userID, ok := value.(uint)
+	return userID, ok
+}
+
+// GetClaims returns the authenticated user's token claims, if any
+func GetClaims(c *gin.Context) (*service.Claims, bool) {
+	value, ok := c.Get(ClaimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := value.(*service.Claims)
+	return claims, ok && claims != nil
+}
+
+// IsAdmin reports whether the authenticated user is an administrator
+func IsAdmin(c *gin.Context) bool {
+	if claims, ok := GetClaims(c); ok {
+		return claims.IsAdmin
+	}
+	return c.GetBool(IsAdminKey)
+}
+
+// requestContext returns the request's context, carrying the authenticated user as the actor
+// for auditing when one is known
+func requestContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if userID, ok := GetUserID(c); ok {
+		return service.WithActorID(ctx, userID)
+	}
+	return ctx
+}
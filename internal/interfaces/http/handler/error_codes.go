@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// errorCodes maps domain errors to the stable codes sent in ErrorResponse.Code. Clients branch
+// on these rather than on messages, so a code must never change once published; add a new one
+// instead. An error can wrap several domain errors, e.g. a ValidationError wraps both
+// ErrValidationFailed and its cause, so the first match wins: keep specific errors ahead of the
+// general ones they may be wrapped with.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	// Products
+	{entity.ErrProductNotFound, "PRODUCT_NOT_FOUND"},
+	{entity.ErrProductNameRequired, "PRODUCT_NAME_REQUIRED"},
+	{entity.ErrProductNameTooShort, "PRODUCT_NAME_TOO_SHORT"},
+	{entity.ErrProductNameTooLong, "PRODUCT_NAME_TOO_LONG"},
+	{entity.ErrProductPriceInvalid, "PRODUCT_PRICE_INVALID"},
+	{entity.ErrProductStockInvalid, "PRODUCT_STOCK_INVALID"},
+	{entity.ErrLowStockThresholdInvalid, "LOW_STOCK_THRESHOLD_INVALID"},
+	{entity.ErrProductAlreadyExists, "PRODUCT_NAME_TAKEN"},
+	{entity.ErrProductHasReferences, "PRODUCT_HAS_REFERENCES"},
+	{entity.ErrInsufficientStock, "INSUFFICIENT_STOCK"},
+	{entity.ErrSearchQueryTooShort, "QUERY_TOO_SHORT"},
+	{entity.ErrSearchTooDeep, "SEARCH_TOO_DEEP"},
+	{entity.ErrProductImageNotFound, "PRODUCT_IMAGE_NOT_FOUND"},
+	{entity.ErrProductSKUExists, "PRODUCT_SKU_TAKEN"},
+	{entity.ErrImportJobNotFound, "IMPORT_JOB_NOT_FOUND"},
+	{entity.ErrTooManyImports, "TOO_MANY_IMPORTS"},
+	{entity.ErrConcurrentModification, "CONCURRENT_MODIFICATION"},
+	{entity.ErrSearchReindexRunning, "SEARCH_REINDEX_RUNNING"},
+
+	// Categories
+	{entity.ErrCategoryNotFound, "CATEGORY_NOT_FOUND"},
+	{entity.ErrCategoryAlreadyExists, "CATEGORY_NAME_TAKEN"},
+	{entity.ErrCategoryInUse, "CATEGORY_IN_USE"},
+
+	// Webhooks
+	{entity.ErrWebhookNotFound, "WEBHOOK_NOT_FOUND"},
+
+	// Users and authentication
+	{entity.ErrUserNotFound, "USER_NOT_FOUND"},
+	{entity.ErrUserEmailRequired, "EMAIL_REQUIRED"},
+	{entity.ErrUserUsernameRequired, "USERNAME_REQUIRED"},
+	{entity.ErrUserUsernameTooShort, "USERNAME_TOO_SHORT"},
+	{entity.ErrUserUsernameTooLong, "USERNAME_TOO_LONG"},
+	{entity.ErrEmailAlreadyExists, "EMAIL_TAKEN"},
+	{entity.ErrUsernameAlreadyExists, "USERNAME_TAKEN"},
+	{entity.ErrUserEmailConflict, "EMAIL_TAKEN"},
+	{entity.ErrUserUsernameConflict, "USERNAME_TAKEN"},
+	{entity.ErrInvalidCredentials, "INVALID_CREDENTIALS"},
+	{entity.ErrUserInactive, "USER_INACTIVE"},
+	{entity.ErrUnauthorized, "UNAUTHORIZED"},
+	{entity.ErrInvalidToken, "INVALID_TOKEN"},
+	{entity.ErrEmailNotVerified, "EMAIL_NOT_VERIFIED"},
+	{entity.ErrBadVerificationToken, "INVALID_VERIFICATION_TOKEN"},
+	{entity.ErrBadPasswordResetToken, "INVALID_PASSWORD_RESET_TOKEN"},
+	{entity.ErrTooManyPasswordResets, "RATE_LIMITED"},
+	{entity.ErrCannotModifySelf, "CANNOT_MODIFY_SELF"},
+
+	// General
+	{entity.ErrReadOnlyMode, "READ_ONLY_MODE"},
+
+	// Errors that come wrapped with a more specific one, matched only when nothing else is
+	{entity.ErrUserAlreadyExists, "USER_ALREADY_EXISTS"},
+	{entity.ErrInvalidInput, "INVALID_INPUT"},
+	{entity.ErrValidationFailed, "VALIDATION_FAILED"},
+}
+
+// errorCode returns the code of the domain error err is or wraps, or "" if it has none
+func errorCode(err error) string {
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec.code
+		}
+	}
+	return ""
+}
+
+// respondDomainError sends err's message with status and the code of the domain error it wraps
+func respondDomainError(c *gin.Context, status int, err error) {
+	RespondError(c, status, errorCode(err), err.Error())
+}
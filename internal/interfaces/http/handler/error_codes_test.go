@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestErrorCodesAreUpperSnakeCase(t *testing.T) {
+	pattern := regexp.MustCompile(`^[A-Z]+(_[A-Z]+)*$`)
+	for _, ec := range errorCodes {
+		if !pattern.MatchString(ec.code) {
+			t.Errorf("%v: got code %q, want UPPER_SNAKE_CASE", ec.err, ec.code)
+		}
+	}
+}
+
+func TestErrorCodeFollowsWrappedErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{entity.ErrProductNotFound, "PRODUCT_NOT_FOUND"},
+		{fmt.Errorf("%w: page must be positive", entity.ErrInvalidInput), "INVALID_INPUT"},
+		{fmt.Errorf("reserving stock: %w", entity.ErrInsufficientStock), "INSUFFICIENT_STOCK"},
+		{errors.New("connection refused"), ""},
+	}
+	for _, tt := range tests {
+		if got := errorCode(tt.err); got != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestErrorCodePrefersTheMostSpecificWrappedError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{entity.NewValidationError("price", "min", entity.ErrProductPriceInvalid), "PRODUCT_PRICE_INVALID"},
+		{fmt.Errorf("row 3: %w", entity.NewValidationError("username", "max", entity.ErrUserUsernameTooLong)), "USERNAME_TOO_LONG"},
+		{errors.Join(entity.ErrInvalidInput, entity.ErrProductStockInvalid), "PRODUCT_STOCK_INVALID"},
+		{errors.Join(entity.ErrValidationFailed, entity.ErrInvalidInput), "INVALID_INPUT"},
+		{entity.ErrValidationFailed, "VALIDATION_FAILED"},
+	}
+	for _, tt := range tests {
+		// Repeat, since a map-based lookup could pass by chance
+		for range 20 {
+			if got := errorCode(tt.err); got != tt.want {
+				t.Fatalf("%v: got %q, want %q", tt.err, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestDomainErrorEnvelopesCarryTheirCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond func(*gin.Context)
+		status  int
+		code    string
+	}{
+		{"missing product", func(c *gin.Context) { handleError(c, entity.ErrProductNotFound) }, http.StatusNotFound, "PRODUCT_NOT_FOUND"},
+		{"taken SKU", func(c *gin.Context) { handleError(c, entity.ErrProductSKUExists) }, http.StatusConflict, "PRODUCT_SKU_TAKEN"},
+		{"invalid price", func(c *gin.Context) { handleError(c, entity.ErrProductPriceInvalid) }, http.StatusBadRequest, "PRODUCT_PRICE_INVALID"},
+		{"import running", func(c *gin.Context) { handleError(c, entity.ErrTooManyImports) }, http.StatusTooManyRequests, "TOO_MANY_IMPORTS"},
+		{"stale version", func(c *gin.Context) { handleError(c, entity.ErrConcurrentModification) }, http.StatusConflict, "CONCURRENT_MODIFICATION"},
+		{"wrong password", func(c *gin.Context) { handleAuthError(c, entity.ErrInvalidCredentials) }, http.StatusUnauthorized, "INVALID_CREDENTIALS"},
+		{"unverified email", func(c *gin.Context) { handleAuthError(c, entity.ErrEmailNotVerified) }, http.StatusForbidden, "EMAIL_NOT_VERIFIED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := serve(t, tt.respond)
+			if status != tt.status || response.Code != tt.code || response.RequestID != "req-123" {
+				t.Errorf("got %d %+v, want %d with code %s and the request ID", status, response, tt.status, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// StatusClientClosedRequest is the non-standard status (from nginx) recorded for requests
+// abandoned by the client before a response was ready
+const StatusClientClosedRequest = 499
+
+// CodeInternalError is the error code sent with every 500 response, whether from a failed
+// operation or a recovered panic
+const CodeInternalError = "INTERNAL_ERROR"
+
+// RespondError aborts the request with status and the standard ErrorResponse envelope, so
+// handlers and middleware all send errors in the same shape. code is an optional stable,
+// machine-readable identifier; message is the human-readable explanation.
+func RespondError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, ErrorResponse{
+		Error:     http.StatusText(status),
+		Code:      code,
+		Message:   message,
+		RequestID: GetRequestID(c),
+	})
+}
+
+// respondIfCanceled responds and returns true if err came from the request's context ending.
+// A client that hung up gets 499, which it will never see but which keeps the request out of
+// the 5xx error rate; one that ran out of time gets the 504 the timeout middleware sends.
+func respondIfCanceled(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, context.Canceled):
+		c.AbortWithStatus(StatusClientClosedRequest)
+	case errors.Is(err, context.DeadlineExceeded):
+		RespondError(c, http.StatusGatewayTimeout, "REQUEST_TIMEOUT", "The request did not complete within its time limit")
+	default:
+		return false
+	}
+	return true
+}
+
+// respondInternalError logs the full error server-side and sends a generic 500 response.
+// The raw error is only echoed back in Details outside of release mode so internal and
+// database details never leak in production; clients correlate via the request ID instead.
+func respondInternalError(c *gin.Context, err error) {
+	requestID := GetRequestID(c)
+	GetLogger(c).ErrorContext(c.Request.Context(), "Internal error",
+		slog.String("method", c.Request.Method),
+		slog.String("path", c.Request.URL.Path),
+		slog.Any("error", err),
+	)
+
+	response := ErrorResponse{
+		Error:     "Internal Server Error",
+		Code:      CodeInternalError,
+		Message:   "An unexpected error occurred",
+		RequestID: requestID,
+	}
+	if gin.Mode() != gin.ReleaseMode {
+		response.Details = err.Error()
+	}
+
+	c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+}
+
+// RespondBodyTooLarge sends a 413 response stating the body size limit that applies to the route
+func RespondBodyTooLarge(c *gin.Context, limit int64) {
+	RespondError(c, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE", fmt.Sprintf("Request body exceeds the %s limit for this endpoint", formatBytes(limit)))
+}
+
+// respondIfBodyTooLarge sends a 413 response and returns true if err came from reading
+// past the route's body size limit
+func respondIfBodyTooLarge(c *gin.Context, err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		return false
+	}
+	RespondBodyTooLarge(c, maxBytesErr.Limit)
+	return true
+}
+
+// readOnlyRetryAfterSeconds is the Retry-After hint sent while writes are disabled
+const readOnlyRetryAfterSeconds = 60
+
+// respondIfReadOnly sends a 503 response and returns true if err was caused by a write
+// attempted while the service is in read-only mode
+func respondIfReadOnly(c *gin.Context, err error) bool {
+	if !errors.Is(err, entity.ErrReadOnlyMode) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(readOnlyRetryAfterSeconds))
+	RespondError(c, http.StatusServiceUnavailable, "READ_ONLY_MODE", "The service is temporarily read-only for maintenance, please retry later")
+	return true
+}
+
+// formatBytes renders a byte count using the largest whole binary unit, e.g. 4096 as "4KB"
+func formatBytes(n int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	unit := 0
+	for unit < len(units)-1 && n >= 1024 && n%1024 == 0 {
+		n /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%d%s", n, units[unit])
+}
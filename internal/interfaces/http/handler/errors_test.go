@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/infrastructure/logging"
+)
+
+// serve runs handler for a GET request carrying a request ID and decodes the error envelope
+func serve(t *testing.T, handler gin.HandlerFunc) (int, ErrorResponse) {
+	t.Helper()
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		c.Set(RequestIDKey, "req-123")
+	}, handler)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode %q: %v", recorder.Body.String(), err)
+	}
+	return recorder.Code, response
+}
+
+func TestUnauthorizedEnvelope(t *testing.T) {
+	status, response := serve(t, (&AuthHandler{}).GetProfile)
+
+	if status != http.StatusUnauthorized {
+		t.Errorf("got status %d, want %d", status, http.StatusUnauthorized)
+	}
+	want := ErrorResponse{Error: "Unauthorized", Code: "UNAUTHORIZED", Message: "User ID not found in context", RequestID: "req-123"}
+	if response != want {
+		t.Errorf("got %+v, want %+v", response, want)
+	}
+}
+
+func TestInternalErrorEnvelope(t *testing.T) {
+	mode := gin.Mode()
+	defer gin.SetMode(mode)
+
+	fail := func(c *gin.Context) { respondInternalError(c, errors.New("pq: relation \"products\" does not exist")) }
+
+	gin.SetMode(gin.ReleaseMode)
+	status, response := serve(t, fail)
+	if status != http.StatusInternalServerError {
+		t.Errorf("got status %d, want %d", status, http.StatusInternalServerError)
+	}
+	want := ErrorResponse{Error: "Internal Server Error", Code: CodeInternalError, Message: "An unexpected error occurred", RequestID: "req-123"}
+	if response != want {
+		t.Errorf("release mode: got %+v, want %+v", response, want)
+	}
+
+	gin.SetMode(gin.DebugMode)
+	if _, response := serve(t, fail); response.Details == "" {
+		t.Error("debug mode: the error was not echoed in details")
+	}
+}
+
+func TestInvalidIDEnvelope(t *testing.T) {
+	status, response := serve(t, func(c *gin.Context) { parseIDParam(c, "id", "product") })
+
+	if status != http.StatusBadRequest || response.Code != "INVALID_ID" || response.RequestID != "req-123" {
+		t.Errorf("got %d %+v, want 400 with code INVALID_ID and the request ID", status, response)
+	}
+}
+
+func TestSearchTooDeepEnvelope(t *testing.T) {
+	err := fmt.Errorf("%w: only the first 1000 results can be paged through, please refine the query", entity.ErrSearchTooDeep)
+	status, response := serve(t, func(c *gin.Context) { handleError(c, err) })
+
+	if status != http.StatusBadRequest || response.Code != "SEARCH_TOO_DEEP" {
+		t.Errorf("got %d %+v, want 400 with code SEARCH_TOO_DEEP", status, response)
+	}
+}
+
+func TestInternalErrorIsLoggedWithTheRequestID(t *testing.T) {
+	var logs bytes.Buffer
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		c.Set(RequestIDKey, "req-123")
+		c.Set(LoggerKey, logging.NewLogger(&logs, "json", "info"))
+		c.Request = c.Request.WithContext(service.WithRequestID(c.Request.Context(), "req-123"))
+	}, func(c *gin.Context) {
+		respondInternalError(c, errors.New("connection reset"))
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("decode log record %q: %v", logs.String(), err)
+	}
+	if record["request_id"] != "req-123" || record["error"] != "connection reset" || record["level"] != "ERROR" {
+		t.Errorf("got log record %v, want an error carrying request_id req-123", record)
+	}
+}
+
+func TestUserConflictEnvelopeNamesTheTakenField(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{entity.ErrEmailAlreadyExists, "EMAIL_TAKEN"},
+		{entity.ErrUsernameAlreadyExists, "USERNAME_TAKEN"},
+	}
+	for _, tt := range tests {
+		status, response := serve(t, func(c *gin.Context) { handleAuthError(c, tt.err) })
+
+		if status != http.StatusConflict || response.Code != tt.code {
+			t.Errorf("%v: got %d %+v, want 409 with code %s", tt.err, status, response, tt.code)
+		}
+	}
+}
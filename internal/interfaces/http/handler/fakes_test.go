@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/usecase"
+)
+
+// stubProductRepo serves products from memory, or fails every call with err when it is set.
+// It embeds the interface, so calling a method a test doesn't expect panics.
+type stubProductRepo struct {
+	repository.ProductRepository
+	mu       sync.Mutex
+	products map[uint]*entity.Product
+	err      error
+}
+
+func (r *stubProductRepo) GetByID(_ context.Context, id uint) (*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	product, ok := r.products[id]
+	if !ok {
+		return nil, entity.ErrProductNotFound
+	}
+	found := *product
+	return &found, nil
+}
+
+// GetByIDs returns the stored products among ids, in no particular order
+func (r *stubProductRepo) GetByIDs(_ context.Context, ids []uint) ([]*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	var products []*entity.Product
+	for _, id := range ids {
+		if product, ok := r.products[id]; ok {
+			found := *product
+			products = append(products, &found)
+		}
+	}
+	return products, nil
+}
+
+// matching returns the products passing filter's active flag and categories, in ID order
+func (r *stubProductRepo) matching(filter *repository.ProductFilter) []*entity.Product {
+	var products []*entity.Product
+	for _, product := range r.products {
+		if filter != nil && filter.IsActive != nil && product.IsActive != *filter.IsActive {
+			continue
+		}
+		if filter != nil && len(filter.Categories) > 0 && !slices.Contains(filter.Categories, product.Category) {
+			continue
+		}
+		found := *product
+		products = append(products, &found)
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	return products
+}
+
+func (r *stubProductRepo) GetAll(_ context.Context, filter *repository.ProductFilter, offset, limit int) ([]*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	products := r.matching(filter)
+	if offset >= len(products) {
+		return nil, nil
+	}
+	return products[offset:min(offset+limit, len(products))], nil
+}
+
+func (r *stubProductRepo) GetTotalCount(_ context.Context, filter *repository.ProductFilter) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return 0, r.err
+	}
+	return int64(len(r.matching(filter))), nil
+}
+
+func (r *stubProductRepo) GetBySKU(_ context.Context, sku string) (*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, product := range r.products {
+		if product.SKU != nil && *product.SKU == sku {
+			found := *product
+			return &found, nil
+		}
+	}
+	return nil, entity.ErrProductNotFound
+}
+
+func (r *stubProductRepo) ExistsByNameInCategory(_ context.Context, name, category string, excludeID uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, product := range r.products {
+		if product.ID != excludeID && strings.EqualFold(product.Name, name) && product.Category == category {
+			return true, r.err
+		}
+	}
+	return false, r.err
+}
+
+// Update stores product and moves it to the next version, provided it is still at the version
+// it was read at, like the real repository
+func (r *stubProductRepo) Update(_ context.Context, product *entity.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	stored, ok := r.products[product.ID]
+	if !ok {
+		return entity.ErrProductNotFound
+	}
+	if stored.Version != product.Version {
+		return entity.ErrConcurrentModification
+	}
+	product.Version++
+	updated := *product
+	r.products[product.ID] = &updated
+	return nil
+}
+
+func (r *stubProductRepo) DecrementStock(_ context.Context, id uint, qty int) (*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	product, ok := r.products[id]
+	if !ok {
+		return nil, entity.ErrProductNotFound
+	}
+	if product.Stock < qty {
+		return nil, entity.ErrInsufficientStock
+	}
+	product.Stock -= qty
+	updated := *product
+	return &updated, nil
+}
+
+// CountReferences reports no references
+func (r *stubProductRepo) CountReferences(context.Context, uint) (entity.ProductReferences, error) {
+	return entity.ProductReferences{}, r.err
+}
+
+func (r *stubProductRepo) HardDelete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	delete(r.products, id)
+	return nil
+}
+
+// nopAuditRepo discards audit entries
+type nopAuditRepo struct {
+	repository.AuditLogRepository
+}
+
+func (nopAuditRepo) Create(context.Context, *entity.AuditLog) error {
+	return nil
+}
+
+// stubTxManager runs transactions directly
+type stubTxManager struct{}
+
+func (stubTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (stubTxManager) WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// nopEventLogger discards business events
+type nopEventLogger struct{}
+
+func (nopEventLogger) LogEvent(context.Context, service.BusinessEvent) {}
+
+// newProductService returns a product use case backed by repo
+func newProductService(repo repository.ProductRepository) *usecase.ProductUseCase {
+	return usecase.NewProductUseCase(repo, nil, nil, nil, nopAuditRepo{}, nil, nil, stubTxManager{}, usecase.ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+}
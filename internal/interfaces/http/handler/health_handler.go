@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/product-management/internal/infrastructure/database"
+	"github.com/product-management/pkg/version"
 )
 
 // HealthHandler handles health check requests
@@ -20,11 +22,23 @@ func NewHealthHandler(db *database.Database) *HealthHandler {
 	}
 }
 
+// healthCheckTimeout bounds each dependency check so a hung dependency reports unhealthy quickly
+const healthCheckTimeout = 2 * time.Second
+
 // HealthResponse represents a health check response
 type HealthResponse struct {
-	Status    string            `json:"status"`
-	Timestamp string            `json:"timestamp"`
-	Services  map[string]string `json:"services"`
+	Status    string                   `json:"status"`
+	Timestamp string                   `json:"timestamp"`
+	Version   string                   `json:"version"`
+	Commit    string                   `json:"commit"`
+	Services  map[string]ServiceHealth `json:"services"`
+}
+
+// ServiceHealth represents the health of a single dependency
+type ServiceHealth struct {
+	Status         string  `json:"status"`
+	ResponseTimeMs float64 `json:"response_time_ms"`
+	Error          string  `json:"error,omitempty"`
 }
 
 // HealthCheck godoc
@@ -36,23 +50,23 @@ type HealthResponse struct {
 // @Failure 503 {object} HealthResponse
 // @Router /health [get]
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
-	services := make(map[string]string)
-	overallStatus := "healthy"
-
-	// Check database health
-	if err := h.db.HealthCheck(); err != nil {
-		services["database"] = "unhealthy"
-		overallStatus = "unhealthy"
-	} else {
-		services["database"] = "healthy"
+	services := map[string]ServiceHealth{
+		"api":      {Status: "healthy"},
+		"database": h.checkDatabase(c.Request.Context()),
 	}
 
-	// Add more service checks here as needed
-	services["api"] = "healthy"
+	overallStatus := "healthy"
+	for _, service := range services {
+		if service.Status != "healthy" {
+			overallStatus = "unhealthy"
+		}
+	}
 
 	response := HealthResponse{
 		Status:    overallStatus,
 		Timestamp: time.Now().Format(time.RFC3339),
+		Version:   version.Version,
+		Commit:    version.Commit,
 		Services:  services,
 	}
 
@@ -63,22 +77,22 @@ func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	}
 }
 
-// HealthCheck is a standalone function for the health check endpoint
-func HealthCheck(c *gin.Context) {
-	services := make(map[string]string)
-	overallStatus := "healthy"
-
-	// For now, we'll just return a basic health check
-	// In a real implementation, you might want to inject the database dependency
-	services["api"] = "healthy"
+// checkDatabase pings the database within healthCheckTimeout and reports how long it took
+func (h *HealthHandler) checkDatabase(ctx context.Context) ServiceHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
 
-	response := HealthResponse{
-		Status:    overallStatus,
-		Timestamp: time.Now().Format(time.RFC3339),
-		Services:  services,
+	start := time.Now()
+	err := h.db.HealthCheck(ctx)
+	health := ServiceHealth{
+		Status:         "healthy",
+		ResponseTimeMs: float64(time.Since(start).Microseconds()) / 1000,
 	}
-
-	c.JSON(http.StatusOK, response)
+	if err != nil {
+		health.Status = "unhealthy"
+		health.Error = err.Error()
+	}
+	return health
 }
 
 // ReadinessCheck godoc
@@ -91,12 +105,10 @@ func HealthCheck(c *gin.Context) {
 // @Router /ready [get]
 func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
 	// Check if database is ready
-	if err := h.db.HealthCheck(); err != nil {
-		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error:   "Service Unavailable",
-			Message: "Database is not ready",
-			Details: err.Error(),
-		})
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthCheckTimeout)
+	defer cancel()
+	if err := h.db.HealthCheck(ctx); err != nil {
+		RespondError(c, http.StatusServiceUnavailable, "NOT_READY", "Database is not ready: "+err.Error())
 		return
 	}
 
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/infrastructure/repository"
+	"github.com/product-management/internal/usecase"
+)
+
+// GetReadOnlyMode handles reporting whether writes are currently rejected
+func GetReadOnlyMode(mode *repository.ReadOnlyMode) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, ReadOnlyModeResponse{Enabled: mode.Enabled()})
+	}
+}
+
+// SetReadOnlyMode handles turning read-only mode on or off, e.g. around a schema migration
+func SetReadOnlyMode(mode *repository.ReadOnlyMode) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ReadOnlyModeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		mode.Set(*req.Enabled)
+		userID, _ := GetUserID(c)
+		GetLogger(c).InfoContext(c.Request.Context(), "Read-only mode changed", slog.Bool("enabled", *req.Enabled), slog.Uint64("user_id", uint64(userID)))
+
+		c.JSON(http.StatusOK, ReadOnlyModeResponse{Enabled: mode.Enabled()})
+	}
+}
+
+// StartSearchReindex handles starting a background recompute of every product's search
+// vector, e.g. after a bulk import or a change to the search configuration. It answers 202
+// with the reindex status, which GetSearchReindexStatus then reports the progress of.
+func StartSearchReindex(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, err := productService.StartSearchReindex(requestContext(c))
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		userID, _ := GetUserID(c)
+		GetLogger(c).InfoContext(c.Request.Context(), "Search reindex started", slog.Uint64("user_id", uint64(userID)))
+
+		c.JSON(http.StatusAccepted, status)
+	}
+}
+
+// GetSearchReindexStatus handles reporting the progress of the running search reindex, or the
+// outcome of the last one
+func GetSearchReindexStatus(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, productService.SearchReindexStatus())
+	}
+}
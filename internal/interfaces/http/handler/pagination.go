@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// PaginationDefaults configures how ParsePagination fills in and clamps values
+type PaginationDefaults struct {
+	PageSize    int
+	MaxPageSize int
+	Headers     bool // whether list responses also carry pagination headers, see setPaginationHeaders
+}
+
+// PageSizeCappedHeader is set on list responses whose requested page_size exceeded the maximum
+const PageSizeCappedHeader = "X-Page-Size-Capped"
+
+// DefaultPagination is used by list endpoints that have no special requirements. The router
+// replaces it with the configured sizes at startup.
+var DefaultPagination = PaginationDefaults{
+	PageSize:    10,
+	MaxPageSize: 100,
+}
+
+// ParsePagination reads the page and page_size query parameters so every list endpoint
+// handles them the same way: a missing or invalid page becomes 1 and a missing page_size
+// becomes the default. A page_size that isn't a positive integer fails with
+// entity.ErrInvalidInput, and one above the maximum is capped, which is reported in the
+// X-Page-Size-Capped response header.
+//
+// The legacy limit/offset parameters are still honoured when page/page_size are absent.
+func ParsePagination(c *gin.Context, defaults PaginationDefaults) (page, pageSize, offset int, err error) {
+	pageSize = defaults.PageSize
+	for _, key := range []string{"page_size", "limit"} {
+		raw, ok := c.GetQuery(key)
+		if !ok {
+			continue
+		}
+		pageSize, err = strconv.Atoi(raw)
+		if err != nil || pageSize < 1 {
+			return 0, 0, 0, fmt.Errorf("%w: %s must be a positive integer", entity.ErrInvalidInput, key)
+		}
+		break
+	}
+	if defaults.MaxPageSize > 0 && pageSize > defaults.MaxPageSize {
+		c.Header(PageSizeCappedHeader, strconv.Itoa(defaults.MaxPageSize))
+		pageSize = defaults.MaxPageSize
+	}
+
+	legacyOffset := queryInt(c, "offset", 0)
+	page = queryInt(c, "page", legacyOffset/pageSize+1)
+	if page < 1 {
+		page = 1
+	}
+
+	return page, pageSize, (page - 1) * pageSize, nil
+}
+
+// Pagination response headers, mirroring service.PageInfo for clients that don't read the body
+const (
+	TotalCountHeader = "X-Total-Count"
+	PageHeader       = "X-Page"
+	PageSizeHeader   = "X-Page-Size"
+	TotalPagesHeader = "X-Total-Pages"
+)
+
+// setPaginationHeaders reports info in the pagination headers and an RFC 8288 Link header
+// with first, prev, next and last links, when DefaultPagination enables them
+func setPaginationHeaders(c *gin.Context, info service.PageInfo) {
+	if !DefaultPagination.Headers {
+		return
+	}
+
+	c.Header(TotalCountHeader, strconv.FormatInt(info.Total, 10))
+	c.Header(PageHeader, strconv.Itoa(info.Page))
+	c.Header(PageSizeHeader, strconv.Itoa(info.PageSize))
+	c.Header(TotalPagesHeader, strconv.Itoa(info.TotalPages))
+	c.Header("Link", paginationLinks(c.Request.URL, info))
+}
+
+// paginationLinks builds the Link header value for the pages around info, keeping the
+// request's other query parameters. An empty list still has a first and last page, page 1.
+func paginationLinks(requestURL *url.URL, info service.PageInfo) string {
+	lastPage := info.TotalPages
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	link := func(page int, rel string) string {
+		query := requestURL.Query()
+		// page/page_size replace the legacy parameters, which would otherwise conflict
+		query.Del("limit")
+		query.Del("offset")
+		query.Set("page", strconv.Itoa(page))
+		query.Set("page_size", strconv.Itoa(info.PageSize))
+		target := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, target.String(), rel)
+	}
+
+	links := []string{link(1, "first")}
+	if info.Page > 1 {
+		links = append(links, link(min(info.Page-1, lastPage), "prev"))
+	}
+	if info.Page < lastPage {
+		links = append(links, link(info.Page+1, "next"))
+	}
+	links = append(links, link(lastPage, "last"))
+	return strings.Join(links, ", ")
+}
+
+// respondInvalidPagination responds 400 to pagination parameters rejected by ParsePagination
+func respondInvalidPagination(c *gin.Context, err error) {
+	RespondError(c, http.StatusBadRequest, "INVALID_PAGINATION", err.Error())
+}
+
+// queryInt returns an integer query parameter, or fallback when it is absent or malformed
+func queryInt(c *gin.Context, key string, fallback int) int {
+	value, err := strconv.Atoi(c.Query(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
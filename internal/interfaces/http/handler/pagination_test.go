@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// testContext returns a gin context for a GET of target and its response recorder
+func testContext(target string) (*gin.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c, recorder
+}
+
+func TestParsePagination(t *testing.T) {
+	defaults := PaginationDefaults{PageSize: 10, MaxPageSize: 50}
+	tests := []struct {
+		query                  string
+		page, pageSize, offset int
+	}{
+		{"", 1, 10, 0},
+		{"?page=3&page_size=20", 3, 20, 40},
+		{"?page=0", 1, 10, 0},
+		{"?page=abc", 1, 10, 0},
+		{"?limit=5&offset=10", 3, 5, 10},
+		{"?page=2&page_size=5&limit=7&offset=30", 2, 5, 5},
+	}
+	for _, tt := range tests {
+		c, _ := testContext("/products" + tt.query)
+		page, pageSize, offset, err := ParsePagination(c, defaults)
+		if err != nil {
+			t.Errorf("%q: %v", tt.query, err)
+			continue
+		}
+		if page != tt.page || pageSize != tt.pageSize || offset != tt.offset {
+			t.Errorf("%q: got page %d, size %d, offset %d, want %d, %d, %d", tt.query, page, pageSize, offset, tt.page, tt.pageSize, tt.offset)
+		}
+	}
+}
+
+func TestParsePaginationCapsThePageSize(t *testing.T) {
+	c, recorder := testContext("/products?page_size=500")
+	_, pageSize, _, err := ParsePagination(c, PaginationDefaults{PageSize: 10, MaxPageSize: 50})
+	if err != nil {
+		t.Fatalf("ParsePagination: %v", err)
+	}
+	if pageSize != 50 || recorder.Header().Get(PageSizeCappedHeader) != "50" {
+		t.Errorf("got page size %d and capped header %q, want 50 for both", pageSize, recorder.Header().Get(PageSizeCappedHeader))
+	}
+}
+
+func TestParsePaginationRejectsInvalidPageSizes(t *testing.T) {
+	for _, query := range []string{"?page_size=0", "?page_size=-5", "?page_size=ten", "?limit=0"} {
+		c, _ := testContext("/products" + query)
+		if _, _, _, err := ParsePagination(c, DefaultPagination); !errors.Is(err, entity.ErrInvalidInput) {
+			t.Errorf("%q: got %v, want %v", query, err, entity.ErrInvalidInput)
+		}
+	}
+}
+
+func TestNewPageInfo(t *testing.T) {
+	tests := []struct {
+		total      int64
+		pageSize   int
+		totalPages int
+	}{
+		{0, 10, 0},
+		{1, 10, 1},
+		{10, 10, 1},
+		{11, 10, 2},
+		{5, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := service.NewPageInfo(tt.total, 1, tt.pageSize).TotalPages; got != tt.totalPages {
+			t.Errorf("%d items in pages of %d: got %d pages, want %d", tt.total, tt.pageSize, got, tt.totalPages)
+		}
+	}
+}
+
+func TestPaginationLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		info   service.PageInfo
+		want   string
+	}{
+		{
+			"middle page keeps other parameters",
+			"/products?category=Lighting&page=2&page_size=10",
+			service.NewPageInfo(35, 2, 10),
+			`</products?category=Lighting&page=1&page_size=10>; rel="first", ` +
+				`</products?category=Lighting&page=1&page_size=10>; rel="prev", ` +
+				`</products?category=Lighting&page=3&page_size=10>; rel="next", ` +
+				`</products?category=Lighting&page=4&page_size=10>; rel="last"`,
+		},
+		{
+			"legacy parameters are replaced",
+			"/products?limit=10&offset=0",
+			service.NewPageInfo(15, 1, 10),
+			`</products?page=1&page_size=10>; rel="first", </products?page=2&page_size=10>; rel="next", </products?page=2&page_size=10>; rel="last"`,
+		},
+		{
+			"empty list",
+			"/products",
+			service.NewPageInfo(0, 1, 10),
+			`</products?page=1&page_size=10>; rel="first", </products?page=1&page_size=10>; rel="last"`,
+		},
+		{
+			"past the last page",
+			"/products?page=9",
+			service.NewPageInfo(15, 9, 10),
+			`</products?page=1&page_size=10>; rel="first", </products?page=2&page_size=10>; rel="prev", </products?page=2&page_size=10>; rel="last"`,
+		},
+	}
+	for _, tt := range tests {
+		c, _ := testContext(tt.target)
+		if got := paginationLinks(c.Request.URL, tt.info); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetPaginationHeadersFollowsTheConfiguration(t *testing.T) {
+	defaults := DefaultPagination
+	defer func() { DefaultPagination = defaults }()
+	info := service.NewPageInfo(35, 2, 10)
+
+	DefaultPagination.Headers = true
+	c, recorder := testContext("/products?page=2")
+	setPaginationHeaders(c, info)
+	for header, want := range map[string]string{TotalCountHeader: "35", PageHeader: "2", PageSizeHeader: "10", TotalPagesHeader: "4"} {
+		if got := recorder.Header().Get(header); got != want {
+			t.Errorf("got %s %q, want %q", header, got, want)
+		}
+	}
+	if recorder.Header().Get("Link") == "" {
+		t.Error("no Link header was set")
+	}
+
+	DefaultPagination.Headers = false
+	c, recorder = testContext("/products?page=2")
+	setPaginationHeaders(c, info)
+	if got := recorder.Header().Get(TotalCountHeader); got != "" {
+		t.Errorf("headers disabled: got %s %q, want none", TotalCountHeader, got)
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// maxID is the largest ID that fits both the platform's uint and a Postgres bigint
+const maxID = uint64(math.MaxInt64) >> (64 - strconv.IntSize)
+
+// parseID parses a path ID, rejecting zero, negative, non-numeric and out-of-range values
+func parseID(raw string) (uint, bool) {
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || id == 0 || id > maxID {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseListQuery returns the values of a query parameter that may be repeated, comma-separated
+// or both, e.g. ?category=a,b&category=c. Values are trimmed and empty or repeated ones dropped.
+func parseListQuery(c *gin.Context, name string) []string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, raw := range c.QueryArray(name) {
+		for _, value := range strings.Split(raw, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// queryParamOrAlias returns alias when only the alias of a query parameter was sent, and the
+// parameter's own name otherwise
+func queryParamOrAlias(c *gin.Context, name, alias string) string {
+	if _, ok := c.GetQuery(name); !ok {
+		if _, ok := c.GetQuery(alias); ok {
+			return alias
+		}
+	}
+	return name
+}
+
+// parseTagsQuery parses a list query parameter of product tags, normalizing each tag and
+// dropping duplicates that only differed in case
+func parseTagsQuery(c *gin.Context, name string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, value := range parseListQuery(c, name) {
+		tag := entity.NormalizeTag(value)
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// parseIDParam parses the named path parameter as an ID, responding with 400 when it is
+// not a positive integer in range. resource names the entity in the error message.
+func parseIDParam(c *gin.Context, name, resource string) (uint, bool) {
+	id, ok := parseID(c.Param(name))
+	if !ok {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "Invalid "+resource+" ID: must be a positive integer")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		raw string
+		id  uint
+		ok  bool
+	}{
+		{"1", 1, true},
+		{"42", 42, true},
+		{strconv.FormatUint(maxID, 10), uint(maxID), true},
+		{"0", 0, false},
+		{"-1", 0, false},
+		{"abc", 0, false},
+		{"", 0, false},
+		{"1.5", 0, false},
+		{strconv.FormatUint(maxID+1, 10), 0, false},
+		{strconv.FormatUint(math.MaxUint64, 10), 0, false},
+		{"99999999999999999999999", 0, false},
+	}
+	for _, tt := range tests {
+		id, ok := parseID(tt.raw)
+		if id != tt.id || ok != tt.ok {
+			t.Errorf("parseID(%q): got %d, %v, want %d, %v", tt.raw, id, ok, tt.id, tt.ok)
+		}
+	}
+}
+
+func TestParseIDParamRejectsOverflowingIDs(t *testing.T) {
+	status, response := serve(t, func(c *gin.Context) {
+		c.Params = gin.Params{{Key: "id", Value: "18446744073709551616"}}
+		parseIDParam(c, "id", "product")
+	})
+
+	if status != http.StatusBadRequest || response.Code != "INVALID_ID" {
+		t.Errorf("got %d %+v, want 400 with code INVALID_ID", status, response)
+	}
+}
+
+func TestParseListQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"?category=Lighting", []string{"Lighting"}},
+		{"?category=Lighting,Office", []string{"Lighting", "Office"}},
+		{"?category=Lighting&category=Office", []string{"Lighting", "Office"}},
+		{"?category=Lighting,%20Office&category=Garden", []string{"Lighting", "Office", "Garden"}},
+		{"?category=Lighting,,Lighting&category=", []string{"Lighting"}},
+	}
+	for _, tt := range tests {
+		c, _ := testContext("/products" + tt.query)
+		if got := parseListQuery(c, "category"); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
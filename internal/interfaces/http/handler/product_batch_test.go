@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// postBatch posts body to the GetProductsBatch handler, allowing at most maxIDs IDs
+func postBatch(repo *stubProductRepo, maxIDs int, body string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.POST("/products/batch", GetProductsBatch(newProductService(repo), maxIDs))
+
+	request := httptest.NewRequest(http.MethodPost, "/products/batch", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGetProductsBatchReportsMissingIDs(t *testing.T) {
+	repo := &stubProductRepo{products: map[uint]*entity.Product{
+		1: {ID: 1, Name: "Desk Lamp"},
+		2: {ID: 2, Name: "Office Chair"},
+	}}
+
+	recorder := postBatch(repo, 10, `{"ids": [2, 9, 1, 2]}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+	var result service.ProductBatchResult
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	var ids []uint
+	for _, product := range result.Products {
+		ids = append(ids, product.ID)
+	}
+	if !slices.Equal(ids, []uint{2, 1}) {
+		t.Errorf("got products %v, want [2 1] in request order without duplicates", ids)
+	}
+	if !slices.Equal(result.NotFound, []uint{9}) {
+		t.Errorf("got not_found %v, want [9]", result.NotFound)
+	}
+}
+
+func TestGetProductsBatchStatusFollowsTheError(t *testing.T) {
+	tests := []struct {
+		name   string
+		repo   *stubProductRepo
+		body   string
+		status int
+	}{
+		{"no IDs", &stubProductRepo{}, `{"ids": []}`, http.StatusUnprocessableEntity},
+		{"missing IDs", &stubProductRepo{}, `{}`, http.StatusUnprocessableEntity},
+		{"too many IDs", &stubProductRepo{}, `{"ids": [1, 2, 3, 4]}`, http.StatusBadRequest},
+		{"database failure", &stubProductRepo{err: errors.New("connection refused")}, `{"ids": [1]}`, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postBatch(tt.repo, 3, tt.body).Code; got != tt.status {
+				t.Errorf("got status %d, want %d", got, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestGetAllProductsAnswersEndedRequests(t *testing.T) {
+	repo := &stubProductRepo{products: map[uint]*entity.Product{1: {ID: 1, Name: "Desk Lamp"}}}
+	router := gin.New()
+	router.GET("/products", GetAllProducts(newProductService(repo)))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+	}{
+		{"client hung up", canceled, StatusClientClosedRequest},
+		{"time limit reached", expired, http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/products", nil).WithContext(tt.ctx))
+
+			if recorder.Code != tt.status {
+				t.Fatalf("got status %d, want %d", recorder.Code, tt.status)
+			}
+			if tt.status != http.StatusGatewayTimeout {
+				return
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Code != "REQUEST_TIMEOUT" {
+				t.Errorf("got body %q, want the REQUEST_TIMEOUT envelope", recorder.Body)
+			}
+		})
+	}
+}
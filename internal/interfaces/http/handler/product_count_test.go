@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// getJSON requests target from handler and decodes the response into response
+func getJSON(t *testing.T, handler gin.HandlerFunc, target string, response any) int {
+	t.Helper()
+	router := gin.New()
+	router.GET("/products", handler)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatalf("decode %q: %v", recorder.Body.String(), err)
+	}
+	return recorder.Code
+}
+
+func TestCountProductsMatchesTheListTotal(t *testing.T) {
+	repo := &stubProductRepo{products: map[uint]*entity.Product{
+		1: {ID: 1, Name: "Desk Lamp", Category: "Lighting"},
+		2: {ID: 2, Name: "Floor Lamp", Category: "Lighting"},
+		3: {ID: 3, Name: "Office Chair", Category: "Office"},
+	}}
+	products := newProductService(repo)
+
+	tests := []struct {
+		query string
+		total int64
+	}{
+		{"", 3},
+		{"?category=Lighting", 2},
+		{"?category=Office,Garden", 1},
+		{"?category=Garden", 0},
+	}
+	for _, tt := range tests {
+		query := tt.query
+		var count CountResponse
+		if status := getJSON(t, CountProducts(products), "/products"+query, &count); status != http.StatusOK {
+			t.Fatalf("%q: got status %d, want %d", query, status, http.StatusOK)
+		}
+		var list struct {
+			Total int64 `json:"total"`
+		}
+		getJSON(t, GetAllProducts(products), "/products"+query, &list)
+
+		if count.Total != tt.total || list.Total != tt.total {
+			t.Errorf("%q: got count %d and list total %d, want %d for both", query, count.Total, list.Total, tt.total)
+		}
+	}
+}
+
+func TestCountProductsRejectsInvalidFilters(t *testing.T) {
+	var response ErrorResponse
+	status := getJSON(t, CountProducts(newProductService(&stubProductRepo{})), "/products?created_from=yesterday", &response)
+
+	if status != http.StatusBadRequest || response.Code != "INVALID_FILTER" {
+		t.Errorf("got %d %+v, want 400 with code INVALID_FILTER", status, response)
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// deleteProduct sends a DELETE for product 7 to the DeleteProduct handler as an admin or not,
+// confirming the deletion if confirm is set
+func deleteProduct(repo *stubProductRepo, query string, admin, confirm bool) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.DELETE("/products/:id", func(c *gin.Context) {
+		c.Set(IsAdminKey, admin)
+	}, DeleteProduct(newProductService(repo)))
+
+	request := httptest.NewRequest(http.MethodDelete, "/products/7"+query, nil)
+	if confirm {
+		request.Header.Set(ConfirmDeleteHeader, "true")
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestPermanentDeleteIsAdminOnlyAndConfirmed(t *testing.T) {
+	tests := []struct {
+		name    string
+		admin   bool
+		confirm bool
+		status  int
+		code    string
+	}{
+		{"not an admin", false, true, http.StatusForbidden, "ADMIN_REQUIRED"},
+		{"unconfirmed", true, false, http.StatusBadRequest, "CONFIRMATION_REQUIRED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubProductRepo{products: map[uint]*entity.Product{7: {ID: 7, Name: "Desk Lamp"}}}
+			recorder := deleteProduct(repo, "?permanent=true", tt.admin, tt.confirm)
+
+			var response ErrorResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decode %q: %v", recorder.Body, err)
+			}
+			if recorder.Code != tt.status || response.Code != tt.code {
+				t.Errorf("got %d %+v, want %d with code %q", recorder.Code, response, tt.status, tt.code)
+			}
+			if _, ok := repo.products[7]; !ok {
+				t.Error("the product was deleted")
+			}
+		})
+	}
+}
+
+func TestPermanentDeleteRemovesTheProduct(t *testing.T) {
+	repo := &stubProductRepo{products: map[uint]*entity.Product{7: {ID: 7, Name: "Desk Lamp"}}}
+
+	if recorder := deleteProduct(repo, "?permanent=true", true, true); recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+	if _, ok := repo.products[7]; ok {
+		t.Error("the product is still stored")
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseProductFilterDateRanges(t *testing.T) {
+	c, _ := testContext("/products?created_from=2026-01-01T00:00:00Z&created_to=2026-01-31T23:59:59Z&updated_from=2026-01-15T12:00:00%2B02:00")
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		t.Fatalf("parseProductFilter: %v", err)
+	}
+
+	if filter.CreatedFrom == nil || !filter.CreatedFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got created_from %v, want 2026-01-01T00:00:00Z", filter.CreatedFrom)
+	}
+	if filter.CreatedTo == nil || !filter.CreatedTo.Equal(time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("got created_to %v, want 2026-01-31T23:59:59Z", filter.CreatedTo)
+	}
+	if filter.UpdatedFrom == nil || !filter.UpdatedFrom.Equal(time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("got updated_from %v, want 2026-01-15T10:00:00Z", filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		t.Errorf("got updated_to %v, want an open end", filter.UpdatedTo)
+	}
+}
+
+func TestParseProductFilterRejectsInvalidDateRanges(t *testing.T) {
+	for _, query := range []string{
+		"?created_from=yesterday",
+		"?updated_to=2026-01-01",
+		"?created_from=2026-02-01T00:00:00Z&created_to=2026-01-01T00:00:00Z",
+	} {
+		c, _ := testContext("/products" + query)
+		if _, err := parseProductFilter(c); err == nil {
+			t.Errorf("%q: parseProductFilter succeeded, want an error", query)
+		}
+	}
+}
+
+func TestParseProductFilterAcceptsDateAliases(t *testing.T) {
+	c, _ := testContext("/products?created_after=2026-01-01T00:00:00Z&created_before=2026-01-31T00:00:00Z&updated_after=2026-01-10T00:00:00Z&updated_before=2026-01-20T00:00:00Z")
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		t.Fatalf("parseProductFilter: %v", err)
+	}
+
+	want := map[string]struct {
+		got  *time.Time
+		want time.Time
+	}{
+		"created_after":  {filter.CreatedFrom, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"created_before": {filter.CreatedTo, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+		"updated_after":  {filter.UpdatedFrom, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)},
+		"updated_before": {filter.UpdatedTo, time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)},
+	}
+	for alias, bound := range want {
+		if bound.got == nil || !bound.got.Equal(bound.want) {
+			t.Errorf("%s: got %v, want %v", alias, bound.got, bound.want)
+		}
+	}
+}
+
+func TestParseProductFilterPrefersTheCanonicalDateName(t *testing.T) {
+	c, _ := testContext("/products?created_from=2026-01-01T00:00:00Z&created_after=2025-01-01T00:00:00Z")
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		t.Fatalf("parseProductFilter: %v", err)
+	}
+	if filter.CreatedFrom == nil || filter.CreatedFrom.Year() != 2026 {
+		t.Errorf("got created_from %v, want the created_from value", filter.CreatedFrom)
+	}
+
+	// An alias is validated like the name it stands for
+	c, _ = testContext("/products?created_after=2026-02-01T00:00:00Z&created_before=2026-01-01T00:00:00Z")
+	if _, err := parseProductFilter(c); err == nil {
+		t.Error("an inverted aliased range was accepted, want an error")
+	}
+}
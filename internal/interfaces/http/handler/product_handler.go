@@ -1,54 +1,496 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/interfaces/productcsv"
 	"github.com/product-management/internal/usecase"
 )
 
-// GetAllProducts handles getting all products
+// importRetryAfterSeconds is the Retry-After hint sent when the import limit is reached
+const importRetryAfterSeconds = 30
+
+// GetAllProducts handles getting a paginated list of products. Pages carry an ETag hashed
+// from their contents, so polling clients can revalidate with If-None-Match.
 func GetAllProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get query parameters
-		category := c.Query("category")
-		limitStr := c.DefaultQuery("limit", "10")
-		offsetStr := c.DefaultQuery("offset", "0")
+		filter, err := parseProductFilter(c)
+		if err != nil {
+			respondInvalidFilter(c, err)
+			return
+		}
+		page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+		if err != nil {
+			respondInvalidPagination(c, err)
+			return
+		}
 
-		limit, err := strconv.Atoi(limitStr)
+		// A cursor parameter (even empty, for the first page) selects keyset pagination
+		if cursor, ok := c.GetQuery("cursor"); ok {
+			response, err := productService.GetProductsCursor(c.Request.Context(), filter, cursor, pageSize)
+			if err != nil {
+				handleError(c, err)
+				return
+			}
+
+			respondJSON(c, http.StatusOK, response)
+			return
+		}
+
+		response, err := productService.GetProducts(c.Request.Context(), filter, page, pageSize)
 		if err != nil {
-			limit = 10
+			handleError(c, err)
+			return
 		}
 
-		offset, err := strconv.Atoi(offsetStr)
+		setPaginationHeaders(c, response.PageInfo)
+		respondConditionalJSON(c, response, time.Time{})
+	}
+}
+
+// CountProducts handles counting the products that match the list filters, without
+// fetching them
+func CountProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, err := parseProductFilter(c)
 		if err != nil {
-			offset = 0
+			respondInvalidFilter(c, err)
+			return
 		}
 
-		products, err := productService.GetAllProducts(category, limit, offset)
+		total, err := productService.CountProducts(c.Request.Context(), filter)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handleError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, products)
+		c.JSON(http.StatusOK, CountResponse{Total: total})
 	}
 }
 
-// GetProduct handles getting a single product
+// GetProductStats handles summarizing the products that match the list filters: counts,
+// inventory value, average price and per-category counts
+func GetProductStats(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, err := parseProductFilter(c)
+		if err != nil {
+			respondInvalidFilter(c, err)
+			return
+		}
+
+		stats, err := productService.GetStats(c.Request.Context(), filter)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, stats)
+	}
+}
+
+// SearchProducts handles searching products by name or description.
+//
+// A query shorter than the minimum length gets 400 with code QUERY_TOO_SHORT, while a
+// valid query without matches gets 200 with total 0 and a list of suggestions. Results are
+// ordered by relevance: relevance=text uses the plain full-text rank instead of the default
+// weighted score, and min_score drops matches scoring below it.
+func SearchProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+		if err != nil {
+			respondInvalidPagination(c, err)
+			return
+		}
+
+		// "search" is accepted as an alias so clients using the list filter's name keep working
+		query := c.Query("q")
+		if query == "" {
+			query = c.Query("search")
+		}
+
+		opts := service.SearchOptions{Relevance: c.Query("relevance")}
+		if raw := c.Query("min_score"); raw != "" {
+			minScore, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				respondInvalidFilter(c, errors.New("invalid min_score: must be a number"))
+				return
+			}
+			opts.MinScore = &minScore
+		}
+
+		response, err := productService.SearchProductsWithOptions(c.Request.Context(), query, opts, page, pageSize)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		setPaginationHeaders(c, response.PageInfo)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// GetLowStockProducts handles listing active products whose stock is at or below their
+// low stock threshold, lowest stock first
+func GetLowStockProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+		if err != nil {
+			respondInvalidPagination(c, err)
+			return
+		}
+
+		response, err := productService.GetLowStockProducts(c.Request.Context(), page, pageSize)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		setPaginationHeaders(c, response.PageInfo)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ReportBrokenImage handles a client reporting that a product's image fails to load
+func ReportBrokenImage(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		if err := productService.ReportBrokenImage(requestContext(c), id); err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"message": "Broken image reported"})
+	}
+}
+
+// GetImageReports handles listing products whose image has been reported broken, those
+// flagged for review first
+func GetImageReports(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+		if err != nil {
+			respondInvalidPagination(c, err)
+			return
+		}
+
+		response, err := productService.GetImageReports(c.Request.Context(), page, pageSize)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		setPaginationHeaders(c, response.PageInfo)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ClearImageReports handles resetting a product's broken image reports after review
+func ClearImageReports(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		if err := productService.ClearImageReports(requestContext(c), id); err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ExportProducts handles streaming products matching the list filters as a CSV attachment.
+// Rows are in ID order, so an interrupted export can be resumed with since_id set to the
+// last ID received, and limit splits an export into chunks. By default the export reflects
+// writes committed while it runs; consistent=true reads it from a single snapshot instead.
+func ExportProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, err := parseProductFilter(c)
+		if err != nil {
+			respondInvalidFilter(c, err)
+			return
+		}
+		// Exports are always streamed in ID order
+		filter.OrderBy, filter.OrderDir = "", ""
+
+		opts := service.ExportOptions{Consistent: c.Query("consistent") == "true"}
+		if raw, ok := c.GetQuery("since_id"); ok {
+			sinceID, ok := parseID(raw)
+			if !ok {
+				RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid since_id: must be a positive integer")
+				return
+			}
+			opts.SinceID = sinceID
+		}
+		if raw, ok := c.GetQuery("limit"); ok {
+			limit, err := strconv.Atoi(raw)
+			if err != nil || limit <= 0 {
+				RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "Invalid limit: must be a positive integer")
+				return
+			}
+			opts.Limit = limit
+		}
+
+		filename := fmt.Sprintf("products-%s.csv", time.Now().UTC().Format("20060102-150405"))
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		w := productcsv.NewWriter(c.Writer)
+		if err := w.WriteHeader(); err != nil {
+			handleError(c, err)
+			return
+		}
+
+		err = productService.ExportProducts(c.Request.Context(), filter, opts, func(p *entity.Product) error {
+			return w.Write(p)
+		})
+		if flushErr := w.Flush(); err == nil {
+			err = flushErr
+		}
+		if err != nil {
+			// Headers and part of the body are already sent, so the response can't be turned into an error
+			GetLogger(c).ErrorContext(c.Request.Context(), "Product export aborted", slog.Any("error", err))
+			c.Abort()
+		}
+	}
+}
+
+// ExportCategoryPriceSheet handles streaming the sku, name, price and stock of the active
+// products in a category as a CSV attachment named after the category. A category without
+// active products gets 404 when emptyNotFound is set, and a sheet with only the header otherwise.
+func ExportCategoryPriceSheet(productService *usecase.ProductUseCase, emptyNotFound bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if format := c.DefaultQuery("format", "csv"); format != "csv" {
+			RespondError(c, http.StatusBadRequest, "UNSUPPORTED_FORMAT", fmt.Sprintf("Unsupported price sheet format %q: only csv is available", format))
+			return
+		}
+
+		category := c.Param("category")
+		active := true
+		filter := &repository.ProductFilter{Categories: []string{category}, IsActive: &active}
+
+		filename := fmt.Sprintf("price-sheet-%s-%s.csv", filenameSafe(category), time.Now().UTC().Format("20060102"))
+		w := productcsv.NewPriceSheetWriter(c.Writer)
+
+		// Headers are only sent with the first product, so an empty category can still get a 404
+		started := false
+		start := func() error {
+			started = true
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+			return w.WriteHeader()
+		}
+
+		err := productService.ExportProducts(c.Request.Context(), filter, service.ExportOptions{}, func(p *entity.Product) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+			return w.Write(p)
+		})
+		if err != nil && !started {
+			handleError(c, err)
+			return
+		}
+		if err == nil && !started {
+			if emptyNotFound {
+				RespondError(c, http.StatusNotFound, "CATEGORY_HAS_NO_PRODUCTS", fmt.Sprintf("No active products in category %q", category))
+				return
+			}
+			err = start()
+		}
+		if flushErr := w.Flush(); err == nil {
+			err = flushErr
+		}
+		if err != nil {
+			// Headers and part of the body are already sent, so the response can't be turned into an error
+			GetLogger(c).ErrorContext(c.Request.Context(), "Price sheet export aborted", slog.Any("error", err))
+			c.Abort()
+		}
+	}
+}
+
+// filenameSafe reduces s to letters, digits and dashes for use in a download filename
+func filenameSafe(s string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, s)
+	safe = strings.Trim(safe, "-")
+	if safe == "" {
+		return "category"
+	}
+	return safe
+}
+
+// ImportProducts handles creating or updating products by name from a multipart CSV upload.
+// With async=true the import runs in the background and a job to poll is returned instead.
+func ImportProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			if respondIfBodyTooLarge(c, err) {
+				return
+			}
+			RespondError(c, http.StatusBadRequest, "FILE_REQUIRED", "A CSV file is required in the \"file\" form field")
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		defer file.Close()
+
+		reader, err := productcsv.NewReader(file)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		rows, rowErrors := reader.ReadAll()
+
+		if c.Query("async") == "true" {
+			job, err := productService.StartImportJob(requestContext(c), rows, rowErrors)
+			if err != nil {
+				handleError(c, err)
+				return
+			}
+			c.JSON(http.StatusAccepted, job)
+			return
+		}
+
+		result, err := productService.ImportProducts(requestContext(c), rows)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		// Rows that couldn't be parsed count as skipped alongside those rejected by the use case
+		result.AddRowErrors(rowErrors)
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetImportJob handles polling the status of a background product import
+func GetImportJob(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, err := productService.GetImportJob(c.Request.Context(), c.Param("job_id"))
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// ImportProductPrices handles updating prices of existing products from a "sku,price" or
+// "id,price" CSV upload.
+// Pass dry_run=true to see what would change without writing anything.
+func ImportProductPrices(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			if respondIfBodyTooLarge(c, err) {
+				return
+			}
+			RespondError(c, http.StatusBadRequest, "FILE_REQUIRED", "A CSV file is required in the \"file\" form field")
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		defer file.Close()
+
+		rows, rowErrors, err := productcsv.ReadPriceUpdates(file)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		result, err := productService.ImportPrices(requestContext(c), rows, c.Query("dry_run") == "true")
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		// Rows that couldn't be parsed are reported as invalid alongside the use case's results
+		for _, rowErr := range rowErrors {
+			result.Invalid++
+			result.Rows = append(result.Rows, service.PriceUpdateResult{
+				Line:   rowErr.Line,
+				Status: service.PriceUpdateStatusInvalid,
+				Reason: rowErr.Reason,
+			})
+		}
+		sort.SliceStable(result.Rows, func(i, j int) bool {
+			return result.Rows[i].Line < result.Rows[j].Line
+		})
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// ListActiveImports handles listing the imports that are currently running
+func ListActiveImports(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"imports": productService.ListActiveImports(c.Request.Context())})
+	}
+}
+
+// GetProduct handles getting a single product, answering 304 Not Modified when the client's
+// If-None-Match or If-Modified-Since shows it already has the current version
 func GetProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		idStr := c.Param("id")
-		id, err := strconv.ParseUint(idStr, 10, 32)
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		product, err := productService.GetProduct(requestContext(c), id)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			handleError(c, err)
 			return
 		}
 
-		product, err := productService.GetProduct(uint(id))
+		respondConditionalJSON(c, product, product.UpdatedAt)
+	}
+}
+
+// GetProductBySKU handles looking up a product by its SKU, for integrations keyed by SKU
+func GetProductBySKU(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		product, err := productService.GetProductBySKU(c.Request.Context(), c.Param("sku"))
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+			handleError(c, err)
 			return
 		}
 
@@ -61,13 +503,13 @@ func CreateProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req usecase.CreateProductRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			handleBindError(c, err)
 			return
 		}
 
-		product, err := productService.CreateProduct(&req)
+		product, err := productService.CreateProduct(requestContext(c), &req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handleError(c, err)
 			return
 		}
 
@@ -75,25 +517,87 @@ func CreateProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	}
 }
 
-// UpdateProduct handles updating an existing product
-func UpdateProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
+// BulkCreateProducts handles creating several products in one transaction
+func BulkCreateProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		idStr := c.Param("id")
-		id, err := strconv.ParseUint(idStr, 10, 32)
+		var req BulkCreateProductsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if respondIfBodyTooLarge(c, err) {
+				return
+			}
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		results, err := productService.BulkCreateProducts(requestContext(c), req.Products, req.ContinueOnError)
+		if err != nil && !errors.Is(err, entity.ErrValidationFailed) {
+			handleError(c, err)
+			return
+		}
+
+		response := BulkCreateProductsResponse{Results: results}
+		for _, result := range results {
+			if result.Product != nil {
+				response.Created++
+			} else {
+				response.Failed++
+			}
+		}
+
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			c.JSON(http.StatusUnprocessableEntity, response)
+			return
+		}
+
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+// ValidateProduct handles validating a create payload without persisting it
+func ValidateProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req usecase.CreateProductRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		if err := productService.ValidateProduct(requestContext(c), &req); err != nil {
+			validationErr, ok := asValidationError(err)
+			if !ok {
+				handleError(c, err)
+				return
+			}
+
+			c.JSON(http.StatusUnprocessableEntity, ValidationResponse{
+				Valid:  false,
+				Errors: validationErr.Violations,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, ValidationResponse{Valid: true})
+	}
+}
+
+// UpdateProduct handles replacing a product with the full representation in the body. A body
+// missing a required field is rejected with 422; use PatchProduct to change only some fields.
+func UpdateProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
 			return
 		}
 
 		var req usecase.UpdateProductRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			handleBindError(c, err)
 			return
 		}
 
-		product, err := productService.UpdateProduct(uint(id), &req)
+		product, err := productService.UpdateProduct(requestContext(c), id, &req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			handleError(c, err)
 			return
 		}
 
@@ -101,32 +605,279 @@ func UpdateProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	}
 }
 
-// DeleteProduct handles deleting a product
+// PatchProduct handles partially updating a product. Absent fields are left unchanged and
+// null clears description, category or image_url.
+func PatchProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		var req service.ProductPatchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		product, err := productService.PatchProduct(requestContext(c), id, &req)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, product)
+	}
+}
+
+// DeleteProduct handles deleting a product. Products are soft-deleted unless permanent=true,
+// which is admin only and must be confirmed with the X-Confirm-Delete header.
 func DeleteProduct(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		idStr := c.Param("id")
-		id, err := strconv.ParseUint(idStr, 10, 32)
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		deleteProduct, message := productService.DeleteProduct, "Product deleted successfully"
+		if c.Query("permanent") == "true" {
+			if !IsAdmin(c) {
+				RespondError(c, http.StatusForbidden, "ADMIN_REQUIRED", "Admin privileges required to permanently delete a product")
+				return
+			}
+			if c.GetHeader(ConfirmDeleteHeader) != "true" {
+				RespondError(c, http.StatusBadRequest, "CONFIRMATION_REQUIRED", "Permanent deletion must be confirmed with the "+ConfirmDeleteHeader+": true header")
+				return
+			}
+			deleteProduct, message = productService.HardDeleteProduct, "Product permanently deleted"
+		}
+
+		force := c.Query("force") == "true"
+		if err := deleteProduct(requestContext(c), id, force); err != nil {
+			var referencedErr *entity.ProductReferencedError
+			if errors.As(err, &referencedErr) {
+				c.JSON(http.StatusConflict, ProductReferencesResponse{
+					ProductID:  id,
+					Total:      referencedErr.References.Total(),
+					References: referencedErr.References,
+				})
+				return
+			}
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": message})
+	}
+}
+
+// ListDeletedProducts handles listing soft-deleted products with when they were deleted (admin only)
+func ListDeletedProducts(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+		if err != nil {
+			respondInvalidPagination(c, err)
+			return
+		}
+
+		response, err := productService.ListDeletedProducts(c.Request.Context(), page, pageSize)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		setPaginationHeaders(c, response.PageInfo)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// GetProductReferences handles listing counts of records that reference a product
+func GetProductReferences(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		references, err := productService.GetProductReferences(requestContext(c), id)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, ProductReferencesResponse{
+			ProductID:  id,
+			Total:      references.Total(),
+			References: references,
+		})
+	}
+}
+
+// GetProductHistory handles listing the audit trail of a product
+func GetProductHistory(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		history, err := productService.GetProductHistory(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			handleError(c, err)
 			return
 		}
 
-		if err := productService.DeleteProduct(uint(id)); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusOK, gin.H{"product_id": id, "history": history})
+	}
+}
+
+// GetProductPriceHistory handles listing a product's price changes, oldest first
+func GetProductPriceHistory(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully"})
+		history, err := productService.GetPriceHistory(c.Request.Context(), id)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"product_id": id, "price_history": history})
+	}
+}
+
+// GetProductReservations handles listing a product's unexpired stock reservations, soonest
+// to expire first, with the total quantity they hold
+func GetProductReservations(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		reservations, err := productService.GetProductReservations(c.Request.Context(), id)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, reservations)
+	}
+}
+
+// ReserveProductStock handles holding back part of a product's stock for a cart until the
+// reservation expires
+func ReserveProductStock(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		var req service.ReserveStockRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		reservation, err := productService.ReserveStock(requestContext(c), id, &req)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, reservation)
+	}
+}
+
+// GetProductDiff handles reporting how a product changed between the from and to RFC3339
+// timestamps, reconstructed from its audit trail; to defaults to now
+func GetProductDiff(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		from, to, err := parseDateRange(c, "from", "to")
+		if err != nil {
+			respondInvalidFilter(c, err)
+			return
+		}
+		if from == nil {
+			respondInvalidFilter(c, errors.New("from is required"))
+			return
+		}
+		if to == nil {
+			now := time.Now()
+			if from.After(now) {
+				respondInvalidFilter(c, errors.New("invalid from: must not be in the future"))
+				return
+			}
+			to = &now
+		}
+
+		diff, err := productService.GetProductDiff(c.Request.Context(), id, *from, *to)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
+// BulkUpdateProductStatus handles activating or deactivating several products at once
+func BulkUpdateProductStatus(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req BulkUpdateStatusRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		result, err := productService.BulkUpdateProductStatus(requestContext(c), req.ProductIDs, *req.IsActive)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetProductsBatch handles getting up to maxIDs products in one request, e.g. to render a
+// cart. Unknown IDs are listed in not_found rather than failing the request.
+func GetProductsBatch(productService *usecase.ProductUseCase, maxIDs int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ProductBatchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+		if len(req.IDs) > maxIDs {
+			handleError(c, fmt.Errorf("%w: at most %d product IDs can be requested at once", entity.ErrInvalidInput, maxIDs))
+			return
+		}
+
+		result, err := productService.GetProductsByIDs(c.Request.Context(), req.IDs)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
 	}
 }
 
 // UpdateProductStock handles updating product stock
 func UpdateProductStock(productService *usecase.ProductUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		idStr := c.Param("id")
-		id, err := strconv.ParseUint(idStr, 10, 32)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
 			return
 		}
 
@@ -134,15 +885,154 @@ func UpdateProductStock(productService *usecase.ProductUseCase) gin.HandlerFunc
 			Quantity int `json:"quantity" binding:"required"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			if respondIfBodyTooLarge(c, err) {
+				return
+			}
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		if err := productService.UpdateStock(requestContext(c), id, req.Quantity); err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Stock updated successfully"})
+	}
+}
+
+// DecrementProductStock handles atomically removing units from a product's stock
+func DecrementProductStock(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return adjustProductStock(productService.DecrementStock)
+}
+
+// IncrementProductStock handles atomically adding units to a product's stock
+func IncrementProductStock(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return adjustProductStock(productService.IncrementStock)
+}
+
+// adjustProductStock builds a handler applying a relative stock change
+func adjustProductStock(adjust func(ctx context.Context, id uint, qty int) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		var req StockAdjustmentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if respondIfBodyTooLarge(c, err) {
+				return
+			}
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
 			return
 		}
 
-		if err := productService.UpdateStock(uint(id), req.Quantity); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if err := adjust(requestContext(c), id, req.Quantity); err != nil {
+			handleError(c, err)
 			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{"message": "Stock updated successfully"})
 	}
 }
+
+// parseProductFilter builds a product filter from the list query parameters. It fails when
+// a created_/updated_ date bound isn't RFC3339 or a range's from is after its to.
+func parseProductFilter(c *gin.Context) (*repository.ProductFilter, error) {
+	filter := &repository.ProductFilter{
+		Categories: parseListQuery(c, "category"),
+		Tags:       parseTagsQuery(c, "tags"),
+		TagMatch:   c.DefaultQuery("tag_match", repository.TagMatchAll),
+		SearchTerm: c.Query("search"),
+		OrderBy:    c.Query("order_by"),
+		OrderDir:   c.Query("order_dir"),
+	}
+	if filter.TagMatch != repository.TagMatchAll && filter.TagMatch != repository.TagMatchAny {
+		return nil, fmt.Errorf("invalid tag_match: must be %q or %q", repository.TagMatchAll, repository.TagMatchAny)
+	}
+
+	var err error
+	// created_after/created_before (and updated_*) are accepted as aliases of the _from/_to names
+	createdFrom, createdTo := queryParamOrAlias(c, "created_from", "created_after"), queryParamOrAlias(c, "created_to", "created_before")
+	if filter.CreatedFrom, filter.CreatedTo, err = parseDateRange(c, createdFrom, createdTo); err != nil {
+		return nil, err
+	}
+	updatedFrom, updatedTo := queryParamOrAlias(c, "updated_from", "updated_after"), queryParamOrAlias(c, "updated_to", "updated_before")
+	if filter.UpdatedFrom, filter.UpdatedTo, err = parseDateRange(c, updatedFrom, updatedTo); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseDateRange parses an optional pair of RFC3339 query parameters bounding a date range
+func parseDateRange(c *gin.Context, fromParam, toParam string) (from, to *time.Time, err error) {
+	if from, err = parseTimeQuery(c, fromParam); err != nil {
+		return nil, nil, err
+	}
+	if to, err = parseTimeQuery(c, toParam); err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return nil, nil, fmt.Errorf("invalid %s: must not be after %s", fromParam, toParam)
+	}
+	return from, to, nil
+}
+
+// parseTimeQuery parses an optional RFC3339 query parameter, returning nil when it is absent
+func parseTimeQuery(c *gin.Context, param string) (*time.Time, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be an RFC3339 timestamp", param)
+	}
+	return &t, nil
+}
+
+// respondInvalidFilter writes a 400 response for query parameters that failed to parse
+func respondInvalidFilter(c *gin.Context, err error) {
+	RespondError(c, http.StatusBadRequest, "INVALID_FILTER", err.Error())
+}
+
+// handleError handles different types of product errors
+func handleError(c *gin.Context, err error) {
+	if respondIfReadOnly(c, err) {
+		return
+	}
+	if respondIfCanceled(c, err) {
+		return
+	}
+	if validationErr, ok := asValidationError(err); ok {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, entity.ErrProductNotFound), errors.Is(err, entity.ErrImportJobNotFound),
+		errors.Is(err, entity.ErrProductImageNotFound):
+		respondDomainError(c, http.StatusNotFound, err)
+	case errors.Is(err, entity.ErrProductAlreadyExists), errors.Is(err, entity.ErrProductSKUExists),
+		errors.Is(err, entity.ErrProductHasReferences),
+		errors.Is(err, entity.ErrInsufficientStock), errors.Is(err, entity.ErrConcurrentModification),
+		errors.Is(err, entity.ErrSearchReindexRunning):
+		respondDomainError(c, http.StatusConflict, err)
+	case errors.Is(err, entity.ErrTooManyImports):
+		c.Header("Retry-After", strconv.Itoa(importRetryAfterSeconds))
+		RespondError(c, http.StatusTooManyRequests, errorCode(err), "Another import is already running, please retry later")
+	case errors.Is(err, entity.ErrSearchQueryTooShort):
+		RespondError(c, http.StatusBadRequest, errorCode(err), fmt.Sprintf("Search query must be at least %d characters", service.MinSearchQueryLength))
+	case errors.Is(err, entity.ErrSearchTooDeep):
+		respondDomainError(c, http.StatusBadRequest, err)
+	case errors.Is(err, entity.ErrProductNameRequired), errors.Is(err, entity.ErrProductNameTooShort),
+		errors.Is(err, entity.ErrProductNameTooLong), errors.Is(err, entity.ErrProductPriceInvalid),
+		errors.Is(err, entity.ErrProductStockInvalid), errors.Is(err, entity.ErrLowStockThresholdInvalid),
+		errors.Is(err, entity.ErrInvalidInput),
+		errors.Is(err, entity.ErrValidationFailed):
+		respondDomainError(c, http.StatusBadRequest, err)
+	default:
+		respondInternalError(c, err)
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// getProduct requests product id from the GetProduct handler with the given headers
+func getProduct(repo *stubProductRepo, id string, headers map[string]string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.GET("/products/:id", GetProduct(newProductService(repo)))
+
+	request := httptest.NewRequest(http.MethodGet, "/products/"+id, nil)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGetProductStatusFollowsTheError(t *testing.T) {
+	tests := []struct {
+		name   string
+		repo   *stubProductRepo
+		status int
+	}{
+		{"missing product", &stubProductRepo{}, http.StatusNotFound},
+		{"database failure", &stubProductRepo{err: errors.New("connection refused")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getProduct(tt.repo, "7", nil).Code; got != tt.status {
+				t.Errorf("got status %d, want %d", got, tt.status)
+			}
+		})
+	}
+}
+
+func TestGetProductIfNoneMatch(t *testing.T) {
+	repo := &stubProductRepo{products: map[uint]*entity.Product{
+		7: {ID: 7, Name: "Desk Lamp", Price: 10, UpdatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}}
+
+	first := getProduct(repo, "7", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("got status %d and ETag %q, want 200 with an ETag", first.Code, etag)
+	}
+
+	second := getProduct(repo, "7", map[string]string{"If-None-Match": etag})
+	if second.Code != http.StatusNotModified || second.Body.Len() != 0 {
+		t.Errorf("got status %d with a %d byte body, want an empty 304", second.Code, second.Body.Len())
+	}
+
+	repo.products[7].Price = 12
+	if third := getProduct(repo, "7", map[string]string{"If-None-Match": etag}); third.Code != http.StatusOK {
+		t.Errorf("got status %d after the product changed, want 200", third.Code)
+	}
+}
+
+func TestUpdateProductStockStatusFollowsTheError(t *testing.T) {
+	tests := []struct {
+		name   string
+		repo   *stubProductRepo
+		body   string
+		status int
+	}{
+		{"missing product", &stubProductRepo{}, `{"quantity": 5}`, http.StatusNotFound},
+		{"negative quantity", &stubProductRepo{}, `{"quantity": -1}`, http.StatusUnprocessableEntity},
+		{"database failure", &stubProductRepo{err: errors.New("connection refused")}, `{"quantity": 5}`, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.PATCH("/products/:id/stock", UpdateProductStock(newProductService(tt.repo)))
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPatch, "/products/7/stock", strings.NewReader(tt.body)))
+			if recorder.Code != tt.status {
+				t.Errorf("got status %d, want %d: %s", recorder.Code, tt.status, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestDecrementProductStock(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		status    int
+		code      string
+		wantStock int
+	}{
+		{"in stock", `{"quantity": 2}`, http.StatusOK, "", 1},
+		{"more than in stock", `{"quantity": 4}`, http.StatusConflict, "INSUFFICIENT_STOCK", 3},
+		{"non-positive quantity", `{"quantity": 0}`, http.StatusBadRequest, "INVALID_REQUEST", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubProductRepo{products: map[uint]*entity.Product{7: {ID: 7, Name: "Desk Lamp", Stock: 3}}}
+			router := gin.New()
+			router.POST("/products/:id/stock/decrement", DecrementProductStock(newProductService(repo)))
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/products/7/stock/decrement", strings.NewReader(tt.body)))
+
+			var response ErrorResponse
+			_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+			if recorder.Code != tt.status || response.Code != tt.code {
+				t.Errorf("got %d with code %q, want %d with code %q", recorder.Code, response.Code, tt.status, tt.code)
+			}
+			if stock := repo.products[7].Stock; stock != tt.wantStock {
+				t.Errorf("got stock %d, want %d", stock, tt.wantStock)
+			}
+		})
+	}
+}
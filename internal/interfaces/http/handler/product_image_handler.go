@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/usecase"
+)
+
+// AddProductImage handles adding an image to a product's gallery
+func AddProductImage(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		var req service.AddProductImageRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		image, err := productService.AddProductImage(requestContext(c), id, &req)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, image)
+	}
+}
+
+// DeleteProductImage handles removing an image from a product's gallery
+func DeleteProductImage(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+		imageID, ok := parseIDParam(c, "image_id", "image")
+		if !ok {
+			return
+		}
+
+		if err := productService.DeleteProductImage(requestContext(c), id, imageID); err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Product image deleted successfully"})
+	}
+}
+
+// ReorderProductImages handles changing the display order and primary image of a product's gallery
+func ReorderProductImages(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		var req service.ReorderProductImagesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		images, err := productService.ReorderProductImages(requestContext(c), id, &req)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"images": images})
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/usecase"
+)
+
+// SetProductTags handles replacing a product's tags, used by ?tags= filtering of the product list
+func SetProductTags(productService *usecase.ProductUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "product")
+		if !ok {
+			return
+		}
+
+		var req service.SetProductTagsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		product, err := productService.SetProductTags(requestContext(c), id, &req)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, product)
+	}
+}
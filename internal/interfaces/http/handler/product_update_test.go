@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// newDeskLampRepo returns a repository holding a fully described desk lamp as product 7, at version 1
+func newDeskLampRepo() *stubProductRepo {
+	sku := "LAMP-1"
+	return &stubProductRepo{products: map[uint]*entity.Product{
+		7: {ID: 7, Name: "Desk Lamp", SKU: &sku, Description: "Warm light", Category: "Lighting", Price: 10, Stock: 4,
+			LowStockThreshold: 2, ImageURL: "https://example.com/lamp.png", IsActive: true, Version: 1},
+	}}
+}
+
+// sendProduct sends body to product 7 with method through the UpdateProduct and PatchProduct handlers
+func sendProduct(repo *stubProductRepo, method, body string) *httptest.ResponseRecorder {
+	products := newProductService(repo)
+	router := gin.New()
+	router.PUT("/products/:id", UpdateProduct(products))
+	router.PATCH("/products/:id", PatchProduct(products))
+
+	request := httptest.NewRequest(method, "/products/7", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestPutRequiresEveryRequiredField(t *testing.T) {
+	tests := []struct {
+		name, body, field string
+	}{
+		{"no version", `{"name": "Desk Lamp", "price": 10, "stock": 4}`, "version"},
+		{"no name", `{"version": 1, "price": 10, "stock": 4}`, "name"},
+		{"no price", `{"version": 1, "name": "Desk Lamp", "stock": 4}`, "price"},
+		{"no stock", `{"version": 1, "name": "Desk Lamp", "price": 10}`, "stock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newDeskLampRepo()
+			recorder := sendProduct(repo, http.MethodPut, tt.body)
+
+			if recorder.Code != http.StatusUnprocessableEntity || !strings.Contains(recorder.Body.String(), `"`+tt.field+`"`) {
+				t.Errorf("got %d %s, want 422 naming %s", recorder.Code, recorder.Body, tt.field)
+			}
+			if repo.products[7].Version != 1 {
+				t.Error("the product was saved")
+			}
+		})
+	}
+}
+
+func TestPutReplacesTheProduct(t *testing.T) {
+	repo := newDeskLampRepo()
+	recorder := sendProduct(repo, http.MethodPut, `{"version": 1, "name": "Brass Desk Lamp", "price": 10, "stock": 3}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+
+	product := repo.products[7]
+	if product.Name != "Brass Desk Lamp" || product.Stock != 3 {
+		t.Errorf("got name %q and stock %d, want Brass Desk Lamp and 3", product.Name, product.Stock)
+	}
+	if product.SKU != nil || product.Description != "" || product.Category != "" || product.LowStockThreshold != entity.DefaultLowStockThreshold {
+		t.Errorf("got %+v, want the omitted optional fields cleared and the threshold reset", product)
+	}
+	if product.ImageURL == "" || !product.IsActive {
+		t.Errorf("got image_url %q and is_active %v, want both kept", product.ImageURL, product.IsActive)
+	}
+}
+
+func TestPatchLeavesAbsentFieldsUnchanged(t *testing.T) {
+	repo := newDeskLampRepo()
+	recorder := sendProduct(repo, http.MethodPatch, `{"version": 1, "name": "Brass Desk Lamp"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+
+	var product entity.Product
+	if err := json.Unmarshal(recorder.Body.Bytes(), &product); err != nil {
+		t.Fatalf("decode %q: %v", recorder.Body, err)
+	}
+	if product.Name != "Brass Desk Lamp" || product.Description != "Warm light" || product.Category != "Lighting" ||
+		product.Price != 10 || product.Stock != 4 || product.SKU == nil || product.LowStockThreshold != 2 {
+		t.Errorf("got %+v, want only the name changed", product)
+	}
+}
+
+func TestUpdatesBasedOnAStaleVersionAreRejected(t *testing.T) {
+	for _, method := range []string{http.MethodPut, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			repo := newDeskLampRepo()
+			body := `{"version": 1, "name": "Desk Lamp", "price": 10, "stock": 3}`
+
+			if first := sendProduct(repo, method, body); first.Code != http.StatusOK {
+				t.Fatalf("first update: got status %d, want 200: %s", first.Code, first.Body)
+			}
+			second := sendProduct(repo, method, strings.Replace(body, `"stock": 3`, `"stock": 9`, 1))
+
+			var response ErrorResponse
+			if err := json.Unmarshal(second.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decode %q: %v", second.Body, err)
+			}
+			if second.Code != http.StatusConflict || response.Code != "CONCURRENT_MODIFICATION" {
+				t.Errorf("second update: got %d %+v, want 409 with code CONCURRENT_MODIFICATION", second.Code, response)
+			}
+			if product := repo.products[7]; product.Stock != 3 || product.Version != 2 {
+				t.Errorf("got stock %d at version %d, want the first update's 3 at version 2", product.Stock, product.Version)
+			}
+		})
+	}
+}
+
+// racingProductRepo moves a product to its next version right after it is read, as if another
+// request saved it in the meantime
+type racingProductRepo struct {
+	*stubProductRepo
+}
+
+func (r *racingProductRepo) GetByID(ctx context.Context, id uint) (*entity.Product, error) {
+	product, err := r.stubProductRepo.GetByID(ctx, id)
+	if err == nil {
+		r.mu.Lock()
+		r.products[id].Version++
+		r.mu.Unlock()
+	}
+	return product, err
+}
+
+func TestUpdateRejectsChangesMadeSinceTheProductWasRead(t *testing.T) {
+	products := newProductService(&racingProductRepo{stubProductRepo: newDeskLampRepo()})
+	router := gin.New()
+	router.PUT("/products/:id", UpdateProduct(products))
+
+	request := httptest.NewRequest(http.MethodPut, "/products/7", strings.NewReader(`{"version": 1, "name": "Desk Lamp", "price": 10, "stock": 3}`))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("got status %d, want 409: %s", recorder.Code, recorder.Body)
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/usecase"
+)
+
+// GetPublicProducts handles the partner product feed: a paginated list of active products
+// reduced to their public fields, which clients and proxies may cache for cacheMaxAge
+func GetPublicProducts(productService *usecase.ProductUseCase, cacheMaxAge time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize, _, err := ParsePagination(c, DefaultPagination)
+		if err != nil {
+			respondInvalidPagination(c, err)
+			return
+		}
+
+		isActive := true
+		filter := &repository.ProductFilter{IsActive: &isActive}
+
+		response, err := productService.GetProducts(c.Request.Context(), filter, page, pageSize)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		products := make([]PublicProduct, 0, len(response.Products))
+		for _, product := range response.Products {
+			products = append(products, toPublicProduct(product))
+		}
+
+		setPaginationHeaders(c, response.PageInfo)
+		c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheMaxAge.Seconds())))
+		c.JSON(http.StatusOK, PublicProductListResponse{
+			Products: products,
+			PageInfo: response.PageInfo,
+		})
+	}
+}
+
+// toPublicProduct copies the fields of product that may be shown to partners
+func toPublicProduct(product *entity.Product) PublicProduct {
+	return PublicProduct{
+		ID:          product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Category:    product.Category,
+		ImageURL:    product.ImageURL,
+		InStock:     product.Stock > 0,
+		UpdatedAt:   product.UpdatedAt,
+	}
+}
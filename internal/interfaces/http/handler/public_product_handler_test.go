@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestGetPublicProductsServesOnlyPublicFieldsOfActiveProducts(t *testing.T) {
+	sku := "LAMP-1"
+	createdBy := uint(3)
+	rank := 0.5
+	repo := &stubProductRepo{products: map[uint]*entity.Product{
+		1: {ID: 1, Name: "Desk Lamp", SKU: &sku, Price: 25, Stock: 4, Reserved: 2, LowStockThreshold: 5, Category: "Lighting",
+			ImageReports: 1, NeedsReview: true, IsActive: true, CreatedBy: &createdBy, UpdatedBy: &createdBy, Version: 7,
+			Images: []entity.ProductImage{{URL: "https://example.com/lamp.png"}}, Rank: &rank},
+		2: {ID: 2, Name: "Retired Lamp", Price: 10, IsActive: false},
+	}}
+
+	router := gin.New()
+	router.GET("/public/products", GetPublicProducts(newProductService(repo), 5*time.Minute))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/public/products", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	if got := recorder.Header().Get("Cache-Control"); got != "public, max-age=300" {
+		t.Errorf("got Cache-Control %q, want %q", got, "public, max-age=300")
+	}
+
+	var response struct {
+		Products []map[string]any `json:"products"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode %q: %v", recorder.Body.String(), err)
+	}
+	if len(response.Products) != 1 || response.Products[0]["name"] != "Desk Lamp" {
+		t.Fatalf("got %v, want only the active Desk Lamp", response.Products)
+	}
+
+	product := response.Products[0]
+	for _, field := range []string{"sku", "stock", "reserved", "low_stock_threshold", "category_id", "image_reports",
+		"image_reported_at", "needs_review", "is_active", "created_by", "updated_by", "version", "created_at", "images", "tags", "rank"} {
+		if _, ok := product[field]; ok {
+			t.Errorf("the public feed exposes %q", field)
+		}
+	}
+	if product["in_stock"] != true {
+		t.Errorf("got in_stock %v, want true", product["in_stock"])
+	}
+}
@@ -1,10 +1,20 @@
 package handler
 
-// ErrorResponse represents an error response
+import (
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// ErrorResponse is the envelope of every error response, from handlers and middleware alike
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
+	Error     string `json:"error"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+	Details   string `json:"details,omitempty"`
+	Stack     string `json:"stack,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // SuccessResponse represents a success response
@@ -18,11 +28,49 @@ type StockUpdateRequest struct {
 	Stock int `json:"stock" validate:"min=0"`
 }
 
+// StockAdjustmentRequest represents a request to atomically add or remove stock
+type StockAdjustmentRequest struct {
+	Quantity int `json:"quantity" binding:"required,gt=0"`
+}
+
 // ProfileUpdateRequest represents a request to update user profile
 type ProfileUpdateRequest struct {
 	FirstName *string `json:"first_name,omitempty"`
 	LastName  *string `json:"last_name,omitempty"`
-	Username  *string `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
+	Username  *string `json:"username,omitempty" validate:"omitempty,min=3,max=50,username"`
+}
+
+// SetPasswordRequest represents an admin request to set a user's password
+type SetPasswordRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// TokenInfoResponse describes the caller's current access token without revealing it
+type TokenInfoResponse struct {
+	UserID           uint       `json:"user_id"`
+	Username         string     `json:"username"`
+	Role             string     `json:"role"`
+	IsAdmin          bool       `json:"is_admin"`
+	TokenID          string     `json:"jti"`
+	IssuedAt         *time.Time `json:"issued_at,omitempty"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RemainingSeconds int64      `json:"remaining_seconds"`
+}
+
+// ResendVerificationRequest represents a request to resend the email verification link
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotPasswordRequest represents a request to email a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents a request to set a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"` // checked against the password policy
 }
 
 // RefreshTokenRequest represents a refresh token request
@@ -30,8 +78,84 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+// BulkDeactivateUsersRequest represents a request to deactivate several users at once
+type BulkDeactivateUsersRequest struct {
+	UserIDs []uint `json:"user_ids" binding:"required,min=1"`
+}
+
+// CountResponse represents the number of records matching a filter
+type CountResponse struct {
+	Total int64 `json:"total"`
+}
+
 // BulkUpdateStatusRequest represents a request to bulk update product status
 type BulkUpdateStatusRequest struct {
-	ProductIDs []uint `json:"product_ids" validate:"required,min=1"`
-	IsActive   bool   `json:"is_active"`
+	ProductIDs []uint `json:"product_ids" binding:"required,min=1"`
+	IsActive   *bool  `json:"is_active" binding:"required"`
+}
+
+// ProductBatchRequest represents a request to get several products by ID
+type ProductBatchRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"`
+}
+
+// PublicProduct is the reduced view of a product served by the public feed. It is built field
+// by field so internal product fields can never leak into the feed.
+type PublicProduct struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Price       float64   `json:"price"`
+	Category    string    `json:"category"`
+	ImageURL    string    `json:"image_url"`
+	InStock     bool      `json:"in_stock"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PublicProductListResponse represents a page of the public product feed
+type PublicProductListResponse struct {
+	Products []PublicProduct `json:"products"`
+	service.PageInfo
+}
+
+// ValidationErrorResponse represents a validation failure with field-level detail
+type ValidationErrorResponse struct {
+	ErrorResponse
+	Errors []entity.FieldViolation `json:"errors"`
+}
+
+// ValidationResponse represents the result of validating a payload without persisting it
+type ValidationResponse struct {
+	Valid  bool                    `json:"valid"`
+	Errors []entity.FieldViolation `json:"errors,omitempty"`
+}
+
+// BulkCreateProductsRequest represents a request to create several products at once
+type BulkCreateProductsRequest struct {
+	Products        []*service.ProductCreateRequest `json:"products" binding:"required,min=1"`
+	ContinueOnError bool                            `json:"continue_on_error"`
+}
+
+// BulkCreateProductsResponse represents the per-item outcome of a bulk create
+type BulkCreateProductsResponse struct {
+	Created int                         `json:"created"`
+	Failed  int                         `json:"failed"`
+	Results []*service.BulkCreateResult `json:"results"`
+}
+
+// ProductReferencesResponse represents the records that reference a product
+type ProductReferencesResponse struct {
+	ProductID  uint                     `json:"product_id"`
+	Total      int64                    `json:"total"`
+	References entity.ProductReferences `json:"references"`
+}
+
+// ReadOnlyModeRequest represents a request to turn read-only mode on or off
+type ReadOnlyModeRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// ReadOnlyModeResponse represents the current read-only mode state
+type ReadOnlyModeResponse struct {
+	Enabled bool `json:"enabled"`
 }
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion identifies the shape of the request and response bodies a route group speaks
+type APIVersion string
+
+// Supported API versions
+const (
+	APIVersion1 APIVersion = "v1"
+	APIVersion2 APIVersion = "v2"
+)
+
+// responseFieldRenames lists, for each version after v1, the JSON fields whose v1 names it
+// replaces. A version's responses are v1 responses with these fields renamed wherever they occur.
+var responseFieldRenames = map[APIVersion]map[string]string{
+	APIVersion2: {
+		"image_url": "imageUrl",
+	},
+}
+
+// GetAPIVersion returns the API version of the route group serving the request, v1 if unset
+func GetAPIVersion(c *gin.Context) APIVersion {
+	if version, ok := c.Get(APIVersionKey); ok {
+		if v, ok := version.(APIVersion); ok {
+			return v
+		}
+	}
+	return APIVersion1
+}
+
+// versionedBody returns body in the shape of the request's API version. v1 bodies are returned
+// as they are, so v1 responses stay exactly as before versioning existed.
+func versionedBody(c *gin.Context, body interface{}) (interface{}, error) {
+	renames, ok := responseFieldRenames[GetAPIVersion(c)]
+	if !ok {
+		return body, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	// Decode numbers as written so prices and IDs survive the round trip unchanged
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return renameFields(document, renames), nil
+}
+
+// renameFields renames the object keys in renames throughout a decoded JSON document
+func renameFields(document interface{}, renames map[string]string) interface{} {
+	switch value := document.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			if newKey, ok := renames[key]; ok {
+				key = newKey
+			}
+			renamed[key] = renameFields(field, renames)
+		}
+		return renamed
+	case []interface{}:
+		for i, item := range value {
+			value[i] = renameFields(item, renames)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// respondJSON sends body as a JSON response in the shape of the request's API version
+func respondJSON(c *gin.Context, status int, body interface{}) {
+	body, err := versionedBody(c, body)
+	if err != nil {
+		_ = c.Error(err)
+		RespondError(c, http.StatusInternalServerError, CodeInternalError, "Failed to encode response")
+		return
+	}
+	c.JSON(status, body)
+}
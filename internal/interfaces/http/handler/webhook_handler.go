@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/usecase"
+)
+
+// ListWebhooks handles listing every webhook (admin only)
+func ListWebhooks(webhookService *usecase.WebhookUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhooks, err := webhookService.ListWebhooks(c.Request.Context())
+		if err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+	}
+}
+
+// GetWebhook handles getting a single webhook (admin only)
+func GetWebhook(webhookService *usecase.WebhookUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "webhook")
+		if !ok {
+			return
+		}
+
+		webhook, err := webhookService.GetWebhook(c.Request.Context(), id)
+		if err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// CreateWebhook handles creating a new webhook (admin only)
+func CreateWebhook(webhookService *usecase.WebhookUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req service.WebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		webhook, err := webhookService.CreateWebhook(c.Request.Context(), &req)
+		if err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, webhook)
+	}
+}
+
+// UpdateWebhook handles replacing a webhook's URL, events, active flag and optionally its secret (admin only)
+func UpdateWebhook(webhookService *usecase.WebhookUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "webhook")
+		if !ok {
+			return
+		}
+
+		var req service.WebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleBindError(c, err)
+			return
+		}
+
+		webhook, err := webhookService.UpdateWebhook(c.Request.Context(), id, &req)
+		if err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// DeleteWebhook handles deleting a webhook and its delivery log (admin only)
+func DeleteWebhook(webhookService *usecase.WebhookUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "webhook")
+		if !ok {
+			return
+		}
+
+		if err := webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+	}
+}
+
+// ListWebhookDeliveries handles listing a webhook's most recent deliveries (admin only)
+func ListWebhookDeliveries(webhookService *usecase.WebhookUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id", "webhook")
+		if !ok {
+			return
+		}
+
+		deliveries, err := webhookService.ListDeliveries(c.Request.Context(), id)
+		if err != nil {
+			handleWebhookError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"webhook_id": id, "deliveries": deliveries})
+	}
+}
+
+// handleWebhookError handles different types of webhook errors
+func handleWebhookError(c *gin.Context, err error) {
+	if respondIfReadOnly(c, err) {
+		return
+	}
+	if validationErr, ok := asValidationError(err); ok {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, entity.ErrWebhookNotFound):
+		respondDomainError(c, http.StatusNotFound, err)
+	default:
+		respondInternalError(c, err)
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// APIKeyHeader is the request header carrying a partner API key
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyContextKey is the context key under which the authenticated API key is stored
+const APIKeyContextKey = "api_key"
+
+// APIKeyMiddleware restricts a route to requests carrying one of keys in the X-API-Key header
+func APIKeyMiddleware(keys []string) gin.HandlerFunc {
+	trimmed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			trimmed = append(trimmed, key)
+		}
+	}
+	keys = trimmed
+
+	return func(c *gin.Context) {
+		provided := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if provided == "" || !validAPIKey(keys, provided) {
+			handler.RespondError(c, http.StatusUnauthorized, "INVALID_API_KEY", "A valid "+APIKeyHeader+" header is required")
+			return
+		}
+
+		c.Set(APIKeyContextKey, provided)
+		c.Next()
+	}
+}
+
+// validAPIKey reports whether provided is one of keys, comparing in constant time
+func validAPIKey(keys []string, provided string) bool {
+	valid := false
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(provided)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newPublicFeedRouter guards a route the way the public feed is: an API key from keys, then
+// limit requests per minute per key
+func newPublicFeedRouter(keys []string, limit int) *gin.Engine {
+	router := gin.New()
+	router.Use(APIKeyMiddleware(keys), RateLimitMiddleware(limit, time.Minute, func(c *gin.Context) string {
+		return c.GetString(APIKeyContextKey)
+	}))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func getWithAPIKey(router *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestAPIKeyMiddlewareRequiresAConfiguredKey(t *testing.T) {
+	router := newPublicFeedRouter([]string{" partner-a ", "", "partner-b"}, 100)
+
+	tests := []struct {
+		key    string
+		status int
+	}{
+		{"partner-a", http.StatusNoContent},
+		{"partner-b", http.StatusNoContent},
+		{"", http.StatusUnauthorized},
+		{"partner-c", http.StatusUnauthorized},
+		{"partner-a-but-longer", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		if got := getWithAPIKey(router, tt.key).Code; got != tt.status {
+			t.Errorf("key %q: got status %d, want %d", tt.key, got, tt.status)
+		}
+	}
+}
+
+func TestRateLimitMiddlewareLimitsEachKeySeparately(t *testing.T) {
+	router := newPublicFeedRouter([]string{"partner-a", "partner-b"}, 2)
+
+	for i := 0; i < 2; i++ {
+		if got := getWithAPIKey(router, "partner-a").Code; got != http.StatusNoContent {
+			t.Fatalf("request %d: got status %d, want %d", i+1, got, http.StatusNoContent)
+		}
+	}
+
+	limited := getWithAPIKey(router, "partner-a")
+	if limited.Code != http.StatusTooManyRequests || limited.Header().Get("Retry-After") == "" {
+		t.Errorf("got status %d and Retry-After %q, want 429 with a Retry-After", limited.Code, limited.Header().Get("Retry-After"))
+	}
+	if got := getWithAPIKey(router, "partner-b").Code; got != http.StatusNoContent {
+		t.Errorf("another key: got status %d, want %d", got, http.StatusNoContent)
+	}
+}
+
+func TestRateLimiterStartsANewWindow(t *testing.T) {
+	limiter := &rateLimiter{limit: 1, window: time.Minute, clients: make(map[string]*rateWindow)}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if ok, _ := limiter.allow("partner", start); !ok {
+		t.Fatal("first request was limited")
+	}
+	if ok, retryAfter := limiter.allow("partner", start.Add(20*time.Second)); ok || retryAfter != 40*time.Second {
+		t.Errorf("got allowed=%v, retry after %v, want a limit for 40s", ok, retryAfter)
+	}
+	if ok, _ := limiter.allow("partner", start.Add(time.Minute)); !ok {
+		t.Error("request in the next window was limited")
+	}
+}
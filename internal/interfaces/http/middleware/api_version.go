@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// APIVersionMiddleware records the API version a route group speaks, so handlers shared
+// between versions shape their responses for it
+func APIVersionMiddleware(version handler.APIVersion) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(handler.APIVersionKey, version)
+		c.Next()
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// TokenValidator validates access tokens; it is satisfied by service.AuthService
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*service.Claims, error)
+}
+
+// AuthMiddleware requires a valid "Bearer <token>" Authorization header and stores the
+// authenticated user's ID, admin flag and claims in the context for handlers
+func AuthMiddleware(validator TokenValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			abortUnauthorized(c, "Authorization header required")
+			return
+		}
+
+		token, found := strings.CutPrefix(authHeader, "Bearer ")
+		if !found || strings.TrimSpace(token) == "" {
+			abortUnauthorized(c, "Invalid authorization header format")
+			return
+		}
+
+		claims, err := validator.ValidateToken(c.Request.Context(), strings.TrimSpace(token))
+		if err != nil {
+			switch {
+			case errors.Is(err, entity.ErrInvalidToken):
+				abortUnauthorized(c, "Invalid or expired token")
+			case errors.Is(err, entity.ErrUserInactive):
+				abortUnauthorized(c, "User account is inactive")
+			default:
+				handler.RespondError(c, http.StatusInternalServerError, handler.CodeInternalError, "Failed to authenticate request")
+			}
+			return
+		}
+
+		c.Set(handler.UserIDKey, claims.UserID)
+		c.Set(handler.IsAdminKey, claims.IsAdmin)
+		c.Set(handler.ClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// AdminMiddleware restricts a route to admin users. It must run after the
+// authentication middleware that populates the is_admin context value. Requests that
+// reach it unauthenticated, as on a route missing AuthMiddleware, get a 401 rather than
+// a 403, which is reserved for authenticated users who aren't admins.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := handler.GetUserID(c); !ok {
+			abortUnauthorized(c, "Authentication required")
+			return
+		}
+		if !handler.IsAdmin(c) {
+			handler.RespondError(c, http.StatusForbidden, "ADMIN_REQUIRED", "Admin privileges required")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// abortUnauthorized aborts the request with a 401 response and a Bearer challenge
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", "Bearer")
+	handler.RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", message)
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// stubValidator accepts the tokens in claims and fails every other token with err
+type stubValidator struct {
+	claims map[string]*service.Claims
+	err    error
+}
+
+func (v stubValidator) ValidateToken(_ context.Context, token string) (*service.Claims, error) {
+	if claims, ok := v.claims[token]; ok {
+		return claims, nil
+	}
+	return nil, v.err
+}
+
+// authenticate serves a request with the given Authorization header through AuthMiddleware
+// and AdminMiddleware when admin is set, returning the response and the claims the handler saw
+func authenticate(validator TokenValidator, authorization string, admin bool) (*httptest.ResponseRecorder, *service.Claims) {
+	var seen *service.Claims
+	handlers := []gin.HandlerFunc{AuthMiddleware(validator)}
+	if admin {
+		handlers = append(handlers, AdminMiddleware())
+	}
+	handlers = append(handlers, func(c *gin.Context) {
+		seen, _ = handler.GetClaims(c)
+		c.Status(http.StatusNoContent)
+	})
+
+	router := gin.New()
+	router.GET("/", handlers...)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder, seen
+}
+
+func TestAuthMiddlewarePopulatesTheUserContext(t *testing.T) {
+	claims := &service.Claims{UserID: 7, Email: "user@example.com", Role: "user"}
+	recorder, seen := authenticate(stubValidator{claims: map[string]*service.Claims{"good": claims}}, "Bearer good", false)
+
+	if recorder.Code != http.StatusNoContent || seen != claims {
+		t.Errorf("got status %d and claims %+v, want 204 with the validated claims", recorder.Code, seen)
+	}
+}
+
+func TestAuthMiddlewareRejectsMissingAndInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name          string
+		validator     stubValidator
+		authorization string
+		status        int
+	}{
+		{"no header", stubValidator{}, "", http.StatusUnauthorized},
+		{"not a bearer token", stubValidator{}, "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"empty bearer token", stubValidator{}, "Bearer  ", http.StatusUnauthorized},
+		{"invalid token", stubValidator{err: entity.ErrInvalidToken}, "Bearer forged", http.StatusUnauthorized},
+		{"inactive user", stubValidator{err: entity.ErrUserInactive}, "Bearer stale", http.StatusUnauthorized},
+		{"validation failure", stubValidator{err: errors.New("connection refused")}, "Bearer any", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, seen := authenticate(tt.validator, tt.authorization, false)
+			if recorder.Code != tt.status || seen != nil {
+				t.Errorf("got status %d and claims %+v, want %d before the handler", recorder.Code, seen, tt.status)
+			}
+			if tt.status == http.StatusUnauthorized && recorder.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("got WWW-Authenticate %q, want Bearer", recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAdminMiddlewareRequiresAnAdmin(t *testing.T) {
+	validator := stubValidator{claims: map[string]*service.Claims{
+		"admin": {UserID: 1, IsAdmin: true},
+		"user":  {UserID: 2},
+	}}
+
+	if recorder, _ := authenticate(validator, "Bearer admin", true); recorder.Code != http.StatusNoContent {
+		t.Errorf("admin: got status %d, want %d", recorder.Code, http.StatusNoContent)
+	}
+	if recorder, _ := authenticate(validator, "Bearer user", true); recorder.Code != http.StatusForbidden {
+		t.Errorf("user: got status %d, want %d", recorder.Code, http.StatusForbidden)
+	}
+}
+
+func TestAdminMiddlewareAnswersUnauthenticatedRequestsWith401(t *testing.T) {
+	router := gin.New()
+	router.GET("/", AdminMiddleware(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusUnauthorized || recorder.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Errorf("got status %d and WWW-Authenticate %q, want 401 with a Bearer challenge", recorder.Code, recorder.Header().Get("WWW-Authenticate"))
+	}
+	if recorder, _ := authenticate(stubValidator{}, "", true); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("no token on an admin route: got status %d, want %d", recorder.Code, http.StatusUnauthorized)
+	}
+}
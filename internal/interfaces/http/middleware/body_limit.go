@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// unlimitedBodyKey holds the request body as it was before MaxBodySizeMiddleware limited it,
+// so BodyLimitMiddleware can replace the global limit rather than stack under it
+const unlimitedBodyKey = "unlimited_body"
+
+// MaxBodySizeMiddleware caps request bodies at maxBytes for every route. It never aborts
+// itself, since a route's BodyLimitMiddleware may allow more: a body declaring an oversize
+// length fails on its first read, and others fail once reading passes the limit, so handlers
+// respond 413 as they bind the body. A non-positive maxBytes leaves bodies unlimited.
+func MaxBodySizeMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if maxBytes <= 0 {
+			c.Next()
+			return
+		}
+
+		c.Set(unlimitedBodyKey, c.Request.Body)
+		if c.Request.ContentLength > maxBytes {
+			c.Request.Body = oversizeBody{ReadCloser: c.Request.Body, limit: maxBytes}
+		} else {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+		c.Next()
+	}
+}
+
+// oversizeBody is a body whose declared length is over limit; it fails without reading any of it
+type oversizeBody struct {
+	io.ReadCloser
+	limit int64
+}
+
+// Read reports that the body is too large
+func (b oversizeBody) Read([]byte) (int, error) {
+	return 0, &http.MaxBytesError{Limit: b.limit}
+}
+
+// BodyLimitMiddleware caps request bodies at limit bytes, responding with 413 when exceeded.
+// Bodies with a declared oversize length are rejected upfront; others fail once reading passes
+// the limit. It overrides the global MaxBodySizeMiddleware limit, raising or lowering it, so
+// apply a single one per route. A non-positive limit leaves the body unlimited.
+func BodyLimitMiddleware(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if body, ok := c.Get(unlimitedBodyKey); ok {
+			c.Request.Body = body.(io.ReadCloser)
+		}
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			handler.RespondBodyTooLarge(c, limit)
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// serveWithBodyLimit posts body through BodyLimitMiddleware(limit) to a handler reading it
+// all, hiding the body's length when chunked is set
+func serveWithBodyLimit(limit int64, body string, chunked bool) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.POST("/", BodyLimitMiddleware(limit), func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				handler.RespondBodyTooLarge(c, maxBytesErr.Limit)
+				return
+			}
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if chunked {
+		req.ContentLength = -1
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int64
+		body    string
+		chunked bool
+		status  int
+	}{
+		{"within the limit", 2048, strings.Repeat("x", 2048), false, http.StatusNoContent},
+		{"declared oversize", 2048, strings.Repeat("x", 2049), false, http.StatusRequestEntityTooLarge},
+		{"undeclared oversize", 2048, strings.Repeat("x", 4096), true, http.StatusRequestEntityTooLarge},
+		{"unlimited", 0, strings.Repeat("x", 4096), false, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveWithBodyLimit(tt.limit, tt.body, tt.chunked)
+			if recorder.Code != tt.status {
+				t.Fatalf("got status %d, want %d", recorder.Code, tt.status)
+			}
+			if tt.status != http.StatusRequestEntityTooLarge {
+				return
+			}
+
+			var response handler.ErrorResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decode %q: %v", recorder.Body, err)
+			}
+			if response.Code != "BODY_TOO_LARGE" || !strings.Contains(response.Message, "2KB") {
+				t.Errorf("got %+v, want code BODY_TOO_LARGE naming the 2KB limit", response)
+			}
+		})
+	}
+}
+
+// serveWithMaxBodySize posts body through MaxBodySizeMiddleware(maxBytes), and then through
+// BodyLimitMiddleware(override) if override is set, to a handler binding it as a handler would
+func serveWithMaxBodySize(maxBytes int64, override *int64, body string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Use(MaxBodySizeMiddleware(maxBytes))
+	handlers := []gin.HandlerFunc{func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				handler.RespondBodyTooLarge(c, maxBytesErr.Limit)
+				return
+			}
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}}
+	if override != nil {
+		handlers = append([]gin.HandlerFunc{BodyLimitMiddleware(*override)}, handlers...)
+	}
+	router.POST("/", handlers...)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return recorder
+}
+
+func TestMaxBodySizeMiddleware(t *testing.T) {
+	lower, higher, unlimited := int64(1024), int64(4096), int64(0)
+	tests := []struct {
+		name     string
+		override *int64
+		size     int
+		status   int
+	}{
+		{"just under the global limit", nil, 2047, http.StatusNoContent},
+		{"at the global limit", nil, 2048, http.StatusNoContent},
+		{"just over the global limit", nil, 2049, http.StatusRequestEntityTooLarge},
+		{"over the global limit within a higher override", &higher, 4096, http.StatusNoContent},
+		{"over a higher override", &higher, 4097, http.StatusRequestEntityTooLarge},
+		{"under the global limit over a lower override", &lower, 1025, http.StatusRequestEntityTooLarge},
+		{"over the global limit with an unlimited override", &unlimited, 8192, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveWithMaxBodySize(2048, tt.override, strings.Repeat("x", tt.size))
+			if recorder.Code != tt.status {
+				t.Fatalf("got status %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestMaxBodySizeMiddlewareReadsNothingOfADeclaredOversizeBody(t *testing.T) {
+	body := strings.NewReader(strings.Repeat("x", 4096))
+	router := gin.New()
+	router.POST("/", MaxBodySizeMiddleware(2048), func(c *gin.Context) {
+		if _, err := c.Request.Body.Read(make([]byte, 512)); err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", body))
+
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d, want %d", recorder.Code, http.StatusRequestEntityTooLarge)
+	}
+	if body.Len() != 4096 {
+		t.Errorf("read %d bytes of the body, want none", 4096-body.Len())
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/config"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// CORSMiddleware adds CORS headers for requests from the configured origins. Requests from
+// other origins get no CORS headers, so browsers block them. "*" allows any origin, but with
+// credentials enabled the request's origin is echoed instead, as browsers reject "*" there.
+func CORSMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+	allowAll := false
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.TrimSpace(origin)
+		if origin == "*" {
+			allowAll = true
+		}
+		origins[strings.ToLower(origin)] = true
+	}
+	methods := joinTrimmed(cfg.AllowedMethods)
+	headers := joinTrimmed(cfg.AllowedHeaders)
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		wildcard := allowAll && !cfg.AllowCredentials
+		if !wildcard {
+			// The response depends on the Origin header, so caches must key on it
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+
+		if origin != "" && (allowAll || origins[strings.ToLower(origin)]) {
+			if wildcard {
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Header("Access-Control-Allow-Origin", origin)
+			}
+			if cfg.AllowCredentials {
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
+			c.Header("Access-Control-Expose-Headers", strings.Join([]string{
+				handler.RequestIDHeader, handler.PageSizeCappedHeader, RequestDeadlineHeader,
+				handler.TotalCountHeader, handler.PageHeader, handler.PageSizeHeader, handler.TotalPagesHeader, "Link",
+			}, ", "))
+
+			if preflight {
+				c.Header("Access-Control-Allow-Methods", methods)
+				c.Header("Access-Control-Allow-Headers", headers)
+			}
+		}
+
+		if preflight {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// joinTrimmed joins values into a comma-separated header value, dropping surrounding spaces
+func joinTrimmed(values []string) string {
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return strings.Join(trimmed, ", ")
+}
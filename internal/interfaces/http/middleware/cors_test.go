@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/config"
+)
+
+// corsResponse sends a request from origin through CORSMiddleware, as a preflight when
+// preflight is set
+func corsResponse(cfg config.CORSConfig, origin string, preflight bool) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Use(CORSMiddleware(cfg))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if preflight {
+		req = httptest.NewRequest(http.MethodOptions, "/", nil)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+	}
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+var testCORS = config.CORSConfig{
+	AllowedOrigins: []string{"https://shop.example.com", " https://admin.example.com "},
+	AllowedMethods: []string{"GET", " PATCH"},
+	AllowedHeaders: []string{"Authorization", "Content-Type"},
+}
+
+func TestCORSMiddlewareAllowsOnlyConfiguredOrigins(t *testing.T) {
+	allowed := corsResponse(testCORS, "https://admin.example.com", false)
+	if got := allowed.Header().Get("Access-Control-Allow-Origin"); got != "https://admin.example.com" {
+		t.Errorf("allowed origin: got Allow-Origin %q, want the origin echoed", got)
+	}
+	if got := allowed.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("allowed origin: got Vary %q, want Origin", got)
+	}
+
+	denied := corsResponse(testCORS, "https://evil.example.com", false)
+	if got := denied.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("other origin: got Allow-Origin %q, want none", got)
+	}
+	if denied.Code != http.StatusOK {
+		t.Errorf("other origin: got status %d, want the request served without CORS headers", denied.Code)
+	}
+}
+
+func TestCORSMiddlewareAnswersPreflights(t *testing.T) {
+	recorder := corsResponse(testCORS, "https://shop.example.com", true)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("got status %d, want %d", recorder.Code, http.StatusNoContent)
+	}
+	if got := recorder.Header().Get("Access-Control-Allow-Methods"); got != "GET, PATCH" {
+		t.Errorf("got Allow-Methods %q, want %q", got, "GET, PATCH")
+	}
+	if got := recorder.Header().Get("Access-Control-Allow-Headers"); got != "Authorization, Content-Type" {
+		t.Errorf("got Allow-Headers %q, want %q", got, "Authorization, Content-Type")
+	}
+}
+
+func TestCORSMiddlewareWildcard(t *testing.T) {
+	cfg := config.CORSConfig{AllowedOrigins: []string{"*"}}
+
+	anyOrigin := corsResponse(cfg, "https://anywhere.example.com", false)
+	if got := anyOrigin.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("got Allow-Origin %q, want *", got)
+	}
+	if got := anyOrigin.Header().Get("Vary"); got != "" {
+		t.Errorf("got Vary %q, want none for a wildcard", got)
+	}
+
+	cfg.AllowCredentials = true
+	withCredentials := corsResponse(cfg, "https://anywhere.example.com", false)
+	if got := withCredentials.Header().Get("Access-Control-Allow-Origin"); got != "https://anywhere.example.com" {
+		t.Errorf("with credentials: got Allow-Origin %q, want the origin echoed", got)
+	}
+	if got := withCredentials.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("with credentials: got Allow-Credentials %q, want true", got)
+	}
+}
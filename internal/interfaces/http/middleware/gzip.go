@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// incompressibleTypes are content types that are already compressed, so gzipping them again
+// only costs CPU. Entries ending in "/" match a whole type family.
+var incompressibleTypes = []string{
+	"image/", "video/", "audio/", "font/woff", "font/woff2",
+	"application/gzip", "application/x-gzip", "application/zip", "application/zstd",
+	"application/x-7z-compressed", "application/x-bzip2", "application/pdf",
+}
+
+// gzipResponseWriter compresses the response body once it reaches minSize bytes. Smaller
+// bodies are held back and sent uncompressed when the handler finishes, since gzip would
+// only make them bigger.
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	minSize int
+	buf     []byte
+	decided bool
+	writer  *gzip.Writer // nil unless the body is being compressed
+}
+
+// Write compresses data into the response body, buffering it while the size is undecided
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) < w.minSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.writer != nil {
+		return w.writer.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString compresses s into the response body
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow holds the headers back until the body is known to be compressed or not,
+// since Content-Encoding can't be set once they are sent
+func (w *gzipResponseWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Written reports whether any of the body has been written, including data still buffered
+func (w *gzipResponseWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends the data written so far to the client. Flushing means the handler is streaming,
+// so the body is compressed from here on whatever its size.
+func (w *gzipResponseWriter) Flush() {
+	if !w.decided {
+		w.minSize = 0
+		_ = w.decide()
+	}
+	if w.writer != nil {
+		_ = w.writer.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide chooses whether to compress the body, then writes out what has been buffered
+func (w *gzipResponseWriter) decide() error {
+	w.decided = true
+	buf := w.buf
+	w.buf = nil
+
+	if len(buf) >= w.minSize && w.compressible() {
+		// Any length set by the handler describes the uncompressed body
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.writer = gzip.NewWriter(w.ResponseWriter)
+		_, err := w.writer.Write(buf)
+		return err
+	}
+
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// compressible reports whether the response can be gzipped: it must have a body, not be
+// encoded already (e.g. by an inner gzip middleware) and not be of a compressed type
+func (w *gzipResponseWriter) compressible() bool {
+	status := w.Status()
+	if status == http.StatusNoContent || status == http.StatusNotModified || status < http.StatusOK {
+		return false
+	}
+	if w.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil {
+		return true
+	}
+	for _, incompressible := range incompressibleTypes {
+		if contentType == incompressible || strings.HasSuffix(incompressible, "/") && strings.HasPrefix(contentType, incompressible) {
+			return false
+		}
+	}
+	return true
+}
+
+// finish sends a body that stayed under the minimum size and ends the gzip stream
+func (w *gzipResponseWriter) finish() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.writer != nil {
+		_ = w.writer.Close()
+	}
+}
+
+// GzipMiddleware compresses responses of at least minSize bytes with gzip when the client's
+// Accept-Encoding allows it. Requests whose path starts with one of skipPathPrefixes, such as
+// the swagger UI, are passed through untouched.
+func GzipMiddleware(minSize int, skipPathPrefixes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, prefix := range skipPathPrefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		c.Header("Vary", "Accept-Encoding")
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &gzipResponseWriter{ResponseWriter: original, minSize: minSize}
+		c.Writer = writer
+		// On a panic the buffered body is dropped, leaving the recovery middleware free to
+		// write its error response to the original writer
+		defer func() { c.Writer = original }()
+
+		c.Next()
+		writer.finish()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+
+		rejected := false
+		for _, param := range fields[1:] {
+			param = strings.ReplaceAll(strings.TrimSpace(param), " ", "")
+			if param == "q=0" || param == "q=0.0" || param == "q=0.00" || param == "q=0.000" {
+				rejected = true
+			}
+		}
+		if !rejected {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gzipMinSize is the minimum size newGzipRouter compresses responses from
+const gzipMinSize = 64
+
+// newGzipRouter returns a router compressing responses of at least gzipMinSize bytes, except
+// under /swagger/, like the global gzip middleware. /export compresses every response with a
+// second, nested gzip middleware, like the product export route.
+func newGzipRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(GzipMiddleware(gzipMinSize, "/swagger/"))
+
+	router.GET("/text/:size", func(c *gin.Context) {
+		c.String(http.StatusOK, strings.Repeat("x", sizeParam(c)))
+	})
+	router.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(strings.Repeat("x", 1024)))
+	})
+	router.GET("/swagger/*any", func(c *gin.Context) {
+		c.String(http.StatusOK, strings.Repeat("x", 1024))
+	})
+	router.GET("/no-content", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/not-modified", func(c *gin.Context) {
+		c.Status(http.StatusNotModified)
+	})
+	router.GET("/export/:size", GzipMiddleware(0), func(c *gin.Context) {
+		c.String(http.StatusOK, strings.Repeat("x", sizeParam(c)))
+	})
+	return router
+}
+
+// sizeParam returns the :size path parameter
+func sizeParam(c *gin.Context) int {
+	size, _ := strconv.Atoi(c.Param("size"))
+	return size
+}
+
+// getGzip requests target from router with the given Accept-Encoding header
+func getGzip(router *gin.Engine, target, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// gunzip decompresses body, failing the test if it isn't gzip
+func gunzip(t *testing.T, body io.Reader) string {
+	t.Helper()
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read gzip body: %v", err)
+	}
+	return string(data)
+}
+
+func TestGzipMiddlewareCompressesFromTheMinimumSize(t *testing.T) {
+	router := newGzipRouter()
+
+	tests := []struct {
+		name       string
+		size       int
+		compressed bool
+	}{
+		{"below the minimum", gzipMinSize - 1, false},
+		{"at the minimum", gzipMinSize, true},
+		{"above the minimum", 4096, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := getGzip(router, "/text/"+strconv.Itoa(tt.size), "gzip")
+			want := strings.Repeat("x", tt.size)
+
+			if recorder.Header().Get("Vary") != "Accept-Encoding" {
+				t.Errorf("got Vary %q, want Accept-Encoding", recorder.Header().Get("Vary"))
+			}
+			if !tt.compressed {
+				if encoding := recorder.Header().Get("Content-Encoding"); encoding != "" {
+					t.Fatalf("got Content-Encoding %q, want none", encoding)
+				}
+				if recorder.Body.String() != want {
+					t.Errorf("got body of %d bytes, want the %d bytes written", recorder.Body.Len(), tt.size)
+				}
+				return
+			}
+			if encoding := recorder.Header().Get("Content-Encoding"); encoding != "gzip" {
+				t.Fatalf("got Content-Encoding %q, want gzip", encoding)
+			}
+			if got := gunzip(t, recorder.Body); got != want {
+				t.Errorf("got decompressed body of %d bytes, want the %d bytes written", len(got), tt.size)
+			}
+		})
+	}
+}
+
+func TestGzipMiddlewareSendsUncompressedResponses(t *testing.T) {
+	router := newGzipRouter()
+
+	tests := []struct {
+		name, target, acceptEncoding string
+	}{
+		{"gzip not accepted", "/text/4096", ""},
+		{"gzip refused with q=0", "/text/4096", "gzip;q=0, identity"},
+		{"incompressible content type", "/image", "gzip"},
+		{"skipped path", "/swagger/index.html", "gzip"},
+		{"no content", "/no-content", "gzip"},
+		{"not modified", "/not-modified", "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := getGzip(router, tt.target, tt.acceptEncoding)
+			if encoding := recorder.Header().Get("Content-Encoding"); encoding != "" {
+				t.Errorf("got Content-Encoding %q, want none", encoding)
+			}
+			if recorder.Code == http.StatusNoContent || recorder.Code == http.StatusNotModified {
+				if recorder.Body.Len() != 0 {
+					t.Errorf("got %d byte body for status %d, want none", recorder.Body.Len(), recorder.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestGzipMiddlewareCompressesNestedResponsesOnce(t *testing.T) {
+	router := newGzipRouter()
+
+	// The nested middleware has no minimum, so even responses the global one would leave
+	// alone are compressed, and either way the outer one must not compress them again
+	for _, size := range []int{16, 4096} {
+		recorder := getGzip(router, "/export/"+strconv.Itoa(size), "gzip")
+
+		if encodings := recorder.Header().Values("Content-Encoding"); len(encodings) != 1 || encodings[0] != "gzip" {
+			t.Fatalf("%d bytes: got Content-Encoding %q, want a single gzip", size, encodings)
+		}
+		if got := gunzip(t, recorder.Body); got != strings.Repeat("x", size) {
+			t.Errorf("%d bytes: got %q after decompressing once, want the body written", size, got)
+		}
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           bool
+	}{
+		{"gzip", true},
+		{"br, gzip;q=0.8", true},
+		{"*", true},
+		{"gzip;q=0", false},
+		{"gzip; q=0.0, *;q=0", false},
+		{"identity", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := acceptsGzip(tt.acceptEncoding); got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.acceptEncoding, got, tt.want)
+		}
+	}
+}
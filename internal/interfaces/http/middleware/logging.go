@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// LoggingMiddleware logs each request to logger once it has been handled, at warn level for
+// client errors and error level for server errors. Handlers log through the same logger, see
+// handler.GetLogger.
+func LoggingMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		c.Set(handler.LoggerKey, logger)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := append(requestAttrs(c),
+			slog.String("method", c.Request.Method),
+			slog.String("path", path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		)
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// requestAttrs returns the log attributes identifying the user making the request, once
+// authenticated. The request ID comes from the request's context, see RequestIDMiddleware.
+func requestAttrs(c *gin.Context) []slog.Attr {
+	var attrs []slog.Attr
+	if userID, ok := handler.GetUserID(c); ok {
+		attrs = append(attrs, slog.Uint64("user_id", uint64(userID)))
+	}
+	return attrs
+}
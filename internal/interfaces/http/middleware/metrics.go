@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/pkg/metrics"
+)
+
+// unmatchedRoute labels requests that didn't match any registered route
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware records request count, latency and in-flight requests per route template
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+
+		inFlight := metrics.HTTPRequestsInFlight.WithLabelValues(method, route)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		c.Next()
+
+		status := strconv.Itoa(c.Writer.Status())
+		metrics.HTTPRequestsTotal.WithLabelValues(method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
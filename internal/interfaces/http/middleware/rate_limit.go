@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// rateWindow counts the requests a client made in the current fixed window
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimiter allows each client a fixed number of requests per window
+type rateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	clients   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+// allow records a request by key and reports whether it is within the limit, and if not,
+// how long until the client's window resets
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop clients whose window has ended so the map doesn't grow without bound
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, w := range l.clients {
+			if now.Sub(w.start) >= l.window {
+				delete(l.clients, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, ok := l.clients[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = &rateWindow{start: now}
+		l.clients[key] = w
+	}
+	if w.count >= l.limit {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// RateLimitMiddleware allows each client limit requests per window and answers the rest with
+// 429. Clients are identified by keyFunc, e.g. their API key or IP address.
+func RateLimitMiddleware(limit int, window time.Duration, keyFunc func(c *gin.Context) string) gin.HandlerFunc {
+	limiter := &rateLimiter{
+		limit:   limit,
+		window:  window,
+		clients: make(map[string]*rateWindow),
+	}
+
+	return func(c *gin.Context) {
+		allowed, retryAfter := limiter.allow(keyFunc(c), time.Now())
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			handler.RespondError(c, http.StatusTooManyRequests, "RATE_LIMITED", "Rate limit exceeded, please retry later")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// RecoveryMiddleware recovers from panics, logs them to logger with a stack trace and responds
+// with a 500 ErrorResponse carrying the request ID. The panic value and stack are only included
+// in the response outside of release mode.
+func RecoveryMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			requestID := handler.GetRequestID(c)
+			stack := debug.Stack()
+			logger.LogAttrs(c.Request.Context(), slog.LevelError, "panic recovered", append(requestAttrs(c),
+				slog.String("method", c.Request.Method),
+				slog.String("path", c.Request.URL.Path),
+				slog.Any("panic", recovered),
+				slog.String("stack", string(stack)),
+			)...)
+
+			// The client is gone or the response has started, so there's nothing left to send
+			if err, ok := recovered.(error); ok && (errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)) {
+				c.Abort()
+				return
+			}
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			response := handler.ErrorResponse{
+				Error:     "Internal Server Error",
+				Code:      handler.CodeInternalError,
+				Message:   "An unexpected error occurred",
+				RequestID: requestID,
+			}
+			if gin.Mode() != gin.ReleaseMode {
+				response.Details = fmt.Sprint(recovered)
+				response.Stack = string(stack)
+			}
+
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		}()
+
+		c.Next()
+	}
+}
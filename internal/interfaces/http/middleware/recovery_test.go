@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/infrastructure/logging"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// servePanic serves a request whose handler panics through RequestIDMiddleware and
+// RecoveryMiddleware, returning the decoded response and what was logged
+func servePanic(t *testing.T) (int, handler.ErrorResponse, map[string]interface{}) {
+	t.Helper()
+	var logs bytes.Buffer
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RecoveryMiddleware(logging.NewLogger(&logs, "json", "info")))
+	router.GET("/", func(c *gin.Context) { panic("boom") })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(handler.RequestIDHeader, "req-123")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	var response handler.ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode %q: %v", recorder.Body, err)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("decode log record %q: %v", logs.String(), err)
+	}
+	return recorder.Code, response, record
+}
+
+func TestRecoveryMiddlewareRespondsWithTheErrorEnvelope(t *testing.T) {
+	mode := gin.Mode()
+	defer gin.SetMode(mode)
+
+	gin.SetMode(gin.ReleaseMode)
+	status, response, record := servePanic(t)
+	want := handler.ErrorResponse{Error: "Internal Server Error", Code: handler.CodeInternalError, Message: "An unexpected error occurred", RequestID: "req-123"}
+	if status != http.StatusInternalServerError || response != want {
+		t.Errorf("release mode: got %d %+v, want 500 %+v", status, response, want)
+	}
+	if record["request_id"] != "req-123" || record["panic"] != "boom" || record["stack"] == "" {
+		t.Errorf("got log record %v, want the panic and its stack tagged with the request ID", record)
+	}
+
+	gin.SetMode(gin.DebugMode)
+	if _, response, _ := servePanic(t); response.Details != "boom" || response.Stack == "" {
+		t.Errorf("debug mode: got details %q and a %d byte stack, want the panic and its stack", response.Details, len(response.Stack))
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// maxRequestIDLength bounds client-supplied request IDs so they can't bloat logs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware attaches a request ID to the context, the request's context.Context (so
+// everything logged while serving it is tagged with it) and the X-Request-ID response header,
+// reusing the client's ID when one is supplied
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(handler.RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+
+		c.Set(handler.RequestIDKey, requestID)
+		c.Request = c.Request.WithContext(service.WithRequestID(c.Request.Context(), requestID))
+		c.Header(handler.RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// newRequestID generates a random 128-bit hex request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
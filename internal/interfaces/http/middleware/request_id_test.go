@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/internal/interfaces/http/handler"
+)
+
+// requestIDOf serves a request with the given X-Request-ID through RequestIDMiddleware and
+// returns the ID the handler saw, the one in its context and the one sent back
+func requestIDOf(t *testing.T, supplied string) (seen, inContext, returned string) {
+	t.Helper()
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		seen = handler.GetRequestID(c)
+		inContext, _ = service.RequestIDFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if supplied != "" {
+		req.Header.Set(handler.RequestIDHeader, supplied)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return seen, inContext, recorder.Header().Get(handler.RequestIDHeader)
+}
+
+func TestRequestIDMiddlewareReusesTheClientsID(t *testing.T) {
+	seen, inContext, returned := requestIDOf(t, "client-trace-1")
+	if seen != "client-trace-1" || inContext != seen || returned != seen {
+		t.Errorf("got %q in gin, %q in context and %q returned, want client-trace-1 throughout", seen, inContext, returned)
+	}
+}
+
+func TestRequestIDMiddlewareGeneratesMissingOrOversizedIDs(t *testing.T) {
+	for name, supplied := range map[string]string{"missing": "", "oversized": strings.Repeat("x", maxRequestIDLength+1)} {
+		t.Run(name, func(t *testing.T) {
+			seen, inContext, returned := requestIDOf(t, supplied)
+			if len(seen) != 32 || seen == supplied || inContext != seen || returned != seen {
+				t.Errorf("got %q in gin, %q in context and %q returned, want one generated ID throughout", seen, inContext, returned)
+			}
+		})
+	}
+}
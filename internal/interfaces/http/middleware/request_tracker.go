@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// RequestTracker counts in-flight HTTP requests so shutdown can report and wait for them
+type RequestTracker struct {
+	inFlight atomic.Int64
+	wg       sync.WaitGroup
+}
+
+// NewRequestTracker creates a new request tracker
+func NewRequestTracker() *RequestTracker {
+	return &RequestTracker{}
+}
+
+// Handler wraps next so every request it serves is tracked
+func (t *RequestTracker) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.wg.Add(1)
+		t.inFlight.Add(1)
+		defer func() {
+			t.inFlight.Add(-1)
+			t.wg.Done()
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// InFlight returns the number of requests currently being served
+func (t *RequestTracker) InFlight() int64 {
+	return t.inFlight.Load()
+}
+
+// Wait blocks until every tracked request has finished or ctx is done
+func (t *RequestTracker) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/interfaces/http/handler"
+	"github.com/product-management/pkg/jwt"
+)
+
+// RequestDeadlineHeader is the response header telling clients when their request times out
+const RequestDeadlineHeader = "X-Request-Deadline"
+
+// RequestTierContextKey is the context key under which the request's client tier is stored
+const RequestTierContextKey = "request_tier"
+
+// TimeoutMiddleware gives each request a deadline from the timeout of its client tier, as
+// resolved by tierFunc, falling back to defaultTier for unknown tiers. The deadline is set on
+// the request context before the handler runs and reported in the X-Request-Deadline header.
+//
+// The deadline is cooperative: reaching it cancels the request context, which aborts database
+// queries and streaming exports, but the handler is never interrupted. A handler that gives up
+// without writing a response gets 504; one that ignores the cancellation and responds late is
+// logged as an overrun, with its response sent as written.
+func TimeoutMiddleware(timeouts map[string]time.Duration, defaultTier string, tierFunc func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tier := tierFunc(c)
+		timeout, ok := timeouts[tier]
+		if !ok {
+			tier, timeout = defaultTier, timeouts[defaultTier]
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		deadline, _ := ctx.Deadline()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Set(RequestTierContextKey, tier)
+		c.Header(RequestDeadlineHeader, deadline.UTC().Format(time.RFC3339Nano))
+
+		c.Next()
+
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return
+		}
+		if !c.Writer.Written() {
+			handler.RespondError(c, http.StatusGatewayTimeout, "REQUEST_TIMEOUT", "The request did not complete within its time limit")
+			return
+		}
+		if overrun := time.Since(deadline); overrun > 0 {
+			handler.GetLogger(c).WarnContext(ctx, "Request overran its deadline",
+				slog.String("tier", tier),
+				slog.Duration("timeout", timeout),
+				slog.Duration("overrun", overrun),
+			)
+		}
+	}
+}
+
+// FirstTier returns a tierFunc for TimeoutMiddleware trying each of tierFuncs in turn and
+// returning the first tier found
+func FirstTier(tierFuncs ...func(c *gin.Context) string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		for _, tierFunc := range tierFuncs {
+			if tier := tierFunc(c); tier != "" {
+				return tier
+			}
+		}
+		return ""
+	}
+}
+
+// TokenParser verifies access tokens without looking up their user; it is satisfied by
+// *jwt.TokenManager
+type TokenParser interface {
+	ValidateToken(token string) (*jwt.Claims, error)
+}
+
+// TokenRoleTier returns a tierFunc for TimeoutMiddleware that looks up the tier of the role
+// claim of the request's bearer token in roleTiers. The timeout runs before authentication, so
+// the token's signature and expiry are checked here but not whether it has been revoked; a
+// revoked token gains nothing from its tier as authentication still rejects it. Requests
+// without a valid access token get an empty tier, i.e. the default one.
+func TokenRoleTier(tokens TokenParser, roleTiers map[string]string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		if len(roleTiers) == 0 {
+			return ""
+		}
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || strings.TrimSpace(token) == "" {
+			return ""
+		}
+
+		claims, err := tokens.ValidateToken(strings.TrimSpace(token))
+		if err != nil || claims.IsRefresh() {
+			return ""
+		}
+		return roleTiers[claims.Role]
+	}
+}
+
+// APIKeyTier returns a tierFunc for TimeoutMiddleware that looks up the tier of the client's
+// X-API-Key in keyTiers. Requests without a known key get an empty tier, i.e. the default one.
+func APIKeyTier(keyTiers map[string]string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		provided := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if provided == "" {
+			return ""
+		}
+
+		// Compare every key in constant time so the lookup doesn't leak which keys exist
+		tier := ""
+		for key, keyTier := range keyTiers {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(provided)) == 1 {
+				tier = keyTier
+			}
+		}
+		return tier
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/pkg/jwt"
+)
+
+var testTimeouts = map[string]time.Duration{"free": 50 * time.Millisecond, "premium": time.Minute}
+
+func newTestTokenManager(t *testing.T, secret string) *jwt.TokenManager {
+	t.Helper()
+	key, err := jwt.NewHMACKey(secret)
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	return jwt.NewTokenManager(key, time.Hour, 24*time.Hour, 0, "", "")
+}
+
+// tierOf serves req through TimeoutMiddleware resolving tiers with tierFunc and returns the
+// tier the request was given
+func tierOf(t *testing.T, tierFunc func(c *gin.Context) string, req *http.Request) string {
+	t.Helper()
+	var tier string
+	router := gin.New()
+	router.Use(TimeoutMiddleware(testTimeouts, "free", tierFunc))
+	router.GET("/", func(c *gin.Context) {
+		tier = c.GetString(RequestTierContextKey)
+		c.Status(http.StatusNoContent)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	return tier
+}
+
+func TestTokenRoleTierResolvesTheTierFromTheRoleClaim(t *testing.T) {
+	tokens := newTestTokenManager(t, "test-secret-that-is-long-enough-for-hs256")
+	tierFunc := FirstTier(APIKeyTier(map[string]string{"partner-key": "free"}), TokenRoleTier(tokens, map[string]string{"admin": "premium"}))
+
+	admin, _ := tokens.GenerateToken(1, "admin@example.com", "admin")
+	user, _ := tokens.GenerateToken(2, "user@example.com", "user")
+	refresh, _ := tokens.GenerateRefreshToken(1, "admin@example.com", "admin")
+	forged, _ := newTestTokenManager(t, "another-secret-that-is-long-enough-too").GenerateToken(1, "admin@example.com", "admin")
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"admin token", map[string]string{"Authorization": "Bearer " + admin}, "premium"},
+		{"user token", map[string]string{"Authorization": "Bearer " + user}, "free"},
+		{"refresh token", map[string]string{"Authorization": "Bearer " + refresh}, "free"},
+		{"token signed with another key", map[string]string{"Authorization": "Bearer " + forged}, "free"},
+		{"no token", nil, "free"},
+		{"API key outranks the token", map[string]string{"Authorization": "Bearer " + admin, APIKeyHeader: "partner-key"}, "free"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			if got := tierOf(t, tierFunc, req); got != tt.want {
+				t.Errorf("got tier %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddlewareAnswersAHandlerThatGaveUpWith504(t *testing.T) {
+	router := gin.New()
+	router.Use(TimeoutMiddleware(testTimeouts, "free", APIKeyTier(nil)))
+	router.GET("/", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusGatewayTimeout {
+		t.Errorf("got status %d, want %d", recorder.Code, http.StatusGatewayTimeout)
+	}
+}
+
+func TestTimeoutMiddlewareSendsALateResponseAsWritten(t *testing.T) {
+	router := gin.New()
+	router.Use(TimeoutMiddleware(testTimeouts, "free", APIKeyTier(nil)))
+	router.GET("/", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.String(http.StatusOK, "late")
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "late" {
+		t.Errorf("got %d %q, want the handler's late 200", recorder.Code, recorder.Body.String())
+	}
+}
@@ -1,71 +1,95 @@
 package router
 
 import (
-	"net/http"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/product-management/internal/config"
+	"github.com/product-management/internal/domain/service"
 	"github.com/product-management/internal/infrastructure/database"
+	"github.com/product-management/internal/infrastructure/repository"
 	"github.com/product-management/internal/interfaces/http/handler"
+	"github.com/product-management/internal/interfaces/http/middleware"
 	"github.com/product-management/internal/usecase"
+	"github.com/product-management/pkg/metrics"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-// SetupRouter configures and returns the HTTP router
+// SetupRouter configures and returns the HTTP router, logging requests to logger. tokens
+// resolves the timeout tier of requests from their bearer token's role.
 func SetupRouter(
 	cfg *config.Config,
+	logger *slog.Logger,
 	db *database.Database,
+	readOnlyMode *repository.ReadOnlyMode,
 	productService *usecase.ProductUseCase,
-	authService *usecase.AuthUseCase,
+	categoryService *usecase.CategoryUseCase,
+	webhookService *usecase.WebhookUseCase,
+	authService service.AuthService,
+	tokens middleware.TokenParser,
 ) *gin.Engine {
 	// Set Gin mode
 	if cfg.Server.GinMode == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Create router
-	r := gin.Default()
+	// Create router; logging and recovery are added explicitly below
+	r := gin.New()
 
 	// Add middleware
-	r.Use(gin.Logger())
-	r.Use(gin.Recovery())
-	r.Use(corsMiddleware())
+	r.Use(middleware.RequestIDMiddleware())
+	r.Use(middleware.MetricsMiddleware())
+	// Every route gets the same body size limit unless its group overrides it below
+	r.Use(middleware.MaxBodySizeMiddleware(cfg.Server.MaxRequestBodyBytes))
+	r.Use(middleware.LoggingMiddleware(logger))
+	r.Use(middleware.RecoveryMiddleware(logger))
+	// Compression sits inside logging and recovery, so both see the response as the handler wrote it
+	if cfg.Server.Compression.Enabled {
+		r.Use(middleware.GzipMiddleware(cfg.Server.Compression.MinSize, "/swagger/"))
+	}
+	r.Use(middleware.CORSMiddleware(cfg.CORS))
+	// An explicitly configured API key outranks the role of the caller's token
+	r.Use(middleware.TimeoutMiddleware(parseTimeoutTiers(logger, cfg.RequestTimeout), cfg.RequestTimeout.DefaultTier,
+		middleware.FirstTier(
+			middleware.APIKeyTier(cfg.RequestTimeout.APIKeyTiers),
+			middleware.TokenRoleTier(tokens, cfg.RequestTimeout.RoleTiers),
+		)))
 
-	// Health check endpoint
-	r.GET("/health", handler.HealthCheck)
+	// Health check endpoints
+	healthHandler := handler.NewHealthHandler(db)
+	r.GET("/health", healthHandler.HealthCheck)
+	r.GET("/ready", healthHandler.ReadinessCheck)
+	r.GET("/live", healthHandler.LivenessCheck)
 
-	// API v1 routes
-	v1 := r.Group("/api/v1")
-	{
-		// Auth routes (public)
-		auth := v1.Group("/auth")
-		{
-			auth.POST("/login", handler.Login(authService))
-			auth.POST("/register", handler.Register(authService))
-		}
+	// Prometheus metrics endpoint
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
 
-		// Product routes (protected)
-		products := v1.Group("/products")
-		products.Use(authMiddleware(authService))
-		{
-			products.GET("", handler.GetAllProducts(productService))
-			products.GET("/:id", handler.GetProduct(productService))
-			products.POST("", handler.CreateProduct(productService))
-			products.PUT("/:id", handler.UpdateProduct(productService))
-			products.DELETE("/:id", handler.DeleteProduct(productService))
-			products.PATCH("/:id/stock", handler.UpdateProductStock(productService))
-		}
+	// List endpoints page with the configured sizes
+	handler.DefaultPagination = handler.PaginationDefaults{
+		PageSize:    cfg.Pagination.DefaultPageSize,
+		MaxPageSize: cfg.Pagination.MaxPageSize,
+		Headers:     cfg.Pagination.Headers,
+	}
 
-		// User routes (protected)
-		users := v1.Group("/users")
-		users.Use(authMiddleware(authService))
-		{
-			users.GET("/profile", handler.GetUserProfile(authService))
-			users.PUT("/profile", handler.UpdateUserProfile(authService))
-		}
+	deps := routeDeps{
+		cfg:             cfg,
+		logger:          logger,
+		readOnlyMode:    readOnlyMode,
+		productService:  productService,
+		categoryService: categoryService,
+		webhookService:  webhookService,
+		authService:     authService,
+		requireAuth:     middleware.AuthMiddleware(authService),
 	}
 
+	// Each API version registers its own routes; versions share use cases and differ only in
+	// the shape of their request and response bodies
+	registerV1Routes(r.Group("/api/v1", middleware.APIVersionMiddleware(handler.APIVersion1)), deps)
+	registerV2Routes(r.Group("/api/v2", middleware.APIVersionMiddleware(handler.APIVersion2)), deps)
+
 	// Swagger documentation
 	if cfg.Server.GinMode != "release" {
 		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -74,53 +98,235 @@ func SetupRouter(
 	return r
 }
 
-// corsMiddleware adds CORS headers
-func corsMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+// routeDeps holds the services and shared middleware that API versions register routes with
+type routeDeps struct {
+	cfg             *config.Config
+	logger          *slog.Logger
+	readOnlyMode    *repository.ReadOnlyMode
+	productService  *usecase.ProductUseCase
+	categoryService *usecase.CategoryUseCase
+	webhookService  *usecase.WebhookUseCase
+	authService     service.AuthService
+	requireAuth     gin.HandlerFunc
+}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
-			return
-		}
+// registerV1Routes registers the /api/v1 routes on v1
+func registerV1Routes(v1 *gin.RouterGroup, deps routeDeps) {
+	cfg, requireAuth := deps.cfg, deps.requireAuth
+	productService, categoryService, webhookService := deps.productService, deps.categoryService, deps.webhookService
 
-		c.Next()
-	}
-}
+	// Auth routes
+	authHandler := handler.NewAuthHandler(deps.authService)
 
-// authMiddleware validates JWT tokens
-func authMiddleware(authService *usecase.AuthUseCase) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Get token from Authorization header
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
-			return
+	// Forgot-password requests are throttled per client IP here and per email address in
+	// the use case, so the endpoint can't be used to flood inboxes
+	var forgotPasswordLimits []gin.HandlerFunc
+	if cfg.PasswordReset.MaxPerIP > 0 {
+		resetWindow, err := time.ParseDuration(cfg.PasswordReset.Window)
+		if err != nil {
+			fatal(deps.logger, "Invalid password reset window duration", slog.Any("error", err))
 		}
+		forgotPasswordLimits = append(forgotPasswordLimits, middleware.RateLimitMiddleware(cfg.PasswordReset.MaxPerIP, resetWindow, func(c *gin.Context) string {
+			return c.ClientIP()
+		}))
+	}
+
+	auth := v1.Group("/auth")
+	auth.Use(middleware.BodyLimitMiddleware(cfg.Server.BodyLimits.Auth))
+	{
+		auth.POST("/login", authHandler.Login)
+		auth.POST("/register", authHandler.Register)
+		auth.POST("/refresh", authHandler.RefreshToken)
+		auth.GET("/verify", authHandler.VerifyEmail)
+		auth.POST("/resend-verification", authHandler.ResendVerification)
+		auth.POST("/forgot-password", append(forgotPasswordLimits, authHandler.ForgotPassword)...)
+		auth.POST("/reset-password", authHandler.ResetPassword)
+
+		// Routes for the authenticated user
+		auth.POST("/logout", requireAuth, authHandler.Logout)
+		auth.GET("/profile", requireAuth, authHandler.GetProfile)
+		auth.GET("/token/info", requireAuth, authHandler.GetTokenInfo)
+		auth.PUT("/profile", requireAuth, authHandler.UpdateProfile)
+		auth.POST("/change-password", requireAuth, authHandler.ChangePassword)
 
-		// Extract token from "Bearer <token>"
-		if len(authHeader) < 7 || authHeader[:7] != "Bearer " {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-			c.Abort()
-			return
+		// Admin-only user management
+		adminUsers := auth.Group("/users", requireAuth, middleware.AdminMiddleware())
+		{
+			adminUsers.GET("", authHandler.ListUsers)
+			adminUsers.GET("/count", authHandler.CountUsers)
+			adminUsers.GET("/deleted", authHandler.ListDeletedUsers)
+			adminUsers.GET("/:id", authHandler.GetUser)
+			adminUsers.DELETE("/:id", authHandler.DeleteUser)
+			adminUsers.DELETE("/:id/hard", authHandler.HardDeleteUser)
+			adminUsers.POST("/:id/restore", authHandler.RestoreUser)
+			adminUsers.POST("/:id/deactivate", authHandler.DeactivateUser)
+			adminUsers.POST("/:id/reactivate", authHandler.ReactivateUser)
+			adminUsers.PUT("/:id/password", authHandler.SetUserPassword)
+			adminUsers.POST("/bulk-deactivate", authHandler.BulkDeactivateUsers)
 		}
+	}
+
+	// Exports can be huge, so compress them when the client allows it
+	exportHandlers := []gin.HandlerFunc{handler.ExportProducts(productService)}
+	if cfg.Export.GzipEnabled {
+		exportHandlers = append([]gin.HandlerFunc{middleware.GzipMiddleware(0)}, exportHandlers...)
+	}
+
+	// Product import routes (protected), which accept large CSV uploads
+	productImports := v1.Group("/products")
+	productImports.Use(requireAuth, middleware.BodyLimitMiddleware(cfg.Server.BodyLimits.Import))
+	{
+		productImports.POST("/import", handler.ImportProducts(productService))
+		productImports.POST("/prices/import", handler.ImportProductPrices(productService))
+	}
+
+	// Broken image reports are throttled per client IP, so a single client can't flag
+	// products for review on its own
+	var imageReportLimits []gin.HandlerFunc
+	if cfg.Product.ImageReportRateLimit > 0 {
+		imageReportLimits = append(imageReportLimits, middleware.RateLimitMiddleware(cfg.Product.ImageReportRateLimit, time.Minute, func(c *gin.Context) string {
+			return c.ClientIP()
+		}))
+	}
 
-		token := authHeader[7:]
+	// Product routes (protected)
+	products := v1.Group("/products")
+	products.Use(requireAuth)
+	{
+		products.GET("", handler.GetAllProducts(productService))
+		products.GET("/count", handler.CountProducts(productService))
+		products.GET("/stats", handler.GetProductStats(productService))
+		products.GET("/search", handler.SearchProducts(productService))
+		products.GET("/low-stock", handler.GetLowStockProducts(productService))
+		products.GET("/image-reports", middleware.AdminMiddleware(), handler.GetImageReports(productService))
+		products.GET("/export", exportHandlers...)
+		products.GET("/import/:job_id", handler.GetImportJob(productService))
+		products.GET("/imports", middleware.AdminMiddleware(), handler.ListActiveImports(productService))
+		products.GET("/deleted", middleware.AdminMiddleware(), handler.ListDeletedProducts(productService))
+		products.GET("/sku/:sku", handler.GetProductBySKU(productService))
+		products.GET("/category/:category/price-sheet", middleware.AdminMiddleware(), handler.ExportCategoryPriceSheet(productService, cfg.Export.PriceSheetEmptyNotFound))
+		products.GET("/:id", handler.GetProduct(productService))
+		products.POST("", handler.CreateProduct(productService))
+		products.POST("/validate", handler.ValidateProduct(productService))
+		products.POST("/bulk", handler.BulkCreateProducts(productService))
+		products.POST("/batch", handler.GetProductsBatch(productService, cfg.Product.MaxBatchIDs))
+		products.PATCH("/status", handler.BulkUpdateProductStatus(productService))
+		products.PUT("/:id", handler.UpdateProduct(productService))
+		products.PATCH("/:id", handler.PatchProduct(productService))
+		products.DELETE("/:id", handler.DeleteProduct(productService))
+		products.GET("/:id/references", handler.GetProductReferences(productService))
+		products.POST("/:id/images", handler.AddProductImage(productService))
+		products.PATCH("/:id/images", handler.ReorderProductImages(productService))
+		products.DELETE("/:id/images/:image_id", handler.DeleteProductImage(productService))
+		products.PUT("/:id/tags", handler.SetProductTags(productService))
+		products.POST("/:id/report-broken-image", append(imageReportLimits, handler.ReportBrokenImage(productService))...)
+		products.DELETE("/:id/image-reports", middleware.AdminMiddleware(), handler.ClearImageReports(productService))
+		products.GET("/:id/history", middleware.AdminMiddleware(), handler.GetProductHistory(productService))
+		products.GET("/:id/price-history", handler.GetProductPriceHistory(productService))
+		products.GET("/:id/reservations", middleware.AdminMiddleware(), handler.GetProductReservations(productService))
+		products.POST("/:id/reservations", handler.ReserveProductStock(productService))
+		products.GET("/:id/diff", middleware.AdminMiddleware(), handler.GetProductDiff(productService))
+		products.PATCH("/:id/stock", handler.UpdateProductStock(productService))
+		products.POST("/:id/stock/decrement", handler.DecrementProductStock(productService))
+		products.POST("/:id/stock/increment", handler.IncrementProductStock(productService))
+	}
+
+	// Category routes (protected)
+	categories := v1.Group("/categories")
+	categories.Use(requireAuth)
+	{
+		categories.GET("", handler.ListCategories(categoryService))
+		categories.GET("/tree", handler.GetCategoryTree(categoryService))
+		categories.GET("/:id", handler.GetCategory(categoryService))
+		categories.POST("", handler.CreateCategory(categoryService))
+		categories.PUT("/:id", handler.UpdateCategory(categoryService))
+		categories.DELETE("/:id", handler.DeleteCategory(categoryService))
+	}
+
+	// Webhook routes (admin only)
+	webhooks := v1.Group("/webhooks")
+	webhooks.Use(requireAuth, middleware.AdminMiddleware())
+	{
+		webhooks.GET("", handler.ListWebhooks(webhookService))
+		webhooks.GET("/:id", handler.GetWebhook(webhookService))
+		webhooks.GET("/:id/deliveries", handler.ListWebhookDeliveries(webhookService))
+		webhooks.POST("", handler.CreateWebhook(webhookService))
+		webhooks.PUT("/:id", handler.UpdateWebhook(webhookService))
+		webhooks.DELETE("/:id", handler.DeleteWebhook(webhookService))
+	}
+
+	// Admin routes (protected)
+	admin := v1.Group("/admin")
+	admin.Use(requireAuth, middleware.AdminMiddleware())
+	{
+		admin.GET("/read-only", handler.GetReadOnlyMode(deps.readOnlyMode))
+		admin.PUT("/read-only", handler.SetReadOnlyMode(deps.readOnlyMode))
+		admin.POST("/reindex-search", handler.StartSearchReindex(deps.productService))
+		admin.GET("/reindex-search", handler.GetSearchReindexStatus(deps.productService))
+	}
 
-		// Validate token (this would need to be implemented in the auth service)
-		// For now, we'll just check if the token exists
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
+	// Public partner feed, kept apart from the product routes so it only ever serves the
+	// reduced PublicProduct view; it is off unless API keys are configured
+	if len(cfg.PublicFeed.APIKeys) > 0 {
+		cacheMaxAge, err := time.ParseDuration(cfg.PublicFeed.CacheMaxAge)
+		if err != nil {
+			fatal(deps.logger, "Invalid public feed cache max age duration", slog.Any("error", err))
 		}
 
-		// Add user info to context if needed
-		// c.Set("user", user)
+		public := v1.Group("/public")
+		public.Use(
+			middleware.APIKeyMiddleware(cfg.PublicFeed.APIKeys),
+			middleware.RateLimitMiddleware(cfg.PublicFeed.RateLimit, time.Minute, func(c *gin.Context) string {
+				return c.GetString(middleware.APIKeyContextKey)
+			}),
+		)
+		{
+			public.GET("/products", handler.GetPublicProducts(productService, cacheMaxAge))
+		}
+	}
+}
 
-		c.Next()
+// registerV2Routes registers the /api/v2 routes on v2. v2 serves the same use cases as v1 with
+// camelCase field names; routes not yet ported are only available under v1.
+func registerV2Routes(v2 *gin.RouterGroup, deps routeDeps) {
+	products := v2.Group("/products")
+	products.Use(deps.requireAuth)
+	{
+		products.GET("", handler.GetAllProducts(deps.productService))
+		products.GET("/:id", handler.GetProduct(deps.productService))
+	}
+}
+
+// parseTimeoutTiers parses the configured timeout of each client tier, failing fast on an
+// invalid duration or a default tier without a timeout
+func parseTimeoutTiers(logger *slog.Logger, cfg config.RequestTimeoutConfig) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(cfg.Tiers))
+	for tier, value := range cfg.Tiers {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			fatal(logger, "Invalid request timeout: must be a positive duration", slog.String("tier", tier), slog.String("timeout", value))
+		}
+		timeouts[tier] = timeout
+	}
+	if _, ok := timeouts[cfg.DefaultTier]; !ok {
+		fatal(logger, "Invalid default request timeout tier: no timeout is configured for it", slog.String("tier", cfg.DefaultTier))
 	}
+	for _, tier := range cfg.APIKeyTiers {
+		if _, ok := timeouts[tier]; !ok {
+			fatal(logger, "Invalid request timeout tier for an API key: no timeout is configured for it", slog.String("tier", tier))
+		}
+	}
+	for role, tier := range cfg.RoleTiers {
+		if _, ok := timeouts[tier]; !ok {
+			fatal(logger, "Invalid request timeout tier for a token role: no timeout is configured for it", slog.String("role", role), slog.String("tier", tier))
+		}
+	}
+	return timeouts
+}
+
+// fatal logs msg at error level and exits. The router is only set up on startup, where an
+// invalid configuration must stop the server rather than serve with a guessed default.
+func fatal(logger *slog.Logger, msg string, attrs ...any) {
+	logger.Error(msg, attrs...)
+	os.Exit(1)
 }
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/product-management/internal/config"
+)
+
+// newTestRouter returns the router for the default configuration with the public feed enabled
+// and small body limits. No services are wired, so only requests rejected by middleware can be
+// served.
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	cfg := config.LoadConfig()
+	cfg.PublicFeed.APIKeys = []string{"partner-key"}
+	cfg.Server.MaxRequestBodyBytes = 1 << 10
+	cfg.Server.BodyLimits = config.BodyLimitConfig{Auth: 256, Import: 4 << 10}
+	return SetupRouter(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), nil, nil, nil, nil, nil, nil, nil)
+}
+
+func TestRouteGroupsCapRequestBodies(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name, method, target string
+		size                 int
+	}{
+		{"auth login", http.MethodPost, "/api/v1/auth/login", 257},
+		{"auth register", http.MethodPost, "/api/v1/auth/register", 257},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(strings.Repeat("x", tt.size)))
+			req.Header.Set("X-API-Key", "partner-key")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != http.StatusRequestEntityTooLarge {
+				t.Errorf("got status %d, want %d", recorder.Code, http.StatusRequestEntityTooLarge)
+			}
+		})
+	}
+}
+
+func TestAdminRoutesAnswerUnauthenticatedRequestsWith401(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, target := range []string{"/api/v1/products/deleted", "/api/v1/webhooks", "/api/v1/auth/users"} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if recorder.Code != http.StatusUnauthorized || recorder.Header().Get("WWW-Authenticate") != "Bearer" {
+			t.Errorf("%s: got status %d and WWW-Authenticate %q, want 401 with a Bearer challenge", target, recorder.Code, recorder.Header().Get("WWW-Authenticate"))
+		}
+	}
+}
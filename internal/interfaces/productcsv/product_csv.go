@@ -0,0 +1,263 @@
+// Package productcsv encodes and decodes products as CSV for bulk export and import
+package productcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// Header is the column layout written by Writer
+var Header = []string{"id", "name", "description", "price", "stock", "category", "image_url", "is_active", "created_at", "updated_at"}
+
+// ErrMissingColumn is returned when an import file lacks a required column
+var ErrMissingColumn = errors.New("missing required column")
+
+// Writer writes products as CSV rows
+type Writer struct {
+	w *csv.Writer
+}
+
+// NewWriter creates a new product CSV writer
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: csv.NewWriter(w)}
+}
+
+// WriteHeader writes the header row
+func (w *Writer) WriteHeader() error {
+	return w.w.Write(Header)
+}
+
+// Write writes a single product row
+func (w *Writer) Write(p *entity.Product) error {
+	return w.w.Write([]string{
+		strconv.FormatUint(uint64(p.ID), 10),
+		p.Name,
+		p.Description,
+		strconv.FormatFloat(p.Price, 'f', 2, 64),
+		strconv.Itoa(p.Stock),
+		p.Category,
+		p.ImageURL,
+		strconv.FormatBool(p.IsActive),
+		p.CreatedAt.UTC().Format(time.RFC3339),
+		p.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// Flush writes any buffered data and returns the first write error, if any
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// PriceSheetHeader is the column layout written by PriceSheetWriter
+var PriceSheetHeader = []string{"sku", "name", "price", "stock"}
+
+// PriceSheetWriter writes products as the rows of a pricing-oriented price sheet
+type PriceSheetWriter struct {
+	w *csv.Writer
+}
+
+// NewPriceSheetWriter creates a new price sheet CSV writer
+func NewPriceSheetWriter(w io.Writer) *PriceSheetWriter {
+	return &PriceSheetWriter{w: csv.NewWriter(w)}
+}
+
+// WriteHeader writes the header row
+func (w *PriceSheetWriter) WriteHeader() error {
+	return w.w.Write(PriceSheetHeader)
+}
+
+// Write writes a single product row; products without a SKU get an empty sku cell
+func (w *PriceSheetWriter) Write(p *entity.Product) error {
+	sku := ""
+	if p.SKU != nil {
+		sku = *p.SKU
+	}
+	return w.w.Write([]string{
+		sku,
+		p.Name,
+		strconv.FormatFloat(p.Price, 'f', 2, 64),
+		strconv.Itoa(p.Stock),
+	})
+}
+
+// Flush writes any buffered data and returns the first write error, if any
+func (w *PriceSheetWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// Reader reads product import rows from CSV. Columns are matched by header name, so
+// files exported by Writer can be re-imported directly; unknown columns are ignored.
+type Reader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+// NewReader creates a new product CSV reader and consumes the header row
+func NewReader(r io.Reader) (*Reader, error) {
+	return newColumnReader(r, "name", "price")
+}
+
+// newColumnReader consumes the header row and checks the required columns are present
+func newColumnReader(r io.Reader, required ...string) (*Reader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range required {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, column)
+		}
+	}
+
+	return &Reader{r: cr, columns: columns}, nil
+}
+
+// ReadAll reads every remaining row. Malformed rows don't abort the read; they are
+// returned as row errors alongside the rows that parsed successfully.
+func (r *Reader) ReadAll() ([]*service.ProductImportRow, []service.ImportRowError) {
+	var rows []*service.ProductImportRow
+	var rowErrors []service.ImportRowError
+
+	_ = r.each(func(line int, record []string) error {
+		row, err := r.parseRecord(record)
+		if err != nil {
+			return err
+		}
+		row.Line = line
+		rows = append(rows, row)
+		return nil
+	}, func(rowErr service.ImportRowError) {
+		rowErrors = append(rowErrors, rowErr)
+	})
+
+	return rows, rowErrors
+}
+
+// each calls fn for every remaining record. Records that are malformed or rejected by fn
+// are passed to onError instead; only an I/O error stops the iteration and is returned.
+func (r *Reader) each(fn func(line int, record []string) error, onError func(service.ImportRowError)) error {
+	for {
+		record, err := r.r.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				// A bare I/O error can't be skipped past
+				onError(service.ImportRowError{Reason: err.Error()})
+				return err
+			}
+			onError(service.ImportRowError{Line: parseErr.StartLine, Reason: err.Error()})
+			continue
+		}
+
+		line, _ := r.r.FieldPos(0)
+		if err := fn(line, record); err != nil {
+			onError(service.ImportRowError{Line: line, Reason: err.Error()})
+		}
+	}
+}
+
+// parseRecord converts a raw CSV record into an import row
+func (r *Reader) parseRecord(record []string) (*service.ProductImportRow, error) {
+	row := &service.ProductImportRow{
+		Name:        r.field(record, "name"),
+		Description: r.field(record, "description"),
+		Category:    r.field(record, "category"),
+		ImageURL:    r.field(record, "image_url"),
+	}
+
+	price, err := strconv.ParseFloat(r.field(record, "price"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price %q", r.field(record, "price"))
+	}
+	row.Price = price
+
+	if stock := r.field(record, "stock"); stock != "" {
+		row.Stock, err = strconv.Atoi(stock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stock %q", stock)
+		}
+	}
+
+	if isActive := r.field(record, "is_active"); isActive != "" {
+		active, err := strconv.ParseBool(isActive)
+		if err != nil {
+			return nil, fmt.Errorf("invalid is_active %q", isActive)
+		}
+		row.IsActive = &active
+	}
+
+	return row, nil
+}
+
+// field returns the trimmed value of the named column, or "" when absent
+func (r *Reader) field(record []string, name string) string {
+	i, ok := r.columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// ReadPriceUpdates reads a CSV of price changes with a price column and a sku or id column
+// naming the product. A row with both is matched by its SKU. Rows that can't be parsed are
+// returned as row errors rather than aborting the read.
+func ReadPriceUpdates(r io.Reader) ([]*service.PriceUpdateRow, []service.ImportRowError, error) {
+	reader, err := newColumnReader(r, "price")
+	if err != nil {
+		return nil, nil, err
+	}
+	_, hasSKU := reader.columns["sku"]
+	_, hasID := reader.columns["id"]
+	if !hasSKU && !hasID {
+		return nil, nil, fmt.Errorf("%w: sku or id", ErrMissingColumn)
+	}
+
+	var rows []*service.PriceUpdateRow
+	var rowErrors []service.ImportRowError
+	err = reader.each(func(line int, record []string) error {
+		row := &service.PriceUpdateRow{Line: line, SKU: reader.field(record, "sku")}
+		if row.SKU == "" {
+			id, err := strconv.ParseUint(reader.field(record, "id"), 10, 64)
+			if err != nil || id == 0 {
+				return fmt.Errorf("invalid id %q", reader.field(record, "id"))
+			}
+			row.ID = uint(id)
+		}
+
+		price, err := strconv.ParseFloat(reader.field(record, "price"), 64)
+		if err != nil {
+			return fmt.Errorf("invalid price %q", reader.field(record, "price"))
+		}
+		row.Price = price
+
+		rows = append(rows, row)
+		return nil
+	}, func(rowErr service.ImportRowError) {
+		rowErrors = append(rowErrors, rowErr)
+	})
+
+	return rows, rowErrors, err
+}
@@ -0,0 +1,40 @@
+package productcsv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadPriceUpdatesBySKU(t *testing.T) {
+	rows, rowErrors, err := ReadPriceUpdates(strings.NewReader("sku,price\nLAMP-01,19.99\n,5\nMUG-02,abc\n"))
+	if err != nil {
+		t.Fatalf("ReadPriceUpdates: %v", err)
+	}
+	if len(rows) != 1 || rows[0].SKU != "LAMP-01" || rows[0].Price != 19.99 || rows[0].ID != 0 {
+		t.Fatalf("got rows %+v, want one LAMP-01 row at 19.99", rows)
+	}
+	if len(rowErrors) != 2 || rowErrors[0].Line != 3 || rowErrors[1].Line != 4 {
+		t.Errorf("got row errors %+v, want lines 3 and 4", rowErrors)
+	}
+}
+
+func TestReadPriceUpdatesPrefersSKUOverID(t *testing.T) {
+	rows, rowErrors, err := ReadPriceUpdates(strings.NewReader("id,sku,price\n7,LAMP-01,10\n8,,12\n"))
+	if err != nil || len(rowErrors) != 0 {
+		t.Fatalf("ReadPriceUpdates: %v %+v", err, rowErrors)
+	}
+	if rows[0].SKU != "LAMP-01" || rows[0].ID != 0 {
+		t.Errorf("got row %+v, want it matched by SKU", rows[0])
+	}
+	if rows[1].SKU != "" || rows[1].ID != 8 {
+		t.Errorf("got row %+v, want it matched by ID 8", rows[1])
+	}
+}
+
+func TestReadPriceUpdatesNeedsSKUOrID(t *testing.T) {
+	_, _, err := ReadPriceUpdates(strings.NewReader("name,price\nLamp,10\n"))
+	if !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("got %v, want %v", err, ErrMissingColumn)
+	}
+}
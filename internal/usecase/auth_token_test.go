@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/infrastructure/cache"
+	"github.com/product-management/pkg/jwt"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTokenAuthUseCase returns an auth use case issuing HS256 tokens, with an active admin as
+// user 1 and an inactive user as user 2
+func newTokenAuthUseCase(t *testing.T) (*AuthUseCase, *jwt.TokenManager) {
+	t.Helper()
+	key, err := jwt.NewHMACKey("test-secret-that-is-long-enough-for-hs256")
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	tokens := jwt.NewTokenManager(key, time.Hour, 24*time.Hour, 0, "", "")
+	users := newFakeUserRepo(
+		&entity.User{Email: "admin@example.com", Username: "admin", IsActive: true, IsAdmin: true},
+		&entity.User{Email: "gone@example.com", Username: "gone", IsActive: false},
+	)
+	revoked := cache.NewTokenRevocationList(cache.NewMemoryCache())
+	uc := NewAuthUseCase(users, &fakeAuditRepo{}, tokens, fakeTxManager{}, EmailVerification{}, PasswordReset{}, bcrypt.MinCost, PasswordPolicies{}, revoked, nopEventLogger{}, ProfileLimits{}, nil)
+	return uc, tokens
+}
+
+func TestValidateTokenReturnsTheUsersClaims(t *testing.T) {
+	uc, tokens := newTokenAuthUseCase(t)
+	token, _ := tokens.GenerateToken(adminID, "admin@example.com", "admin")
+
+	claims, err := uc.ValidateToken(context.Background(), token)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if claims.UserID != adminID || claims.Username != "admin" || !claims.IsAdmin || claims.Role != "admin" || claims.TokenID == "" {
+		t.Errorf("got %+v, want the admin's claims with the token's role and ID", claims)
+	}
+}
+
+func TestValidateTokenRejectsUnusableTokens(t *testing.T) {
+	uc, tokens := newTokenAuthUseCase(t)
+	refresh, _ := tokens.GenerateRefreshToken(adminID, "admin@example.com", "admin")
+	inactive, _ := tokens.GenerateToken(2, "gone@example.com", "user")
+	unknown, _ := tokens.GenerateToken(99, "nobody@example.com", "user")
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"malformed", "not-a-token", entity.ErrInvalidToken},
+		{"refresh token", refresh, entity.ErrInvalidToken},
+		{"unknown user", unknown, entity.ErrInvalidToken},
+		{"inactive user", inactive, entity.ErrUserInactive},
+	}
+	for _, tt := range tests {
+		if _, err := uc.ValidateToken(context.Background(), tt.token); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
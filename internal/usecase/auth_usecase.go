@@ -3,102 +3,618 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/product-management/internal/domain/entity"
 	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/domain/service"
 	"github.com/product-management/pkg/jwt"
 )
 
 // AuthUseCase handles authentication business logic
 type AuthUseCase struct {
-	userRepo     repository.UserRepository
-	tokenManager *jwt.TokenManager
+	userRepo          repository.UserRepository
+	auditRepo         repository.AuditLogRepository
+	tokenManager      *jwt.TokenManager
+	txManager         repository.TxManager
+	emailVerification EmailVerification
+	passwordReset     PasswordReset
+	resetThrottle     *requestThrottle
+	passwordCost      int
+	passwords         PasswordPolicies
+	revokedTokens     service.TokenRevocationList
+	events            service.EventLogger
+	profile           ProfileLimits
+	logger            *slog.Logger
 }
 
-// NewAuthUseCase creates a new auth use case
-func NewAuthUseCase(userRepo repository.UserRepository, tokenManager *jwt.TokenManager) *AuthUseCase {
+// ProfileLimits configures the validation of profile updates
+type ProfileLimits struct {
+	MaxNameLength int // longest first or last name allowed, in characters
+}
+
+// NewAuthUseCase creates a new auth use case logging operational messages to logger, or the
+// default logger if it is nil
+func NewAuthUseCase(userRepo repository.UserRepository, auditRepo repository.AuditLogRepository, tokenManager *jwt.TokenManager, txManager repository.TxManager, emailVerification EmailVerification, passwordReset PasswordReset, passwordCost int, passwords PasswordPolicies, revokedTokens service.TokenRevocationList, events service.EventLogger, profile ProfileLimits, logger *slog.Logger) *AuthUseCase {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &AuthUseCase{
-		userRepo:     userRepo,
-		tokenManager: tokenManager,
+		userRepo:          userRepo,
+		auditRepo:         auditRepo,
+		tokenManager:      tokenManager,
+		txManager:         txManager,
+		emailVerification: emailVerification,
+		passwordReset:     passwordReset,
+		resetThrottle:     newRequestThrottle(passwordReset.MaxPerEmail, passwordReset.Window),
+		passwordCost:      passwordCost,
+		passwords:         passwords,
+		revokedTokens:     revokedTokens,
+		events:            events,
+		profile:           profile,
+		logger:            logger,
 	}
 }
 
-// LoginRequest represents login request data
-type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
-}
+// Register creates a new user account, records it as logged in and returns its tokens. When
+// email verification is required a verification link is sent instead and no tokens are
+// returned; the account can't log in until its email is verified.
+func (uc *AuthUseCase) Register(ctx context.Context, req *service.RegisterRequest) (*service.AuthResponse, error) {
+	req.Email = normalizeEmail(req.Email)
+	req.Username = strings.TrimSpace(req.Username)
+	req.FirstName = strings.TrimSpace(req.FirstName)
+	req.LastName = strings.TrimSpace(req.LastName)
+
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	// Create user
+	user := &entity.User{
+		Email:     req.Email,
+		Username:  req.Username,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		IsActive:  true,
+	}
+
+	if err := uc.passwords.check("password", req.Password, user.IsAdmin); err != nil {
+		return nil, err
+	}
+
+	// Hash password
+	if err := user.HashPassword(req.Password, uc.passwordCost); err != nil {
+		return nil, err
+	}
+
+	var verificationToken string
+	if uc.emailVerification.Required {
+		token, err := uc.issueVerificationToken(user)
+		if err != nil {
+			return nil, err
+		}
+		verificationToken = token
+	}
+
+	// Creating the account and stamping the first login must succeed or fail together
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		emailTaken, err := uc.userRepo.ExistsByEmail(ctx, user.Email)
+		if err != nil {
+			return err
+		}
+		if emailTaken {
+			return entity.ErrEmailAlreadyExists
+		}
+
+		// Usernames keep their casing for display but must be unique case-insensitively
+		usernameTaken, err := uc.userRepo.ExistsByUsername(ctx, user.Username)
+		if err != nil {
+			return err
+		}
+		if usernameTaken {
+			return entity.ErrUsernameAlreadyExists
+		}
 
-// LoginResponse represents login response data
-type LoginResponse struct {
-	Token string      `json:"token"`
-	User  *entity.User `json:"user"`
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+
+		// Unverified accounts stay active; Login turns them away until the email is verified
+		if uc.emailVerification.Required {
+			return nil
+		}
+
+		return uc.userRepo.UpdateLastLogin(ctx, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	logEvent(ctx, uc.events, service.EventUserRegistered, entity.AuditEntityUser, user.ID, map[string]interface{}{
+		"verification_required": uc.emailVerification.Required,
+	})
+
+	if verificationToken != "" {
+		if err := uc.sendVerificationEmail(ctx, user, verificationToken); err != nil {
+			// The account exists either way; the user can request another link
+			uc.logger.WarnContext(ctx, "Could not send verification email", slog.Uint64("user_id", uint64(user.ID)), slog.Any("error", err))
+		}
+		return &service.AuthResponse{User: user}, nil
+	}
+
+	token, err := uc.GenerateToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.AuthResponse{
+		User:  user,
+		Token: token,
+	}, nil
 }
 
-// Login authenticates a user and returns a JWT token
-func (uc *AuthUseCase) Login(req *LoginRequest) (*LoginResponse, error) {
+// Login authenticates a user and returns its tokens
+func (uc *AuthUseCase) Login(ctx context.Context, req *service.LoginRequest) (*service.AuthResponse, error) {
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
 	// Find user by email
-	user, err := uc.userRepo.GetByEmail(context.Background(), req.Email)
+	user, err := uc.userRepo.GetByEmail(ctx, normalizeEmail(req.Email))
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		if errors.Is(err, entity.ErrUserNotFound) {
+			uc.logLoginFailed(ctx, 0, "unknown_email")
+			return nil, entity.ErrInvalidCredentials
+		}
+		return nil, err
 	}
 
 	// Check password
 	if err := user.CheckPassword(req.Password); err != nil {
-		return nil, errors.New("invalid credentials")
+		uc.logLoginFailed(ctx, user.ID, "wrong_password")
+		return nil, entity.ErrInvalidCredentials
+	}
+
+	// Upgrade hashes made with a lower cost while the plain password is at hand
+	if user.NeedsRehash(uc.passwordCost) {
+		uc.rehashPassword(ctx, user, req.Password)
 	}
 
+	// Only checked once the password matches, so it doesn't reveal which emails are registered
+	if uc.emailVerification.Required && !user.EmailVerified {
+		uc.logLoginFailed(ctx, user.ID, "email_not_verified")
+		return nil, entity.ErrEmailNotVerified
+	}
+
+	// Deactivated accounts, e.g. offboarded users, can't obtain new tokens
+	if !user.IsActive {
+		uc.logLoginFailed(ctx, user.ID, "inactive")
+		return nil, entity.ErrUserInactive
+	}
+
+	token, err := uc.GenerateToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	logEvent(ctx, uc.events, service.EventUserLoggedIn, entity.AuditEntityUser, user.ID, nil)
+
+	return &service.AuthResponse{
+		User:  user,
+		Token: token,
+	}, nil
+}
+
+// logLoginFailed logs a rejected login attempt; userID is zero when no account matched
+func (uc *AuthUseCase) logLoginFailed(ctx context.Context, userID uint, reason string) {
+	logEvent(ctx, uc.events, service.EventUserLoginFailed, entity.AuditEntityUser, userID, map[string]interface{}{
+		"reason": reason,
+	})
+}
+
+// GenerateToken issues a new access and refresh token pair for user
+func (uc *AuthUseCase) GenerateToken(ctx context.Context, user *entity.User) (*service.TokenResponse, error) {
 	// Determine role based on IsAdmin field
 	role := "user"
 	if user.IsAdmin {
 		role = "admin"
 	}
 
-	// Generate JWT token
-	token, err := uc.tokenManager.GenerateToken(user.ID, user.Email, role)
+	accessToken, err := uc.tokenManager.GenerateToken(user.ID, user.Email, role)
 	if err != nil {
 		return nil, err
 	}
 
-	return &LoginResponse{
-		Token: token,
-		User:  user,
+	refreshToken, err := uc.tokenManager.GenerateRefreshToken(user.ID, user.Email, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(uc.tokenManager.ExpiresIn().Seconds()),
+		RefreshToken: refreshToken,
 	}, nil
 }
 
-// RegisterRequest represents registration request data
-type RegisterRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
-	Name     string `json:"name" binding:"required"`
-	Role     string `json:"role"`
-}
+// ValidateToken validates an access token and returns the claims of the user it was issued to.
+// The user is reloaded so deleted or deactivated accounts and revoked admin rights take effect
+// before the token expires.
+func (uc *AuthUseCase) ValidateToken(ctx context.Context, token string) (*service.Claims, error) {
+	tokenClaims, err := uc.parseToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if tokenClaims.IsRefresh() {
+		return nil, entity.ErrInvalidToken
+	}
 
-// Register creates a new user account
-func (uc *AuthUseCase) Register(req *RegisterRequest) (*entity.User, error) {
-	// Check if user already exists
-	existingUser, _ := uc.userRepo.GetByEmail(context.Background(), req.Email)
-	if existingUser != nil {
-		return nil, errors.New("user already exists")
+	user, err := uc.activeTokenUser(ctx, tokenClaims)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create user
-	user := &entity.User{
-		Email:     req.Email,
-		Username:  req.Name, // Use Name as Username
-		FirstName: req.Name, // Use Name as FirstName
-		IsActive:  true,
-		IsAdmin:   req.Role == "admin",
+	claims := &service.Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		IsAdmin:  user.IsAdmin,
+
+		Role:    tokenClaims.Role,
+		TokenID: tokenClaims.ID,
 	}
+	if tokenClaims.IssuedAt != nil {
+		claims.IssuedAt = tokenClaims.IssuedAt.Time
+	}
+	if tokenClaims.ExpiresAt != nil {
+		claims.ExpiresAt = tokenClaims.ExpiresAt.Time
+	}
+	return claims, nil
+}
 
-	// Hash password
-	if err := user.HashPassword(req.Password); err != nil {
+// RefreshToken exchanges a refresh token for a new token pair. The refresh token is revoked,
+// so each one can only be used once.
+func (uc *AuthUseCase) RefreshToken(ctx context.Context, refreshToken string) (*service.TokenResponse, error) {
+	tokenClaims, err := uc.parseToken(ctx, refreshToken)
+	if err != nil {
 		return nil, err
 	}
+	if !tokenClaims.IsRefresh() {
+		return nil, entity.ErrInvalidToken
+	}
 
-	if err := uc.userRepo.Create(context.Background(), user); err != nil {
+	user, err := uc.activeTokenUser(ctx, tokenClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.revokedTokens.Revoke(ctx, tokenClaims.ID, tokenClaims.ExpiresAt.Time); err != nil {
 		return nil, err
 	}
 
+	return uc.GenerateToken(ctx, user)
+}
+
+// RevokeToken revokes an access or refresh token until it expires (logout)
+func (uc *AuthUseCase) RevokeToken(ctx context.Context, token string) error {
+	tokenClaims, err := uc.parseToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return uc.revokedTokens.Revoke(ctx, tokenClaims.ID, tokenClaims.ExpiresAt.Time)
+}
+
+// parseToken validates token's signature and times and checks it hasn't been revoked
+func (uc *AuthUseCase) parseToken(ctx context.Context, token string) (*jwt.Claims, error) {
+	tokenClaims, err := uc.tokenManager.ValidateToken(token)
+	if err != nil {
+		return nil, entity.ErrInvalidToken
+	}
+
+	// Tokens issued before token IDs were added can't be revoked individually
+	if tokenClaims.ID != "" {
+		revoked, err := uc.revokedTokens.IsRevoked(ctx, tokenClaims.ID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, entity.ErrInvalidToken
+		}
+	}
+
+	return tokenClaims, nil
+}
+
+// activeTokenUser loads the user a token was issued to, which must still exist and be active
+func (uc *AuthUseCase) activeTokenUser(ctx context.Context, tokenClaims *jwt.Claims) (*entity.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, tokenClaims.UserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil, entity.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, entity.ErrUserInactive
+	}
 	return user, nil
 }
+
+// GetUserByID retrieves a user by their ID
+func (uc *AuthUseCase) GetUserByID(ctx context.Context, id uint) (*entity.User, error) {
+	return uc.userRepo.GetByID(ctx, id)
+}
+
+// GetUserProfile gets the profile of the user with the given ID
+func (uc *AuthUseCase) GetUserProfile(ctx context.Context, userID uint) (*entity.User, error) {
+	return uc.userRepo.GetByID(ctx, userID)
+}
+
+// UpdateProfile applies the first_name, last_name and username values in updates to the user.
+// Every field is checked before anything is saved and all violations are reported together:
+// unknown keys, names longer than the configured limit and usernames breaking the registration
+// rules. A new username must not be taken by another user, ignoring case.
+func (uc *AuthUseCase) UpdateProfile(ctx context.Context, userID uint, updates map[string]interface{}) (*entity.User, error) {
+	values, err := uc.validateProfileUpdates(updates)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *entity.User
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if firstName, ok := values["first_name"]; ok {
+			user.FirstName = firstName
+		}
+		if lastName, ok := values["last_name"]; ok {
+			user.LastName = lastName
+		}
+		if username, ok := values["username"]; ok {
+			// Changing only the casing of one's own username is allowed
+			if !strings.EqualFold(username, user.Username) {
+				taken, err := uc.userRepo.ExistsByUsername(ctx, username)
+				if err != nil {
+					return err
+				}
+				if taken {
+					return entity.ErrUsernameAlreadyExists
+				}
+			}
+			user.Username = username
+		}
+
+		if err := user.Validate(); err != nil {
+			return err
+		}
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		updated = user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// validateProfileUpdates checks every profile update and returns the trimmed values by field,
+// or an *entity.ValidationError listing all violations
+func (uc *AuthUseCase) validateProfileUpdates(updates map[string]interface{}) (map[string]string, error) {
+	values := make(map[string]string, len(updates))
+	validationErr := &entity.ValidationError{}
+	violate := func(field, rule, message string) {
+		validationErr.Violations = append(validationErr.Violations, entity.FieldViolation{Field: field, Rule: rule, Message: message})
+	}
+
+	for field, value := range updates {
+		str, ok := value.(string)
+		if !ok {
+			violate(field, "string", field+" must be a string")
+			continue
+		}
+		str = strings.TrimSpace(str)
+
+		switch field {
+		case "first_name", "last_name":
+			if utf8.RuneCountInString(str) > uc.profile.MaxNameLength {
+				violate(field, "max", fmt.Sprintf("%s must be at most %d characters", field, uc.profile.MaxNameLength))
+				continue
+			}
+		case "username":
+			if err := validate.Var(str, "required,min=3,max=50,username"); err != nil {
+				var validationErrs validator.ValidationErrors
+				if errors.As(err, &validationErrs) {
+					for _, fe := range validationErrs {
+						violate(field, fe.Tag(), fieldViolationMessage(field, fe))
+					}
+					continue
+				}
+				return nil, err
+			}
+		default:
+			violate(field, "unknown", field+" is not a profile field")
+			continue
+		}
+		values[field] = str
+	}
+
+	if len(validationErr.Violations) > 0 {
+		// Map iteration order is random, so sort for a stable response
+		sort.Slice(validationErr.Violations, func(i, j int) bool {
+			return validationErr.Violations[i].Field < validationErr.Violations[j].Field
+		})
+		return nil, validationErr
+	}
+	return values, nil
+}
+
+// ChangePassword replaces the user's password after checking the current one
+func (uc *AuthUseCase) ChangePassword(ctx context.Context, userID uint, req *service.PasswordChangeRequest) error {
+	if err := validateStruct(req); err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := user.CheckPassword(req.CurrentPassword); err != nil {
+		return entity.ErrInvalidCredentials
+	}
+
+	if err := uc.passwords.check("new_password", req.NewPassword, user.IsAdmin); err != nil {
+		return err
+	}
+
+	if err := user.HashPassword(req.NewPassword, uc.passwordCost); err != nil {
+		return err
+	}
+	return uc.userRepo.UpdatePassword(ctx, user.ID, user.Password)
+}
+
+// SetUserPassword replaces a user's password on an admin's behalf, applying the admin password
+// policy when the user is an admin
+func (uc *AuthUseCase) SetUserPassword(ctx context.Context, id uint, password string) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.passwords.check("password", password, user.IsAdmin); err != nil {
+			return err
+		}
+		if err := user.HashPassword(password, uc.passwordCost); err != nil {
+			return err
+		}
+		if err := uc.userRepo.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+			return err
+		}
+
+		return uc.auditRepo.Create(ctx, newUserAuditLog(ctx, id, entity.AuditActionSetPassword, nil))
+	})
+}
+
+// rehashPassword stores password hashed with the configured cost. Failures are only logged,
+// the old hash keeps working and the upgrade is retried on the next login.
+func (uc *AuthUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	if err := user.HashPassword(password, uc.passwordCost); err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to rehash password", slog.Uint64("user_id", uint64(user.ID)), slog.Any("error", err))
+		return
+	}
+	if err := uc.userRepo.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to store rehashed password", slog.Uint64("user_id", uint64(user.ID)), slog.Any("error", err))
+	}
+}
+
+// ListUsers retrieves a paginated list of users with filtering
+func (uc *AuthUseCase) ListUsers(ctx context.Context, filter *repository.UserFilter, page, pageSize int) (*service.UserListResponse, error) {
+	offset := (page - 1) * pageSize
+
+	total, err := uc.userRepo.GetTotalCount(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := uc.userRepo.GetAll(ctx, filter, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.UserListResponse{
+		Users:    users,
+		PageInfo: service.NewPageInfo(total, page, pageSize),
+	}, nil
+}
+
+// CountUsers returns the number of users matching filter, the same total ListUsers reports
+// for it, without fetching any rows
+func (uc *AuthUseCase) CountUsers(ctx context.Context, filter *repository.UserFilter) (int64, error) {
+	return uc.userRepo.GetTotalCount(ctx, filter)
+}
+
+// ListDeletedUsers retrieves a paginated list of soft-deleted users, most recently deleted first
+func (uc *AuthUseCase) ListDeletedUsers(ctx context.Context, page, pageSize int) (*service.UserListResponse, error) {
+	offset := (page - 1) * pageSize
+
+	total, err := uc.userRepo.CountDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := uc.userRepo.GetDeleted(ctx, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.UserListResponse{
+		Users:    users,
+		PageInfo: service.NewPageInfo(total, page, pageSize),
+	}, nil
+}
+
+// RestoreUser undoes the soft-delete of a user. It is refused with entity.ErrUserEmailConflict
+// or entity.ErrUserUsernameConflict when an active account has taken the email or username since.
+func (uc *AuthUseCase) RestoreUser(ctx context.Context, id uint) (*entity.User, error) {
+	var restored *entity.User
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetDeletedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		emailTaken, err := uc.userRepo.ExistsByEmail(ctx, user.Email)
+		if err != nil {
+			return err
+		}
+		if emailTaken {
+			return entity.ErrUserEmailConflict
+		}
+
+		usernameTaken, err := uc.userRepo.ExistsByUsername(ctx, user.Username)
+		if err != nil {
+			return err
+		}
+		if usernameTaken {
+			return entity.ErrUserUsernameConflict
+		}
+
+		if err := uc.userRepo.RestoreUser(ctx, id); err != nil {
+			return err
+		}
+
+		auditLog := newUserAuditLog(ctx, id, entity.AuditActionRestore, entity.AuditChanges{
+			"deleted_at": {Before: user.DeletedAt.Time, After: nil},
+		})
+		if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+			return err
+		}
+
+		restored, err = uc.userRepo.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	logEvent(ctx, uc.events, service.EventUserRestored, entity.AuditEntityUser, id, nil)
+
+	return restored, nil
+}
+
+// normalizeEmail canonicalizes an email address so lookups and uniqueness are case-insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
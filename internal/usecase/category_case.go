@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/product-management/internal/domain/repository"
+)
+
+// CategoryCase is the casing policy applied to free-text product categories, so variants like
+// "electronics" and " Electronics " collapse to one value
+type CategoryCase string
+
+// Category casing policies. Every policy trims the category and collapses inner whitespace.
+const (
+	CategoryCaseNone  CategoryCase = "none"  // keep the casing as given
+	CategoryCaseLower CategoryCase = "lower" // "home goods"
+	CategoryCaseTitle CategoryCase = "title" // "Home Goods"
+)
+
+// ParseCategoryCase parses a category casing policy name
+func ParseCategoryCase(name string) (CategoryCase, error) {
+	switch policy := CategoryCase(strings.ToLower(strings.TrimSpace(name))); policy {
+	case CategoryCaseNone, CategoryCaseLower, CategoryCaseTitle:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown category casing %q, expected none, lower or title", name)
+	}
+}
+
+// normalize returns category in its canonical form under the policy
+func (c CategoryCase) normalize(category string) string {
+	words := strings.Fields(category)
+	for i, word := range words {
+		switch c {
+		case CategoryCaseLower:
+			words[i] = strings.ToLower(word)
+		case CategoryCaseTitle:
+			first, size := utf8.DecodeRuneInString(word)
+			words[i] = string(unicode.ToUpper(first)) + strings.ToLower(word[size:])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// normalizeCategoryFilter puts the categories filtered on in canonical form, so queries match
+// the stored values whatever casing the client used
+func (uc *ProductUseCase) normalizeCategoryFilter(filter *repository.ProductFilter) {
+	if filter == nil || len(filter.Categories) == 0 {
+		return
+	}
+
+	categories := make([]string, 0, len(filter.Categories))
+	for _, category := range filter.Categories {
+		categories = append(categories, uc.categoryCase.normalize(category))
+	}
+	filter.Categories = categories
+}
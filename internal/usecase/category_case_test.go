@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/product-management/internal/domain/repository"
+)
+
+func TestParseCategoryCase(t *testing.T) {
+	for name, want := range map[string]CategoryCase{"none": CategoryCaseNone, " Lower ": CategoryCaseLower, "TITLE": CategoryCaseTitle} {
+		if got, err := ParseCategoryCase(name); err != nil || got != want {
+			t.Errorf("ParseCategoryCase(%q): got %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseCategoryCase("upper"); err == nil {
+		t.Error(`ParseCategoryCase("upper") succeeded, want an error`)
+	}
+}
+
+func TestCategoryCaseNormalize(t *testing.T) {
+	tests := []struct {
+		policy   CategoryCase
+		category string
+		want     string
+	}{
+		{CategoryCaseNone, "  home   GOODS ", "home GOODS"},
+		{CategoryCaseLower, "  Home   GOODS ", "home goods"},
+		{CategoryCaseTitle, "  home   GOODS ", "Home Goods"},
+		{CategoryCaseTitle, "électronique", "Électronique"},
+		{CategoryCaseTitle, "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.normalize(tt.category); got != tt.want {
+			t.Errorf("%s: normalize(%q) got %q, want %q", tt.policy, tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestCreateProductNormalizesTheCategory(t *testing.T) {
+	uc := NewProductUseCase(newFakeProductRepo(), nil, nil, nil, &fakeAuditRepo{}, nil, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, CategoryCase: CategoryCaseTitle, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+
+	product, err := createInCategory(uc, "Desk Lamp", " home  goods")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if product.Category != "Home Goods" {
+		t.Errorf("got category %q, want %q", product.Category, "Home Goods")
+	}
+
+	filter := &repository.ProductFilter{Categories: []string{"HOME GOODS", "garden"}}
+	uc.normalizeCategoryFilter(filter)
+	if want := []string{"Home Goods", "Garden"}; !slices.Equal(filter.Categories, want) {
+		t.Errorf("got filter categories %q, want %q", filter.Categories, want)
+	}
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// CategoryMode is how a product created with a category name, and no category_id, is tied to
+// the managed categories
+type CategoryMode string
+
+// Category modes
+const (
+	// CategoryModeFreeText stores the category name as given, linked to no category
+	CategoryModeFreeText CategoryMode = "free_text"
+	// CategoryModeRequire links the product to the category of that name, rejecting names
+	// that aren't a category yet
+	CategoryModeRequire CategoryMode = "require"
+	// CategoryModeAutoCreate links the product to the category of that name, creating the
+	// category in the same transaction as the product if there is none. Any name a client
+	// sends becomes a category, so a typo like "Electornics" becomes one too.
+	CategoryModeAutoCreate CategoryMode = "auto_create"
+)
+
+// ParseCategoryMode parses a category mode name
+func ParseCategoryMode(name string) (CategoryMode, error) {
+	switch mode := CategoryMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case CategoryModeFreeText, CategoryModeRequire, CategoryModeAutoCreate:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown category mode %q, expected free_text, require or auto_create", name)
+	}
+}
+
+// linkCategory ties a new product named after a category to that category under the category
+// mode, creating the category in auto_create mode. It must run in the transaction that inserts
+// the product, so a failed insert doesn't leave an unused category behind.
+func (uc *ProductUseCase) linkCategory(ctx context.Context, product *entity.Product) error {
+	if uc.categoryMode == CategoryModeFreeText || product.CategoryID != nil || product.Category == "" {
+		return nil
+	}
+
+	category, err := uc.categoryRepo.GetByName(ctx, product.Category)
+	if errors.Is(err, entity.ErrCategoryNotFound) {
+		if uc.categoryMode != CategoryModeAutoCreate {
+			return entity.NewValidationError("category", "exists", err)
+		}
+		category, err = uc.createCategoryNamed(ctx, product.Category)
+	}
+	if err != nil {
+		return err
+	}
+
+	product.CategoryID = &category.ID
+	return nil
+}
+
+// createCategoryNamed creates a top-level category called name. If a concurrent request
+// created it first, that category is returned instead.
+func (uc *ProductUseCase) createCategoryNamed(ctx context.Context, name string) (*entity.Category, error) {
+	category := &entity.Category{Name: name, Slug: entity.Slugify(name)}
+	if err := category.Validate(); err != nil {
+		return nil, entity.NewValidationError("category", "valid", err)
+	}
+
+	// The savepoint keeps a unique violation from aborting the enclosing transaction
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return uc.categoryRepo.Create(ctx, category)
+	})
+	if err != nil {
+		if existing, getErr := uc.categoryRepo.GetByName(ctx, name); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return category, nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// newCategoryModeProductUseCase returns a product use case in the given category mode, with
+// Electronics already set up as category 1
+func newCategoryModeProductUseCase(mode CategoryMode) (*ProductUseCase, *fakeProductRepo, *fakeCategoryRepo) {
+	products := newFakeProductRepo()
+	categories := newFakeCategoryRepo(&entity.Category{Name: "Electronics", Slug: "electronics"})
+	uc := NewProductUseCase(products, nil, nil, categories, &fakeAuditRepo{}, nil, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, CategoryMode: mode, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+	return uc, products, categories
+}
+
+func createInCategory(uc *ProductUseCase, name, category string) (*entity.Product, error) {
+	return uc.CreateProduct(context.Background(), &CreateProductRequest{Name: name, Price: 10, Category: category})
+}
+
+func TestCategoryModeFreeTextLeavesProductsUnlinked(t *testing.T) {
+	uc, _, categories := newCategoryModeProductUseCase(CategoryModeFreeText)
+
+	product, err := createInCategory(uc, "Desk Lamp", "Lighting")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if product.CategoryID != nil {
+		t.Errorf("got category_id %d, want none", *product.CategoryID)
+	}
+	if _, err := categories.GetByName(context.Background(), "Lighting"); !errors.Is(err, entity.ErrCategoryNotFound) {
+		t.Errorf("free_text mode created a category: %v", err)
+	}
+}
+
+func TestZeroOptionsKeepCategoriesAsGiven(t *testing.T) {
+	uc, _, _ := newCategoryModeProductUseCase("")
+
+	if uc.categoryMode != CategoryModeFreeText || uc.categoryCase != CategoryCaseNone {
+		t.Errorf("got mode %q and case %q, want %q and %q", uc.categoryMode, uc.categoryCase, CategoryModeFreeText, CategoryCaseNone)
+	}
+}
+
+func TestCategoryModeRequireLinksExistingCategory(t *testing.T) {
+	uc, _, _ := newCategoryModeProductUseCase(CategoryModeRequire)
+
+	product, err := createInCategory(uc, "Headphones", "electronics")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if product.CategoryID == nil || *product.CategoryID != 1 {
+		t.Fatalf("got category_id %v, want 1", product.CategoryID)
+	}
+	if product.Category != "Electronics" {
+		t.Errorf("got category %q, want the category's own name %q", product.Category, "Electronics")
+	}
+}
+
+func TestCategoryModeRequireRejectsUnknownCategory(t *testing.T) {
+	uc, products, categories := newCategoryModeProductUseCase(CategoryModeRequire)
+
+	_, err := createInCategory(uc, "Headphones", "Electornics")
+	var validationErr *entity.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Violations[0].Field != "category" {
+		t.Fatalf("got %v, want a validation error on category", err)
+	}
+	if !errors.Is(err, entity.ErrCategoryNotFound) {
+		t.Errorf("got %v, want it to wrap %v", err, entity.ErrCategoryNotFound)
+	}
+	if len(products.products) != 0 || len(categories.categories) != 1 {
+		t.Errorf("got %d products and %d categories, want nothing written", len(products.products), len(categories.categories))
+	}
+}
+
+func TestCategoryModeAutoCreateCreatesMissingCategoryOnce(t *testing.T) {
+	uc, _, categories := newCategoryModeProductUseCase(CategoryModeAutoCreate)
+
+	first, err := createInCategory(uc, "Garden Hose", "Home & Garden")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if first.CategoryID == nil {
+		t.Fatal("product was not linked to the created category")
+	}
+	category, err := categories.GetByID(context.Background(), *first.CategoryID)
+	if err != nil {
+		t.Fatalf("created category: %v", err)
+	}
+	if category.Name != "Home & Garden" || category.Slug != "home-garden" {
+		t.Errorf("got category %q (%s), want %q (home-garden)", category.Name, category.Slug, "Home & Garden")
+	}
+
+	second, err := createInCategory(uc, "Rake", "home & garden")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if second.CategoryID == nil || *second.CategoryID != *first.CategoryID {
+		t.Errorf("got category_id %v, want the existing category %d", second.CategoryID, *first.CategoryID)
+	}
+	if len(categories.categories) != 2 {
+		t.Errorf("got %d categories, want 2", len(categories.categories))
+	}
+}
+
+func TestParseCategoryMode(t *testing.T) {
+	if mode, err := ParseCategoryMode(" Auto_Create "); err != nil || mode != CategoryModeAutoCreate {
+		t.Errorf("got %q, %v, want %q", mode, err, CategoryModeAutoCreate)
+	}
+	if _, err := ParseCategoryMode("create"); err == nil {
+		t.Error("accepted an unknown mode")
+	}
+}
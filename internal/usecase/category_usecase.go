@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/domain/service"
+)
+
+// CategoryUseCase handles category business logic
+type CategoryUseCase struct {
+	categoryRepo repository.CategoryRepository
+	txManager    repository.TxManager
+}
+
+// NewCategoryUseCase creates a new category use case
+func NewCategoryUseCase(categoryRepo repository.CategoryRepository, txManager repository.TxManager) *CategoryUseCase {
+	return &CategoryUseCase{
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+	}
+}
+
+// CreateCategory creates a new category
+func (uc *CategoryUseCase) CreateCategory(ctx context.Context, req *service.CategoryRequest) (*entity.Category, error) {
+	category := &entity.Category{}
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.applyRequest(ctx, category, req); err != nil {
+			return err
+		}
+		return uc.categoryRepo.Create(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// GetCategory retrieves a category by its ID
+func (uc *CategoryUseCase) GetCategory(ctx context.Context, id uint) (*entity.Category, error) {
+	return uc.categoryRepo.GetByID(ctx, id)
+}
+
+// ListCategories retrieves every category ordered by name
+func (uc *CategoryUseCase) ListCategories(ctx context.Context) ([]*entity.Category, error) {
+	return uc.categoryRepo.GetAll(ctx)
+}
+
+// GetCategoryTree returns the top-level categories with their subcategories nested under them,
+// each level ordered by name
+func (uc *CategoryUseCase) GetCategoryTree(ctx context.Context) ([]*entity.Category, error) {
+	categories, err := uc.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*entity.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	roots := []*entity.Category{}
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if parent, ok := byID[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+
+	return roots, nil
+}
+
+// UpdateCategory replaces a category's name, slug and parent
+func (uc *CategoryUseCase) UpdateCategory(ctx context.Context, id uint, req *service.CategoryRequest) (*entity.Category, error) {
+	var category *entity.Category
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		category, err = uc.categoryRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.applyRequest(ctx, category, req); err != nil {
+			return err
+		}
+		return uc.categoryRepo.Update(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes a category that has no subcategories or products
+func (uc *CategoryUseCase) DeleteCategory(ctx context.Context, id uint) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.categoryRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		children, err := uc.categoryRepo.CountChildren(ctx, id)
+		if err != nil {
+			return err
+		}
+		products, err := uc.categoryRepo.CountProducts(ctx, id)
+		if err != nil {
+			return err
+		}
+		if children > 0 || products > 0 {
+			return entity.ErrCategoryInUse
+		}
+
+		return uc.categoryRepo.Delete(ctx, id)
+	})
+}
+
+// applyRequest validates req and copies it onto category, checking that the parent exists,
+// doesn't create a cycle, and that the name and slug are unique
+func (uc *CategoryUseCase) applyRequest(ctx context.Context, category *entity.Category, req *service.CategoryRequest) error {
+	if err := validateStruct(req); err != nil {
+		return err
+	}
+
+	category.Name = strings.TrimSpace(req.Name)
+	category.Slug = strings.TrimSpace(req.Slug)
+	if category.Slug == "" {
+		category.Slug = entity.Slugify(category.Name)
+	}
+	category.ParentID = req.ParentID
+
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	if category.ParentID != nil {
+		if err := uc.checkParent(ctx, category.ID, *category.ParentID); err != nil {
+			return err
+		}
+	}
+
+	exists, err := uc.categoryRepo.ExistsByNameOrSlug(ctx, category.Name, category.Slug, category.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return entity.ErrCategoryAlreadyExists
+	}
+
+	return nil
+}
+
+// checkParent verifies that parentID exists and, for an existing category, isn't the category
+// itself or one of its descendants
+func (uc *CategoryUseCase) checkParent(ctx context.Context, categoryID, parentID uint) error {
+	visited := make(map[uint]bool)
+	for ancestorID := &parentID; ancestorID != nil; {
+		if categoryID != 0 && *ancestorID == categoryID {
+			return entity.NewValidationError("parent_id", "cycle", entity.ErrCategoryCycle)
+		}
+		// Guard against cycles already present in the data
+		if visited[*ancestorID] {
+			return nil
+		}
+		visited[*ancestorID] = true
+
+		ancestor, err := uc.categoryRepo.GetByID(ctx, *ancestorID)
+		if errors.Is(err, entity.ErrCategoryNotFound) {
+			if *ancestorID == parentID {
+				return entity.NewValidationError("parent_id", "exists", err)
+			}
+			// A dangling link higher up simply ends the chain
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ancestorID = ancestor.ParentID
+	}
+	return nil
+}
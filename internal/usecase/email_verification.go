@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// EmailVerification configures whether new accounts must verify their email before logging in
+type EmailVerification struct {
+	Required  bool
+	TokenTTL  time.Duration
+	VerifyURL string // the token is appended as the "token" query parameter
+	Sender    service.EmailSender
+}
+
+// VerifyEmail verifies the email of the account holding token. Accounts deactivated by an
+// admin can't be verified, so verifying never brings a deactivated account back.
+func (uc *AuthUseCase) VerifyEmail(ctx context.Context, token string) (*entity.User, error) {
+	if token == "" {
+		return nil, entity.ErrBadVerificationToken
+	}
+
+	user, err := uc.userRepo.GetByVerificationTokenHash(ctx, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil, entity.ErrBadVerificationToken
+		}
+		return nil, err
+	}
+	if user.VerificationTokenExpiresAt == nil || time.Now().After(*user.VerificationTokenExpiresAt) {
+		return nil, entity.ErrBadVerificationToken
+	}
+	if !user.IsActive {
+		return nil, entity.ErrUserInactive
+	}
+
+	user.MarkEmailVerified(time.Now())
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ResendVerification issues a fresh verification link to an unverified account. It succeeds
+// without sending anything for unknown, already verified or deactivated accounts, so callers
+// can't probe which emails are registered.
+func (uc *AuthUseCase) ResendVerification(ctx context.Context, email string) error {
+	if !uc.emailVerification.Required {
+		return nil
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerified || !user.IsActive {
+		return nil
+	}
+
+	token, err := uc.issueVerificationToken(user)
+	if err != nil {
+		return err
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return uc.sendVerificationEmail(ctx, user, token)
+}
+
+// issueVerificationToken sets a new verification token on user, replacing any previous one,
+// and returns it. Only its hash is stored.
+func (uc *AuthUseCase) issueVerificationToken(user *entity.User) (string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(uc.emailVerification.TokenTTL)
+	user.VerificationTokenHash = hashSecretToken(token)
+	user.VerificationTokenExpiresAt = &expiresAt
+	return token, nil
+}
+
+// sendVerificationEmail sends user the link that verifies token
+func (uc *AuthUseCase) sendVerificationEmail(ctx context.Context, user *entity.User, token string) error {
+	link, err := url.Parse(uc.emailVerification.VerifyURL)
+	if err != nil {
+		return fmt.Errorf("invalid verification URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	if err := uc.emailVerification.Sender.SendVerificationEmail(ctx, user, link.String()); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// newSecretToken returns a random token for an emailed link
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashSecretToken returns the stored form of an emailed token
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
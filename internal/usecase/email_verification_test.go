@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const adminID = 1
+
+// newVerificationAuthUseCase returns an auth use case requiring email verification, with an
+// active admin already registered as user 1
+func newVerificationAuthUseCase(t *testing.T) (*AuthUseCase, *fakeUserRepo, *fakeEmailSender) {
+	t.Helper()
+	users := newFakeUserRepo(&entity.User{Email: "admin@example.com", Username: "admin", IsActive: true, IsAdmin: true, EmailVerified: true})
+	sender := &fakeEmailSender{}
+	verification := EmailVerification{
+		Required:  true,
+		TokenTTL:  time.Hour,
+		VerifyURL: "http://localhost/api/v1/auth/verify",
+		Sender:    sender,
+	}
+	uc := NewAuthUseCase(users, &fakeAuditRepo{}, nil, fakeTxManager{}, verification, PasswordReset{}, bcrypt.MinCost, PasswordPolicies{}, nil, nopEventLogger{}, ProfileLimits{}, nil)
+	return uc, users, sender
+}
+
+// register signs up a user and returns their ID and the token from the verification email
+func register(t *testing.T, uc *AuthUseCase, sender *fakeEmailSender, email string) (uint, string) {
+	t.Helper()
+	resp, err := uc.Register(context.Background(), &service.RegisterRequest{Email: email, Username: "newuser", Password: "correct horse battery"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	return resp.User.ID, lastToken(t, sender)
+}
+
+func lastToken(t *testing.T, sender *fakeEmailSender) string {
+	t.Helper()
+	if len(sender.verifyLinks) == 0 {
+		t.Fatal("no verification email was sent")
+	}
+	link, err := url.Parse(sender.verifyLinks[len(sender.verifyLinks)-1])
+	if err != nil {
+		t.Fatalf("parse verification link: %v", err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestVerifyEmailKeepsAccountActive(t *testing.T) {
+	uc, users, sender := newVerificationAuthUseCase(t)
+	id, token := register(t, uc, sender, "new@example.com")
+
+	if _, err := uc.VerifyEmail(context.Background(), token); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+
+	user, _ := users.GetByID(context.Background(), id)
+	if !user.EmailVerified || !user.IsActive {
+		t.Errorf("got verified=%v active=%v, want both true", user.EmailVerified, user.IsActive)
+	}
+	if user.VerificationTokenHash != "" {
+		t.Error("verification token was not consumed")
+	}
+}
+
+func TestDeactivatedUserCannotVerifyBackIntoTheirAccount(t *testing.T) {
+	uc, users, sender := newVerificationAuthUseCase(t)
+	id, token := register(t, uc, sender, "leaver@example.com")
+	adminCtx := service.WithActorID(context.Background(), adminID)
+
+	if _, err := uc.DeactivateUser(adminCtx, id); err != nil {
+		t.Fatalf("DeactivateUser: %v", err)
+	}
+
+	// The link issued before the deactivation no longer works
+	if _, err := uc.VerifyEmail(context.Background(), token); !errors.Is(err, entity.ErrBadVerificationToken) {
+		t.Errorf("VerifyEmail with pre-deactivation token: got %v, want %v", err, entity.ErrBadVerificationToken)
+	}
+
+	// Asking for a new link sends nothing
+	sent := len(sender.verifyLinks)
+	if err := uc.ResendVerification(context.Background(), "leaver@example.com"); err != nil {
+		t.Fatalf("ResendVerification: %v", err)
+	}
+	if len(sender.verifyLinks) != sent {
+		t.Error("ResendVerification sent a link to a deactivated account")
+	}
+
+	user, _ := users.GetByID(context.Background(), id)
+	if user.IsActive || user.EmailVerified {
+		t.Errorf("got active=%v verified=%v, want both false", user.IsActive, user.EmailVerified)
+	}
+}
+
+func TestBulkDeactivationRevokesVerificationTokens(t *testing.T) {
+	uc, users, sender := newVerificationAuthUseCase(t)
+	id, token := register(t, uc, sender, "offboarded@example.com")
+	adminCtx := service.WithActorID(context.Background(), adminID)
+
+	if _, err := uc.BulkDeactivateUsers(adminCtx, []uint{id}); err != nil {
+		t.Fatalf("BulkDeactivateUsers: %v", err)
+	}
+	if _, err := uc.VerifyEmail(context.Background(), token); !errors.Is(err, entity.ErrBadVerificationToken) {
+		t.Errorf("VerifyEmail: got %v, want %v", err, entity.ErrBadVerificationToken)
+	}
+
+	user, _ := users.GetByID(context.Background(), id)
+	if user.IsActive {
+		t.Error("bulk-deactivated user was reactivated")
+	}
+}
+
+func TestVerifyEmailRefusesInactiveAccountWithValidToken(t *testing.T) {
+	uc, users, sender := newVerificationAuthUseCase(t)
+	id, token := register(t, uc, sender, "legacy@example.com")
+
+	// An account deactivated before deactivation started revoking tokens still holds one
+	user, _ := users.GetByID(context.Background(), id)
+	user.IsActive = false
+	_ = users.Update(context.Background(), user)
+
+	if _, err := uc.VerifyEmail(context.Background(), token); !errors.Is(err, entity.ErrUserInactive) {
+		t.Errorf("VerifyEmail: got %v, want %v", err, entity.ErrUserInactive)
+	}
+	user, _ = users.GetByID(context.Background(), id)
+	if user.IsActive || user.EmailVerified {
+		t.Errorf("got active=%v verified=%v, want both false", user.IsActive, user.EmailVerified)
+	}
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// productEventTypes maps product audit actions to the business event they are logged as
+var productEventTypes = map[string]string{
+	entity.AuditActionCreate:      service.EventProductCreated,
+	entity.AuditActionUpdate:      service.EventProductUpdated,
+	entity.AuditActionDelete:      service.EventProductDeleted,
+	entity.AuditActionHardDelete:  service.EventProductHardDeleted,
+	entity.AuditActionDeactivate:  service.EventProductDeactivated,
+	entity.AuditActionStockChange: service.EventProductStockChanged,
+}
+
+// logEvent logs a business event about an entity, attributed to the actor in ctx
+func logEvent(ctx context.Context, events service.EventLogger, eventType, entityType string, entityID uint, fields map[string]interface{}) {
+	event := service.BusinessEvent{
+		Type:       eventType,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Fields:     fields,
+	}
+	if actorID, ok := service.ActorIDFromContext(ctx); ok {
+		event.ActorID = &actorID
+	}
+	events.LogEvent(ctx, event)
+}
+
+// changedFields returns the names of the fields in changes, sorted so log lines are stable
+func changedFields(changes entity.AuditChanges) []string {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
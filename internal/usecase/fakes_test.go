@@ -0,0 +1,669 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/domain/service"
+	"gorm.io/gorm"
+)
+
+// The fakes below keep their records in memory and hand out copies, like a database would.
+// Each embeds the repository interface it fakes, so calling a method a test doesn't expect
+// panics instead of silently doing nothing.
+
+// fakeTxManager runs transactions directly; the fakes have nothing to roll back
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (fakeTxManager) WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// nopEventLogger discards business events
+type nopEventLogger struct{}
+
+func (nopEventLogger) LogEvent(context.Context, service.BusinessEvent) {}
+
+// fakeEventLogger records business events in memory
+type fakeEventLogger struct {
+	mu     sync.Mutex
+	events []service.BusinessEvent
+}
+
+func (l *fakeEventLogger) LogEvent(_ context.Context, event service.BusinessEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+// fakeUserRepo is an in-memory repository.UserRepository
+type fakeUserRepo struct {
+	repository.UserRepository
+	mu     sync.Mutex
+	users  map[uint]*entity.User
+	nextID uint
+}
+
+func newFakeUserRepo(users ...*entity.User) *fakeUserRepo {
+	repo := &fakeUserRepo{users: make(map[uint]*entity.User)}
+	for _, user := range users {
+		_ = repo.Create(context.Background(), user)
+	}
+	return repo
+}
+
+func (r *fakeUserRepo) Create(_ context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if user.ID == 0 {
+		r.nextID++
+		user.ID = r.nextID
+	}
+	stored := *user
+	r.users[user.ID] = &stored
+	return nil
+}
+
+func (r *fakeUserRepo) find(match func(*entity.User) bool) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users {
+		if match(user) {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, entity.ErrUserNotFound
+}
+
+func (r *fakeUserRepo) GetByID(_ context.Context, id uint) (*entity.User, error) {
+	return r.find(func(u *entity.User) bool { return u.ID == id })
+}
+
+func (r *fakeUserRepo) GetByEmail(_ context.Context, email string) (*entity.User, error) {
+	return r.find(func(u *entity.User) bool { return strings.EqualFold(u.Email, email) })
+}
+
+func (r *fakeUserRepo) GetByVerificationTokenHash(_ context.Context, tokenHash string) (*entity.User, error) {
+	return r.find(func(u *entity.User) bool { return tokenHash != "" && u.VerificationTokenHash == tokenHash })
+}
+
+func (r *fakeUserRepo) GetByResetTokenHash(_ context.Context, tokenHash string) (*entity.User, error) {
+	return r.find(func(u *entity.User) bool { return tokenHash != "" && u.ResetTokenHash == tokenHash })
+}
+
+func (r *fakeUserRepo) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	_, err := r.GetByEmail(ctx, email)
+	return err == nil, nil
+}
+
+func (r *fakeUserRepo) ExistsByUsername(_ context.Context, username string) (bool, error) {
+	_, err := r.find(func(u *entity.User) bool { return strings.EqualFold(u.Username, username) })
+	return err == nil, nil
+}
+
+func (r *fakeUserRepo) GetAdminUsers(_ context.Context) ([]*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var admins []*entity.User
+	for _, user := range r.users {
+		if user.IsAdmin && user.IsActive {
+			admin := *user
+			admins = append(admins, &admin)
+		}
+	}
+	return admins, nil
+}
+
+func (r *fakeUserRepo) Update(_ context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[user.ID]; !ok {
+		return entity.ErrUserNotFound
+	}
+	stored := *user
+	r.users[user.ID] = &stored
+	return nil
+}
+
+func (r *fakeUserRepo) UpdatePassword(_ context.Context, id uint, hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return entity.ErrUserNotFound
+	}
+	user.Password = hash
+	return nil
+}
+
+func (r *fakeUserRepo) UpdateLastLogin(context.Context, uint) error {
+	return nil
+}
+
+// fakeAuditRepo records audit entries in memory
+type fakeAuditRepo struct {
+	repository.AuditLogRepository
+	mu      sync.Mutex
+	entries []*entity.AuditLog
+}
+
+func (r *fakeAuditRepo) Create(_ context.Context, auditLog *entity.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, auditLog)
+	return nil
+}
+
+// fakeEmailSender records the links it is asked to send
+type fakeEmailSender struct {
+	mu          sync.Mutex
+	verifyLinks []string
+	resetLinks  []string
+}
+
+func (s *fakeEmailSender) SendVerificationEmail(_ context.Context, _ *entity.User, verifyURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifyLinks = append(s.verifyLinks, verifyURL)
+	return nil
+}
+
+func (s *fakeEmailSender) SendPasswordResetEmail(_ context.Context, _ *entity.User, resetURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetLinks = append(s.resetLinks, resetURL)
+	return nil
+}
+
+// fakeProductRepo is an in-memory repository.ProductRepository
+type fakeProductRepo struct {
+	repository.ProductRepository
+	mu       sync.Mutex
+	products map[uint]*entity.Product
+	nextID   uint
+	sequence int64 // last value handed out by NextSKUSequence
+}
+
+func newFakeProductRepo(products ...*entity.Product) *fakeProductRepo {
+	repo := &fakeProductRepo{products: make(map[uint]*entity.Product)}
+	for _, product := range products {
+		_ = repo.Create(context.Background(), product)
+	}
+	return repo
+}
+
+func (r *fakeProductRepo) Create(_ context.Context, product *entity.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if product.ID == 0 {
+		r.nextID++
+		product.ID = r.nextID
+	}
+	stored := *product
+	r.products[product.ID] = &stored
+	return nil
+}
+
+func (r *fakeProductRepo) find(match func(*entity.Product) bool) (*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, product := range r.products {
+		if match(product) {
+			found := *product
+			return &found, nil
+		}
+	}
+	return nil, entity.ErrProductNotFound
+}
+
+func (r *fakeProductRepo) GetByID(_ context.Context, id uint) (*entity.Product, error) {
+	return r.find(func(p *entity.Product) bool { return p.ID == id })
+}
+
+func (r *fakeProductRepo) GetByName(_ context.Context, name string) (*entity.Product, error) {
+	return r.find(func(p *entity.Product) bool { return strings.EqualFold(p.Name, name) })
+}
+
+func (r *fakeProductRepo) GetBySKU(_ context.Context, sku string) (*entity.Product, error) {
+	return r.find(func(p *entity.Product) bool { return p.SKU != nil && *p.SKU == sku })
+}
+
+func (r *fakeProductRepo) ExistsByName(_ context.Context, name string) (bool, error) {
+	_, err := r.find(func(p *entity.Product) bool { return strings.EqualFold(p.Name, name) })
+	return err == nil, nil
+}
+
+func (r *fakeProductRepo) ExistsByNameInCategory(_ context.Context, name, category string, excludeID uint) (bool, error) {
+	_, err := r.find(func(p *entity.Product) bool {
+		return p.ID != excludeID && strings.EqualFold(p.Name, name) && p.Category == category
+	})
+	return err == nil, nil
+}
+
+// GetAll ignores filter and lists the products in ID order
+func (r *fakeProductRepo) GetAll(_ context.Context, _ *repository.ProductFilter, offset, limit int) ([]*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	products := make([]*entity.Product, 0, len(r.products))
+	for _, product := range r.products {
+		found := *product
+		products = append(products, &found)
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	if offset >= len(products) {
+		return nil, nil
+	}
+	return products[offset:min(offset+limit, len(products))], nil
+}
+
+// GetTotalCount ignores filter and counts every product
+func (r *fakeProductRepo) GetTotalCount(context.Context, *repository.ProductFilter) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.products)), nil
+}
+
+// Delete soft-deletes the product, which the other methods keep returning
+func (r *fakeProductRepo) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	product, ok := r.products[id]
+	if !ok || product.DeletedAt.Valid {
+		return entity.ErrProductNotFound
+	}
+	product.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// HardDelete removes the product, deleted or not
+func (r *fakeProductRepo) HardDelete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.products, id)
+	return nil
+}
+
+// CountReferences reports no references
+func (r *fakeProductRepo) CountReferences(context.Context, uint) (entity.ProductReferences, error) {
+	return entity.ProductReferences{}, nil
+}
+
+// GetDeleted lists the soft-deleted products, most recently deleted first
+func (r *fakeProductRepo) GetDeleted(_ context.Context, offset, limit int) ([]*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var products []*entity.Product
+	for _, product := range r.products {
+		if product.DeletedAt.Valid {
+			found := *product
+			products = append(products, &found)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].DeletedAt.Time.After(products[j].DeletedAt.Time) })
+	if offset >= len(products) {
+		return nil, nil
+	}
+	return products[offset:min(offset+limit, len(products))], nil
+}
+
+func (r *fakeProductRepo) CountDeleted(context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for _, product := range r.products {
+		if product.DeletedAt.Valid {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeProductRepo) NextSKUSequence(context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sequence++
+	return r.sequence, nil
+}
+
+func (r *fakeProductRepo) Update(_ context.Context, product *entity.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.products[product.ID]; !ok {
+		return entity.ErrProductNotFound
+	}
+	stored := *product
+	r.products[product.ID] = &stored
+	return nil
+}
+
+// fakeImageRepo is a repository.ProductImageRepository without any images
+type fakeImageRepo struct {
+	repository.ProductImageRepository
+}
+
+func (fakeImageRepo) DeleteByProduct(context.Context, uint) error {
+	return nil
+}
+
+// fakeCategoryRepo is an in-memory repository.CategoryRepository
+type fakeCategoryRepo struct {
+	repository.CategoryRepository
+	mu         sync.Mutex
+	categories map[uint]*entity.Category
+	nextID     uint
+}
+
+func newFakeCategoryRepo(categories ...*entity.Category) *fakeCategoryRepo {
+	repo := &fakeCategoryRepo{categories: make(map[uint]*entity.Category)}
+	for _, category := range categories {
+		_ = repo.Create(context.Background(), category)
+	}
+	return repo
+}
+
+func (r *fakeCategoryRepo) Create(_ context.Context, category *entity.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.categories {
+		if strings.EqualFold(existing.Name, category.Name) || existing.Slug == category.Slug {
+			return entity.ErrCategoryAlreadyExists
+		}
+	}
+	r.nextID++
+	category.ID = r.nextID
+	stored := *category
+	r.categories[category.ID] = &stored
+	return nil
+}
+
+func (r *fakeCategoryRepo) find(match func(*entity.Category) bool) (*entity.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, category := range r.categories {
+		if match(category) {
+			found := *category
+			return &found, nil
+		}
+	}
+	return nil, entity.ErrCategoryNotFound
+}
+
+func (r *fakeCategoryRepo) GetByID(_ context.Context, id uint) (*entity.Category, error) {
+	return r.find(func(c *entity.Category) bool { return c.ID == id })
+}
+
+func (r *fakeCategoryRepo) GetByName(_ context.Context, name string) (*entity.Category, error) {
+	return r.find(func(c *entity.Category) bool { return strings.EqualFold(c.Name, name) })
+}
+
+func (r *fakeProductRepo) PartitionIDs(_ context.Context, ids []uint) (*repository.ProductIDPartition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	partition := &repository.ProductIDPartition{}
+	for _, id := range ids {
+		product, ok := r.products[id]
+		switch {
+		case !ok:
+			partition.NotFound = append(partition.NotFound, id)
+		case product.DeletedAt.Valid:
+			partition.SoftDeleted = append(partition.SoftDeleted, id)
+		default:
+			partition.Matched = append(partition.Matched, id)
+		}
+	}
+	return partition, nil
+}
+
+func (r *fakeProductRepo) BulkUpdateStatus(_ context.Context, ids []uint, isActive bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		if product, ok := r.products[id]; ok && !product.DeletedAt.Valid {
+			product.IsActive = isActive
+		}
+	}
+	return nil
+}
+
+// adjustStock applies change to a product under the lock and returns a copy of the result,
+// like the repository's single-statement stock updates
+func (r *fakeProductRepo) adjustStock(id uint, change func(*entity.Product) error) (*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	product, ok := r.products[id]
+	if !ok {
+		return nil, entity.ErrProductNotFound
+	}
+	if err := change(product); err != nil {
+		return nil, err
+	}
+	product.Version++
+	updated := *product
+	return &updated, nil
+}
+
+func (r *fakeProductRepo) DecrementStock(_ context.Context, id uint, qty int) (*entity.Product, error) {
+	return r.adjustStock(id, func(p *entity.Product) error {
+		if p.Stock < qty {
+			return entity.ErrInsufficientStock
+		}
+		p.Stock -= qty
+		return nil
+	})
+}
+
+func (r *fakeProductRepo) IncrementStock(_ context.Context, id uint, qty int) (*entity.Product, error) {
+	return r.adjustStock(id, func(p *entity.Product) error {
+		p.Stock += qty
+		return nil
+	})
+}
+
+func (r *fakeProductRepo) ReserveStock(_ context.Context, id uint, qty int) (*entity.Product, error) {
+	return r.adjustStock(id, func(p *entity.Product) error {
+		if p.Stock < qty {
+			return entity.ErrInsufficientStock
+		}
+		p.Stock -= qty
+		p.Reserved += qty
+		return nil
+	})
+}
+
+func (r *fakeProductRepo) ReleaseReservedStock(_ context.Context, id uint, qty int) (*entity.Product, error) {
+	return r.adjustStock(id, func(p *entity.Product) error {
+		if p.Reserved < qty {
+			return errors.New("not enough reserved units")
+		}
+		p.Stock += qty
+		p.Reserved -= qty
+		return nil
+	})
+}
+
+// fakeReservationRepo is an in-memory repository.ReservationRepository
+type fakeReservationRepo struct {
+	repository.ReservationRepository
+	mu           sync.Mutex
+	reservations map[uint]*entity.Reservation
+	nextID       uint
+}
+
+func newFakeReservationRepo() *fakeReservationRepo {
+	return &fakeReservationRepo{reservations: make(map[uint]*entity.Reservation)}
+}
+
+func (r *fakeReservationRepo) Create(_ context.Context, reservation *entity.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	reservation.ID = r.nextID
+	if reservation.CreatedAt.IsZero() {
+		reservation.CreatedAt = time.Now()
+	}
+	stored := *reservation
+	r.reservations[reservation.ID] = &stored
+	return nil
+}
+
+func (r *fakeReservationRepo) LockStale(_ context.Context, now, createdBefore time.Time, limit int) ([]*entity.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var stale []*entity.Reservation
+	for _, reservation := range r.reservations {
+		if len(stale) < limit && (!reservation.ExpiresAt.After(now) || !reservation.CreatedAt.After(createdBefore)) {
+			found := *reservation
+			stale = append(stale, &found)
+		}
+	}
+	return stale, nil
+}
+
+func (r *fakeReservationRepo) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.reservations, id)
+	return nil
+}
+
+// fakePriceRepo records price changes in memory, failing those of product failFor
+type fakePriceRepo struct {
+	repository.PriceHistoryRepository
+	mu      sync.Mutex
+	entries []*entity.PriceHistory
+	failFor uint
+}
+
+func (r *fakePriceRepo) Create(_ context.Context, entry *entity.PriceHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failFor != 0 && entry.ProductID == r.failFor {
+		return errors.New("price history unavailable")
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// fakePublisher records the product events it is asked to publish
+type fakePublisher struct {
+	mu     sync.Mutex
+	events []service.ProductEvent
+}
+
+func (p *fakePublisher) Publish(_ context.Context, event service.ProductEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// fakeWebhookRepo keeps webhooks and their deliveries in memory
+type fakeWebhookRepo struct {
+	repository.WebhookRepository
+	mu         sync.Mutex
+	webhooks   map[uint]*entity.Webhook
+	deliveries []*entity.WebhookDelivery
+}
+
+func newFakeWebhookRepo(webhooks ...*entity.Webhook) *fakeWebhookRepo {
+	repo := &fakeWebhookRepo{webhooks: make(map[uint]*entity.Webhook)}
+	for i, webhook := range webhooks {
+		webhook.ID = uint(i + 1)
+		stored := *webhook
+		repo.webhooks[webhook.ID] = &stored
+	}
+	return repo
+}
+
+func (r *fakeWebhookRepo) GetByID(_ context.Context, id uint) (*entity.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, entity.ErrWebhookNotFound
+	}
+	found := *webhook
+	return &found, nil
+}
+
+func (r *fakeWebhookRepo) GetActive(context.Context) ([]*entity.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var webhooks []*entity.Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.IsActive {
+			found := *webhook
+			webhooks = append(webhooks, &found)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+func (r *fakeWebhookRepo) CreateDeliveries(_ context.Context, deliveries []*entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range deliveries {
+		delivery.ID = uint(len(r.deliveries) + 1)
+		stored := *delivery
+		r.deliveries = append(r.deliveries, &stored)
+	}
+	return nil
+}
+
+func (r *fakeWebhookRepo) ClaimDueDeliveries(_ context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*entity.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if len(due) == limit {
+			break
+		}
+		if delivery.Status == entity.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			delivery.NextAttemptAt = now.Add(lease)
+			claimed := *delivery
+			due = append(due, &claimed)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeWebhookRepo) UpdateDelivery(_ context.Context, delivery *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *delivery
+	r.deliveries[delivery.ID-1] = &stored
+	return nil
+}
+
+// fakeWebhookSender answers each attempt with the next of its statuses, or with err when
+// the statuses run out
+type fakeWebhookSender struct {
+	mu       sync.Mutex
+	statuses []int
+	err      error
+	sent     []*entity.WebhookDelivery
+}
+
+func (s *fakeWebhookSender) Send(_ context.Context, _ *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, delivery)
+	if len(s.statuses) == 0 {
+		return 0, s.err
+	}
+	status := s.statuses[0]
+	s.statuses = s.statuses[1:]
+	return status, nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// importJobTTL is how long a finished import job stays available for polling
+const importJobTTL = time.Hour
+
+// importJobStore keeps the state of background imports in memory
+type importJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*service.ImportJob
+}
+
+// newImportJobStore creates an empty job store
+func newImportJobStore() *importJobStore {
+	return &importJobStore{
+		jobs: make(map[string]*service.ImportJob),
+	}
+}
+
+// create registers a new pending job
+func (s *importJobStore) create() *service.ImportJob {
+	job := &service.ImportJob{
+		ID:        newImportJobID(),
+		Status:    service.ImportJobStatusPending,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	return cloneImportJob(job)
+}
+
+// get returns a snapshot of the job with the given ID
+func (s *importJobStore) get(id string) (*service.ImportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, entity.ErrImportJobNotFound
+	}
+	return cloneImportJob(job), nil
+}
+
+// start marks a job as running
+func (s *importJobStore) start(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		job.Status = service.ImportJobStatusRunning
+	}
+}
+
+// finish records the outcome of a job and schedules its removal after importJobTTL
+func (s *importJobStore) finish(id string, result *service.ImportResult, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Result = result
+	if err != nil {
+		job.Status = service.ImportJobStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = service.ImportJobStatusCompleted
+	}
+
+	time.AfterFunc(importJobTTL, func() {
+		s.mu.Lock()
+		delete(s.jobs, id)
+		s.mu.Unlock()
+	})
+}
+
+// cloneImportJob copies a job so callers never observe later updates to it. The result is
+// set once when the job finishes, so it is safe to share.
+func cloneImportJob(job *service.ImportJob) *service.ImportJob {
+	snapshot := *job
+	return &snapshot
+}
+
+// newImportJobID generates a random 128-bit hex job ID
+func newImportJobID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
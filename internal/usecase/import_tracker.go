@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// importTracker limits how many imports run at once and records the ones in progress
+type importTracker struct {
+	slots   chan struct{}
+	running sync.WaitGroup
+
+	mu     sync.Mutex
+	nextID uint64
+	active map[uint64]service.ActiveImport
+}
+
+// newImportTracker creates a tracker allowing up to maxConcurrent imports (at least one)
+func newImportTracker(maxConcurrent int) *importTracker {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &importTracker{
+		slots:  make(chan struct{}, maxConcurrent),
+		active: make(map[uint64]service.ActiveImport),
+	}
+}
+
+// acquire reserves an import slot without waiting. The returned release function must be
+// deferred by the caller so the slot is freed on success, error, cancellation or panic;
+// calling it more than once is safe.
+func (t *importTracker) acquire(kind string, rows int) (func(), error) {
+	select {
+	case t.slots <- struct{}{}:
+	default:
+		return nil, entity.ErrTooManyImports
+	}
+	t.running.Add(1)
+
+	t.mu.Lock()
+	t.nextID++
+	id := t.nextID
+	t.active[id] = service.ActiveImport{ID: id, Kind: kind, Rows: rows, StartedAt: time.Now()}
+	t.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			delete(t.active, id)
+			t.mu.Unlock()
+			<-t.slots
+			t.running.Done()
+		})
+	}, nil
+}
+
+// wait blocks until every running import has finished or ctx is done
+func (t *importTracker) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// list returns the imports in progress, oldest first
+func (t *importTracker) list() []service.ActiveImport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	imports := make([]service.ActiveImport, 0, len(t.active))
+	for _, activeImport := range t.active {
+		imports = append(imports, activeImport)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].ID < imports[j].ID
+	})
+	return imports
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// PasswordPolicy sets the rules a new password must satisfy
+type PasswordPolicy struct {
+	MinLength        int // shortest password allowed, in characters
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool // punctuation or a symbol such as ! or $
+}
+
+// RequiresClassesOf reports whether p requires every character class other requires
+func (p PasswordPolicy) RequiresClassesOf(other PasswordPolicy) bool {
+	return (p.RequireLowercase || !other.RequireLowercase) &&
+		(p.RequireUppercase || !other.RequireUppercase) &&
+		(p.RequireDigit || !other.RequireDigit) &&
+		(p.RequireSymbol || !other.RequireSymbol)
+}
+
+// PasswordPolicies holds the policy for regular users and the stricter one applied to admins
+type PasswordPolicies struct {
+	User  PasswordPolicy
+	Admin PasswordPolicy
+	// Denylist holds common passwords refused for every account, compared case-insensitively
+	Denylist []string
+}
+
+// check validates password, set on field, against the policy for an admin or regular user,
+// reporting the first rule it breaks. Admin errors read "admin password ..." so clients can
+// tell which policy was applied.
+func (p PasswordPolicies) check(field, password string, isAdmin bool) error {
+	policy, prefix := p.User, ""
+	if isAdmin {
+		policy, prefix = p.Admin, "admin "
+	}
+	violation := func(rule string, err error) error {
+		return entity.NewValidationError(field, rule, fmt.Errorf("%s%w", prefix, err))
+	}
+
+	if len([]rune(password)) < policy.MinLength {
+		return violation("min", fmt.Errorf("%w: must be at least %d characters", entity.ErrPasswordTooShort, policy.MinLength))
+	}
+	for _, denied := range p.Denylist {
+		if strings.EqualFold(password, denied) {
+			return violation("denylist", entity.ErrPasswordTooCommon)
+		}
+	}
+
+	classes := passwordClasses(password)
+	switch {
+	case policy.RequireLowercase && !classes.lower:
+		return violation("lowercase", entity.ErrPasswordMissingLower)
+	case policy.RequireUppercase && !classes.upper:
+		return violation("uppercase", entity.ErrPasswordMissingUpper)
+	case policy.RequireDigit && !classes.digit:
+		return violation("digit", entity.ErrPasswordMissingDigit)
+	case policy.RequireSymbol && !classes.symbol:
+		return violation("symbol", entity.ErrPasswordMissingSymbol)
+	}
+	return nil
+}
+
+// characterClasses records which kinds of character a password contains
+type characterClasses struct {
+	lower, upper, digit, symbol bool
+}
+
+// passwordClasses returns the character classes found in password
+func passwordClasses(password string) characterClasses {
+	var classes characterClasses
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			classes.lower = true
+		case unicode.IsUpper(r):
+			classes.upper = true
+		case unicode.IsDigit(r):
+			classes.digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			classes.symbol = true
+		}
+	}
+	return classes
+}
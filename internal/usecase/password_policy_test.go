@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testPasswordPolicies = PasswordPolicies{
+	User:     PasswordPolicy{MinLength: 8},
+	Admin:    PasswordPolicy{MinLength: 12, RequireLowercase: true, RequireUppercase: true, RequireDigit: true, RequireSymbol: true},
+	Denylist: []string{"password1234"},
+}
+
+func TestPasswordPoliciesCheck(t *testing.T) {
+	tests := []struct {
+		password string
+		isAdmin  bool
+		want     error
+	}{
+		{"lamplight", false, nil},
+		{"short", false, entity.ErrPasswordTooShort},
+		{"Password1234", false, entity.ErrPasswordTooCommon},
+		{"Lamp-light-42", true, nil},
+		{"lamplight", true, entity.ErrPasswordTooShort},
+		{"Password1234", true, entity.ErrPasswordTooCommon},
+		{"LAMP-LIGHT-42", true, entity.ErrPasswordMissingLower},
+		{"lamp-light-42", true, entity.ErrPasswordMissingUpper},
+		{"Lamp-light-xx", true, entity.ErrPasswordMissingDigit},
+		{"Lamplight4242", true, entity.ErrPasswordMissingSymbol},
+	}
+	for _, tt := range tests {
+		err := testPasswordPolicies.check("password", tt.password, tt.isAdmin)
+		if tt.want == nil && err != nil {
+			t.Errorf("%q (admin %v): got %v, want it accepted", tt.password, tt.isAdmin, err)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%q (admin %v): got %v, want %v", tt.password, tt.isAdmin, err, tt.want)
+		}
+		if tt.want != nil && tt.isAdmin && !strings.HasPrefix(err.Error(), "admin ") {
+			t.Errorf("%q: got %q, want the error to name the admin policy", tt.password, err)
+		}
+	}
+}
+
+func TestSetUserPasswordAppliesTheAdminPolicyToAdmins(t *testing.T) {
+	users := newFakeUserRepo(
+		&entity.User{Email: "admin@example.com", Username: "admin", IsActive: true, IsAdmin: true},
+		&entity.User{Email: "user@example.com", Username: "user", IsActive: true},
+	)
+	uc := NewAuthUseCase(users, &fakeAuditRepo{}, nil, fakeTxManager{}, EmailVerification{}, PasswordReset{}, bcrypt.MinCost, testPasswordPolicies, nil, nopEventLogger{}, ProfileLimits{}, nil)
+	ctx := context.Background()
+
+	if err := uc.SetUserPassword(ctx, 2, "lamplight"); err != nil {
+		t.Errorf("user: %v", err)
+	}
+	if err := uc.SetUserPassword(ctx, adminID, "lamplight"); !errors.Is(err, entity.ErrPasswordTooShort) {
+		t.Errorf("admin with a user-grade password: got %v, want %v", err, entity.ErrPasswordTooShort)
+	}
+	if err := uc.SetUserPassword(ctx, adminID, "Lamp-light-42"); err != nil {
+		t.Errorf("admin: %v", err)
+	}
+
+	admin, _ := users.GetByID(ctx, adminID)
+	if admin.CheckPassword("Lamp-light-42") != nil {
+		t.Error("the admin's new password was not stored")
+	}
+}
+
+func TestEachCharacterClassIsRequiredOnItsOwn(t *testing.T) {
+	tests := []struct {
+		policy PasswordPolicy
+		want   error
+	}{
+		{PasswordPolicy{RequireLowercase: true}, entity.ErrPasswordMissingLower},
+		{PasswordPolicy{RequireUppercase: true}, entity.ErrPasswordMissingUpper},
+		{PasswordPolicy{RequireDigit: true}, entity.ErrPasswordMissingDigit},
+		{PasswordPolicy{RequireSymbol: true}, entity.ErrPasswordMissingSymbol},
+	}
+	for _, tt := range tests {
+		policies := PasswordPolicies{User: tt.policy}
+		// Each password lacks only the class its policy requires
+		for password, missing := range map[string]error{
+			"LAMP-LIGHT-42": entity.ErrPasswordMissingLower,
+			"lamp-light-42": entity.ErrPasswordMissingUpper,
+			"Lamp-light-xx": entity.ErrPasswordMissingDigit,
+			"Lamplight4242": entity.ErrPasswordMissingSymbol,
+		} {
+			err := policies.check("password", password, false)
+			if missing == tt.want && !errors.Is(err, tt.want) {
+				t.Errorf("%q: got %v, want %v", password, err, tt.want)
+			}
+			if missing != tt.want && err != nil {
+				t.Errorf("%q: got %v, want it accepted when only %v is checked", password, err, tt.want)
+			}
+		}
+	}
+}
+
+func TestRequiresClassesOf(t *testing.T) {
+	user := PasswordPolicy{RequireDigit: true}
+	if !testPasswordPolicies.Admin.RequiresClassesOf(user) {
+		t.Error("a policy requiring every class must cover one requiring a digit")
+	}
+	if (PasswordPolicy{RequireSymbol: true}).RequiresClassesOf(user) {
+		t.Error("a policy without the digit rule must not cover one requiring a digit")
+	}
+	if !(PasswordPolicy{}).RequiresClassesOf(PasswordPolicy{MinLength: 20}) {
+		t.Error("length is not a character class")
+	}
+}
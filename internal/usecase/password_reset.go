@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// PasswordReset configures the forgot-password flow
+type PasswordReset struct {
+	TokenTTL time.Duration
+	ResetURL string // the token is appended as the "token" query parameter
+	// MaxPerEmail reset requests are allowed for an address within Window, to stop email bombing
+	MaxPerEmail int
+	Window      time.Duration
+	Sender      service.EmailSender
+}
+
+// ForgotPassword emails a password reset link to the account registered with email. Only the
+// latest link works: issuing one replaces any outstanding token. It succeeds without sending
+// anything for unknown or inactive addresses, so callers can't probe which emails are
+// registered, but requests past the per-address limit fail with entity.ErrTooManyPasswordResets
+// whether or not the address exists.
+func (uc *AuthUseCase) ForgotPassword(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+	if !uc.resetThrottle.allow(email, time.Now()) {
+		return entity.ErrTooManyPasswordResets
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	expiresAt := time.Now().Add(uc.passwordReset.TokenTTL)
+	user.ResetTokenHash = hashSecretToken(token)
+	user.ResetTokenExpiresAt = &expiresAt
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	link, err := url.Parse(uc.passwordReset.ResetURL)
+	if err != nil {
+		return fmt.Errorf("invalid password reset URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	if err := uc.passwordReset.Sender.SendPasswordResetEmail(ctx, user, link.String()); err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password for the account holding token and consumes the token.
+// The password must satisfy the policy for the account, as on registration.
+func (uc *AuthUseCase) ResetPassword(ctx context.Context, token, password string) error {
+	if token == "" {
+		return entity.ErrBadPasswordResetToken
+	}
+
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetByResetTokenHash(ctx, hashSecretToken(token))
+		if err != nil {
+			if errors.Is(err, entity.ErrUserNotFound) {
+				return entity.ErrBadPasswordResetToken
+			}
+			return err
+		}
+		if user.ResetTokenExpiresAt == nil || time.Now().After(*user.ResetTokenExpiresAt) || !user.IsActive {
+			return entity.ErrBadPasswordResetToken
+		}
+
+		if err := uc.passwords.check("new_password", password, user.IsAdmin); err != nil {
+			return err
+		}
+		if err := user.HashPassword(password, uc.passwordCost); err != nil {
+			return err
+		}
+		user.ClearResetToken()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		uc.logger.InfoContext(ctx, "Password reset", slog.Uint64("user_id", uint64(user.ID)))
+		return uc.auditRepo.Create(ctx, newUserAuditLog(ctx, user.ID, entity.AuditActionResetPassword, nil))
+	})
+}
+
+// requestThrottle allows each key a fixed number of requests per window
+type requestThrottle struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	windows   map[string]*throttleWindow
+	lastSweep time.Time
+}
+
+// throttleWindow counts the requests made for a key in the current window
+type throttleWindow struct {
+	start time.Time
+	count int
+}
+
+// newRequestThrottle creates a throttle allowing limit requests per key per window;
+// a limit of 0 or less disables it
+func newRequestThrottle(limit int, window time.Duration) *requestThrottle {
+	return &requestThrottle{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*throttleWindow),
+	}
+}
+
+// allow records a request for key and reports whether it is within the limit
+func (t *requestThrottle) allow(key string, now time.Time) bool {
+	if t.limit <= 0 {
+		return true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Forget keys whose window has ended so the map doesn't grow without bound
+	if now.Sub(t.lastSweep) >= t.window {
+		for k, w := range t.windows {
+			if now.Sub(w.start) >= t.window {
+				delete(t.windows, k)
+			}
+		}
+		t.lastSweep = now
+	}
+
+	w, ok := t.windows[key]
+	if !ok || now.Sub(w.start) >= t.window {
+		w = &throttleWindow{start: now}
+		t.windows[key] = w
+	}
+	if w.count >= t.limit {
+		return false
+	}
+	w.count++
+	return true
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newPasswordResetAuthUseCase returns an auth use case allowing two reset requests per address
+// an hour, with an active user as user 1 and an inactive one as user 2
+func newPasswordResetAuthUseCase(t *testing.T) (*AuthUseCase, *fakeUserRepo, *fakeEmailSender) {
+	t.Helper()
+	users := newFakeUserRepo(
+		&entity.User{Email: "user@example.com", Username: "user", IsActive: true},
+		&entity.User{Email: "gone@example.com", Username: "gone", IsActive: false},
+	)
+	sender := &fakeEmailSender{}
+	reset := PasswordReset{
+		TokenTTL:    time.Hour,
+		ResetURL:    "http://localhost/reset-password",
+		MaxPerEmail: 2,
+		Window:      time.Hour,
+		Sender:      sender,
+	}
+	uc := NewAuthUseCase(users, &fakeAuditRepo{}, nil, fakeTxManager{}, EmailVerification{}, reset, bcrypt.MinCost, PasswordPolicies{User: PasswordPolicy{MinLength: 8}}, nil, nopEventLogger{}, ProfileLimits{}, nil)
+	return uc, users, sender
+}
+
+// lastResetToken returns the token from the last password reset email sent
+func lastResetToken(t *testing.T, sender *fakeEmailSender) string {
+	t.Helper()
+	if len(sender.resetLinks) == 0 {
+		t.Fatal("no password reset email was sent")
+	}
+	link, err := url.Parse(sender.resetLinks[len(sender.resetLinks)-1])
+	if err != nil {
+		t.Fatalf("parse reset link: %v", err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestResetPasswordWorksOnlyOnce(t *testing.T) {
+	uc, users, sender := newPasswordResetAuthUseCase(t)
+	ctx := context.Background()
+
+	if err := uc.ForgotPassword(ctx, " User@Example.com "); err != nil {
+		t.Fatalf("ForgotPassword: %v", err)
+	}
+	token := lastResetToken(t, sender)
+
+	if err := uc.ResetPassword(ctx, token, "new lamp light"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	user, _ := users.GetByID(ctx, 1)
+	if user.CheckPassword("new lamp light") != nil || user.ResetTokenHash != "" {
+		t.Errorf("got reset token hash %q, want the new password stored and the token consumed", user.ResetTokenHash)
+	}
+
+	if err := uc.ResetPassword(ctx, token, "another lamp light"); !errors.Is(err, entity.ErrBadPasswordResetToken) {
+		t.Errorf("reusing the token: got %v, want %v", err, entity.ErrBadPasswordResetToken)
+	}
+}
+
+func TestForgotPasswordReplacesTheOutstandingToken(t *testing.T) {
+	uc, _, sender := newPasswordResetAuthUseCase(t)
+	ctx := context.Background()
+
+	_ = uc.ForgotPassword(ctx, "user@example.com")
+	first := lastResetToken(t, sender)
+	_ = uc.ForgotPassword(ctx, "user@example.com")
+	second := lastResetToken(t, sender)
+
+	if err := uc.ResetPassword(ctx, first, "new lamp light"); !errors.Is(err, entity.ErrBadPasswordResetToken) {
+		t.Errorf("first token: got %v, want %v", err, entity.ErrBadPasswordResetToken)
+	}
+	if err := uc.ResetPassword(ctx, second, "new lamp light"); err != nil {
+		t.Errorf("second token: %v", err)
+	}
+}
+
+func TestResetPasswordRejectsExpiredTokensAndWeakPasswords(t *testing.T) {
+	uc, users, sender := newPasswordResetAuthUseCase(t)
+	ctx := context.Background()
+
+	_ = uc.ForgotPassword(ctx, "user@example.com")
+	token := lastResetToken(t, sender)
+
+	if err := uc.ResetPassword(ctx, token, "short"); !errors.Is(err, entity.ErrPasswordTooShort) {
+		t.Errorf("weak password: got %v, want %v", err, entity.ErrPasswordTooShort)
+	}
+
+	user, _ := users.GetByID(ctx, 1)
+	expired := time.Now().Add(-time.Minute)
+	user.ResetTokenExpiresAt = &expired
+	_ = users.Update(ctx, user)
+	if err := uc.ResetPassword(ctx, token, "new lamp light"); !errors.Is(err, entity.ErrBadPasswordResetToken) {
+		t.Errorf("expired token: got %v, want %v", err, entity.ErrBadPasswordResetToken)
+	}
+}
+
+func TestForgotPasswordDoesNotRevealWhichEmailsExist(t *testing.T) {
+	uc, _, sender := newPasswordResetAuthUseCase(t)
+	ctx := context.Background()
+
+	for _, email := range []string{"nobody@example.com", "gone@example.com"} {
+		if err := uc.ForgotPassword(ctx, email); err != nil {
+			t.Errorf("%s: got %v, want success", email, err)
+		}
+	}
+	if len(sender.resetLinks) != 0 {
+		t.Errorf("got %d reset emails, want none", len(sender.resetLinks))
+	}
+}
+
+func TestForgotPasswordIsThrottledPerAddress(t *testing.T) {
+	uc, _, _ := newPasswordResetAuthUseCase(t)
+	ctx := context.Background()
+
+	for _, email := range []string{"nobody@example.com", "Nobody@example.com"} {
+		if err := uc.ForgotPassword(ctx, email); err != nil {
+			t.Fatalf("%s: %v", email, err)
+		}
+	}
+	if err := uc.ForgotPassword(ctx, "nobody@example.com"); !errors.Is(err, entity.ErrTooManyPasswordResets) {
+		t.Errorf("third request: got %v, want %v", err, entity.ErrTooManyPasswordResets)
+	}
+	if err := uc.ForgotPassword(ctx, "user@example.com"); err != nil {
+		t.Errorf("another address: got %v, want success", err)
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+func TestImportPricesMatchesProductsBySKU(t *testing.T) {
+	sku := "LAMP-01"
+	products := newFakeProductRepo(
+		&entity.Product{Name: "Desk Lamp", SKU: &sku, Price: 10},
+		&entity.Product{Name: "Mug", Price: 5},
+	)
+	prices := &fakePriceRepo{}
+	uc := NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, prices, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+
+	result, err := uc.ImportPrices(context.Background(), []*service.PriceUpdateRow{
+		{Line: 2, SKU: " LAMP-01 ", Price: 12},
+		{Line: 3, ID: 2, Price: 6},
+		{Line: 4, SKU: "NOPE-99", Price: 1},
+	}, false)
+	if err != nil {
+		t.Fatalf("ImportPrices: %v", err)
+	}
+	if result.Updated != 2 || result.NotFound != 1 {
+		t.Fatalf("got %d updated and %d not found, want 2 and 1", result.Updated, result.NotFound)
+	}
+	if row := result.Rows[0]; row.ID != 1 || row.OldPrice != 10 || row.NewPrice != 12 {
+		t.Errorf("got row %+v, want product 1 repriced from 10 to 12", row)
+	}
+
+	lamp, _ := products.GetByID(context.Background(), 1)
+	if lamp.Price != 12 {
+		t.Errorf("got price %v, want 12", lamp.Price)
+	}
+	if len(prices.entries) != 2 {
+		t.Errorf("got %d price history entries, want 2", len(prices.entries))
+	}
+}
+
+func TestImportPricesPublishesEventsOnlyOnceCommitted(t *testing.T) {
+	products := newFakeProductRepo(
+		&entity.Product{Name: "Desk Lamp", Price: 10},
+		&entity.Product{Name: "Mug", Price: 5},
+	)
+	prices := &fakePriceRepo{failFor: 2}
+	publisher := &fakePublisher{}
+	uc := NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, prices, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, publisher, nil)
+
+	rows := []*service.PriceUpdateRow{{Line: 2, ID: 1, Price: 12}, {Line: 3, ID: 2, Price: 6}}
+	if _, err := uc.ImportPrices(context.Background(), rows, false); err == nil {
+		t.Fatal("ImportPrices succeeded, want the price history failure")
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("failed import published %d events, want none", len(publisher.events))
+	}
+
+	prices.failFor = 0
+	if _, err := uc.ImportPrices(context.Background(), rows, false); err != nil {
+		t.Fatalf("ImportPrices: %v", err)
+	}
+	if len(publisher.events) != 2 {
+		t.Fatalf("got %d events, want one per repriced product", len(publisher.events))
+	}
+	for i, event := range publisher.events {
+		if event.ProductID != uint(i+1) || event.Action != entity.AuditActionUpdate {
+			t.Errorf("got event %+v, want an update of product %d", event, i+1)
+		}
+	}
+}
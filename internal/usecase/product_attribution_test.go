@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"gorm.io/gorm"
+)
+
+// newAttributionProductUseCase returns a product use case over products
+func newAttributionProductUseCase(products *fakeProductRepo) *ProductUseCase {
+	return NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, &fakePriceRepo{}, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+}
+
+// userOf returns the user ID id points to, or 0 for nil
+func userOf(id *uint) uint {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+func TestProductsRecordWhoCreatedAndUpdatedThem(t *testing.T) {
+	products := newFakeProductRepo()
+	uc := newAttributionProductUseCase(products)
+
+	created, err := uc.CreateProduct(service.WithActorID(context.Background(), 5), &CreateProductRequest{Name: "Desk Lamp", Price: 10})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if userOf(created.CreatedBy) != 5 || userOf(created.UpdatedBy) != 5 {
+		t.Errorf("got created_by %d and updated_by %d, want 5 for both", userOf(created.CreatedBy), userOf(created.UpdatedBy))
+	}
+
+	price := 12.5
+	patch := &service.ProductPatchRequest{Version: &created.Version, Price: &price}
+	if _, err := uc.PatchProduct(service.WithActorID(context.Background(), 8), created.ID, patch); err != nil {
+		t.Fatalf("PatchProduct: %v", err)
+	}
+	updated, _ := products.GetByID(context.Background(), created.ID)
+	if userOf(updated.CreatedBy) != 5 || userOf(updated.UpdatedBy) != 8 {
+		t.Errorf("got created_by %d and updated_by %d, want 5 and 8", userOf(updated.CreatedBy), userOf(updated.UpdatedBy))
+	}
+
+	price = 15
+	patch = &service.ProductPatchRequest{Version: &updated.Version, Price: &price}
+	if _, err := uc.PatchProduct(context.Background(), created.ID, patch); err != nil {
+		t.Fatalf("PatchProduct: %v", err)
+	}
+	anonymous, _ := products.GetByID(context.Background(), created.ID)
+	if anonymous.UpdatedBy != nil {
+		t.Errorf("got updated_by %d after an anonymous update, want it cleared", *anonymous.UpdatedBy)
+	}
+}
+
+func TestListDeletedProductsNewestFirst(t *testing.T) {
+	now := time.Now()
+	products := newFakeProductRepo(
+		&entity.Product{Name: "Desk Lamp", DeletedAt: gorm.DeletedAt{Time: now.Add(-time.Hour), Valid: true}},
+		&entity.Product{Name: "Office Chair"},
+		&entity.Product{Name: "Floor Lamp", DeletedAt: gorm.DeletedAt{Time: now, Valid: true}},
+	)
+	uc := newAttributionProductUseCase(products)
+
+	response, err := uc.ListDeletedProducts(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("ListDeletedProducts: %v", err)
+	}
+	if response.Total != 2 || len(response.Products) != 2 {
+		t.Fatalf("got %d of %d products, want the 2 deleted ones", len(response.Products), response.Total)
+	}
+	first, second := response.Products[0], response.Products[1]
+	if first.Name != "Floor Lamp" || second.Name != "Desk Lamp" || !first.DeletedAt.Equal(now) {
+		t.Errorf("got %s then %s, want Floor Lamp (deleted at %v) then Desk Lamp", first.Name, second.Name, now)
+	}
+}
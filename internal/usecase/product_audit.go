@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// auditedProductFields lists the product fields tracked in the audit log
+var auditedProductFields = []string{"name", "sku", "description", "price", "stock", "low_stock_threshold", "category", "category_id", "image_url", "is_active", "tags"}
+
+// productAuditFields returns the audited fields of a product keyed by their JSON name
+func productAuditFields(product *entity.Product) map[string]interface{} {
+	if product == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"name":                product.Name,
+		"sku":                 optionalString(product.SKU),
+		"description":         product.Description,
+		"price":               product.Price,
+		"stock":               product.Stock,
+		"low_stock_threshold": product.LowStockThreshold,
+		"category":            product.Category,
+		"category_id":         optionalID(product.CategoryID),
+		"image_url":           product.ImageURL,
+		"is_active":           product.IsActive,
+		"tags":                strings.Join(product.TagNames(), ","),
+	}
+}
+
+// optionalID dereferences an optional ID so values, not pointers, are compared and recorded
+func optionalID(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+// optionalString dereferences an optional string so values, not pointers, are compared and recorded
+func optionalString(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// diffProducts returns the audited fields that differ between before and after.
+// A nil before (create) or after (delete) reports every field.
+func diffProducts(before, after *entity.Product) entity.AuditChanges {
+	beforeFields, afterFields := productAuditFields(before), productAuditFields(after)
+
+	changes := entity.AuditChanges{}
+	for _, field := range auditedProductFields {
+		beforeValue, afterValue := beforeFields[field], afterFields[field]
+		if before != nil && after != nil && beforeValue == afterValue {
+			continue
+		}
+		changes[field] = entity.FieldChange{Before: beforeValue, After: afterValue}
+	}
+	return changes
+}
+
+// recordProductAudit records a product mutation made by the actor in ctx and logs it as a
+// business event. Audit failures are logged rather than returned so they never fail the
+// operation being audited.
+func (uc *ProductUseCase) recordProductAudit(ctx context.Context, action string, productID uint, changes entity.AuditChanges) {
+	uc.recordProductAuditWithReason(ctx, action, productID, changes, "")
+}
+
+// recordProductAuditWithReason is recordProductAudit for a mutation made for reason
+func (uc *ProductUseCase) recordProductAuditWithReason(ctx context.Context, action string, productID uint, changes entity.AuditChanges, reason string) {
+	if action == entity.AuditActionUpdate && len(changes) == 0 {
+		return
+	}
+
+	fields := map[string]interface{}{"changed_fields": changedFields(changes)}
+	if change, ok := changes["stock"]; ok && action == entity.AuditActionStockChange {
+		fields["stock_before"], fields["stock_after"] = change.Before, change.After
+	}
+	if reason != "" {
+		fields["reason"] = reason
+	}
+	logEvent(ctx, uc.events, productEventTypes[action], entity.AuditEntityProduct, productID, fields)
+	uc.publishProductEvent(ctx, action, productID)
+
+	auditLog := &entity.AuditLog{
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   productID,
+		Action:     action,
+		Changes:    changes,
+		Reason:     reason,
+	}
+	if actorID, ok := service.ActorIDFromContext(ctx); ok {
+		auditLog.ActorUserID = &actorID
+	}
+
+	// Running in its own transaction (a savepoint when ctx already has one) keeps a failed
+	// insert from aborting the caller's transaction
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return uc.auditRepo.Create(ctx, auditLog)
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to record product audit", slog.String("action", action), slog.Uint64("product_id", uint64(productID)), slog.Any("error", err))
+	}
+}
+
+// publishProductEvent publishes a product event for a mutation made by the actor in ctx.
+// Publish failures are logged rather than returned so they never fail the mutation.
+func (uc *ProductUseCase) publishProductEvent(ctx context.Context, action string, productID uint) {
+	event := service.ProductEvent{
+		ProductID:  productID,
+		Action:     action,
+		OccurredAt: time.Now(),
+	}
+	if actorID, ok := service.ActorIDFromContext(ctx); ok {
+		event.ActorID = &actorID
+	}
+
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to publish product event", slog.String("action", action), slog.Uint64("product_id", uint64(productID)), slog.Any("error", err))
+	}
+}
+
+// insertProduct creates product, attributed to the actor in ctx
+func (uc *ProductUseCase) insertProduct(ctx context.Context, product *entity.Product) error {
+	product.CreatedBy = actingUserID(ctx)
+	product.UpdatedBy = product.CreatedBy
+	return uc.productRepo.Create(ctx, product)
+}
+
+// insertProductSavepoint inserts product in a savepoint, so a failed insert can be retried
+// without aborting the enclosing transaction
+func (uc *ProductUseCase) insertProductSavepoint(ctx context.Context, product *entity.Product) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return uc.insertProduct(ctx, product)
+	})
+}
+
+// saveProduct updates product, attributed to the actor in ctx. An anonymous update clears
+// UpdatedBy rather than leave it naming someone who didn't make the change.
+func (uc *ProductUseCase) saveProduct(ctx context.Context, product *entity.Product) error {
+	product.UpdatedBy = actingUserID(ctx)
+	return uc.productRepo.Update(ctx, product)
+}
+
+// actingUserID returns the ID of the user in ctx, or nil for anonymous operations
+func actingUserID(ctx context.Context) *uint {
+	if id, ok := service.ActorIDFromContext(ctx); ok {
+		return &id
+	}
+	return nil
+}
+
+// recordStockAudit records a relative stock change of delta, made for reason if not empty.
+// product is the product as the atomic update that changed its stock left it, so the levels
+// recorded are exactly those before and after that update, whatever ran concurrently.
+func (uc *ProductUseCase) recordStockAudit(ctx context.Context, product *entity.Product, delta int, reason string) {
+	uc.recordProductAuditWithReason(ctx, entity.AuditActionStockChange, product.ID, entity.AuditChanges{
+		"stock": {Before: product.Stock - delta, After: product.Stock},
+	}, reason)
+	uc.notifyLowStock(ctx, product, product.Stock-delta)
+}
+
+// notifyLowStock logs a low stock event when product's stock has just dropped from above its
+// low stock threshold to at or below it. Products that were already low don't raise it again.
+func (uc *ProductUseCase) notifyLowStock(ctx context.Context, product *entity.Product, previousStock int) {
+	if previousStock <= product.LowStockThreshold || !product.IsLowStock() {
+		return
+	}
+	logEvent(ctx, uc.events, service.EventProductLowStock, entity.AuditEntityProduct, product.ID, map[string]interface{}{
+		"stock":               product.Stock,
+		"low_stock_threshold": product.LowStockThreshold,
+	})
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+func TestBulkUpdateProductStatusReportsWhatTookEffect(t *testing.T) {
+	products := newFakeProductRepo(
+		&entity.Product{Name: "Desk Lamp", IsActive: true},
+		&entity.Product{Name: "Old Mug", IsActive: true, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		&entity.Product{Name: "Chair", IsActive: true},
+	)
+	uc := NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, nil, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+
+	result, err := uc.BulkUpdateProductStatus(context.Background(), []uint{3, 2, 99, 1, 3}, false)
+	if err != nil {
+		t.Fatalf("BulkUpdateProductStatus: %v", err)
+	}
+	if !reflect.DeepEqual(result.Updated, []uint{3, 1}) || !reflect.DeepEqual(result.SoftDeleted, []uint{2}) || !reflect.DeepEqual(result.NotFound, []uint{99}) {
+		t.Errorf("got updated %v, soft-deleted %v, not found %v, want [3 1], [2] and [99]", result.Updated, result.SoftDeleted, result.NotFound)
+	}
+
+	for id, wantActive := range map[uint]bool{1: false, 2: true, 3: false} {
+		if product, _ := products.GetByID(context.Background(), id); product.IsActive != wantActive {
+			t.Errorf("product %d: got active=%v, want %v", id, product.IsActive, wantActive)
+		}
+	}
+}
+
+func TestBulkUpdateProductStatusReportsEmptyGroupsAsEmptyLists(t *testing.T) {
+	uc := NewProductUseCase(newFakeProductRepo(), nil, nil, nil, &fakeAuditRepo{}, nil, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+
+	result, err := uc.BulkUpdateProductStatus(context.Background(), []uint{5}, true)
+	if err != nil {
+		t.Fatalf("BulkUpdateProductStatus: %v", err)
+	}
+	if result.Updated == nil || result.SoftDeleted == nil {
+		t.Errorf("got updated %#v and soft-deleted %#v, want empty lists rather than nil", result.Updated, result.SoftDeleted)
+	}
+
+	if _, err := uc.BulkUpdateProductStatus(context.Background(), nil, true); !errors.Is(err, entity.ErrInvalidInput) {
+		t.Errorf("no IDs: got %v, want %v", err, entity.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+)
+
+// queryCountingProductRepo counts the list queries run against the wrapped repository
+type queryCountingProductRepo struct {
+	*fakeProductRepo
+	queries int
+}
+
+func (r *queryCountingProductRepo) GetTotalCount(ctx context.Context, filter *repository.ProductFilter) (int64, error) {
+	r.queries++
+	return r.fakeProductRepo.GetTotalCount(ctx, filter)
+}
+
+func (r *queryCountingProductRepo) GetAll(ctx context.Context, filter *repository.ProductFilter, offset, limit int) ([]*entity.Product, error) {
+	r.queries++
+	return r.fakeProductRepo.GetAll(ctx, filter, offset, limit)
+}
+
+func TestGetProductsSkipsQueriesOnceTheContextEnds(t *testing.T) {
+	repo := &queryCountingProductRepo{fakeProductRepo: newFakeProductRepo(&entity.Product{Name: "Desk Lamp", Price: 10})}
+	uc := NewProductUseCase(repo, nil, nil, nil, &fakeAuditRepo{}, nil, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := uc.GetProducts(ctx, &repository.ProductFilter{}, 1, 10); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if repo.queries != 0 {
+		t.Errorf("got %d queries after the context ended, want none", repo.queries)
+	}
+
+	if _, err := uc.GetProducts(context.Background(), &repository.ProductFilter{}, 1, 10); err != nil {
+		t.Fatalf("GetProducts: %v", err)
+	}
+	if repo.queries != 2 {
+		t.Errorf("got %d queries for a live request, want 2", repo.queries)
+	}
+}
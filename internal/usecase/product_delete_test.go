@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// deleteDeskLamp soft-deletes an active desk lamp, deactivating it first if deactivateOnDelete
+// is set, and returns the stored product and what was audited and logged
+func deleteDeskLamp(t *testing.T, deactivateOnDelete bool) (*entity.Product, *fakeAuditRepo, *fakeEventLogger) {
+	t.Helper()
+	products := newFakeProductRepo(&entity.Product{Name: "Desk Lamp", Price: 10, IsActive: true})
+	audit, events := &fakeAuditRepo{}, &fakeEventLogger{}
+	uc := NewProductUseCase(products, fakeImageRepo{}, nil, nil, audit, nil, nil, fakeTxManager{}, ProductUseCaseOptions{DeactivateOnDelete: deactivateOnDelete, ImageReportThreshold: 3, NamesPerCategory: true}, events, nil, nil)
+
+	if err := uc.DeleteProduct(context.Background(), 1, false); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+	product, err := products.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	return product, audit, events
+}
+
+// eventTypes returns the types of the events logged, in order
+func eventTypes(events *fakeEventLogger) []string {
+	types := make([]string, 0, len(events.events))
+	for _, event := range events.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestDeleteProductDeactivatesItFirstWhenConfigured(t *testing.T) {
+	product, audit, events := deleteDeskLamp(t, true)
+
+	if product.IsActive || !product.DeletedAt.Valid {
+		t.Errorf("got active=%v deleted=%v, want an inactive, deleted product", product.IsActive, product.DeletedAt.Valid)
+	}
+	if len(audit.entries) != 2 || audit.entries[0].Action != entity.AuditActionDeactivate || audit.entries[1].Action != entity.AuditActionDelete {
+		t.Fatalf("got %d audit entries, want a deactivation then a deletion", len(audit.entries))
+	}
+	if change := audit.entries[0].Changes["is_active"]; change.Before != true || change.After != false {
+		t.Errorf("got is_active change %v -> %v, want true -> false", change.Before, change.After)
+	}
+
+	types := eventTypes(events)
+	if len(types) != 2 || types[0] != service.EventProductDeactivated || types[1] != service.EventProductDeleted {
+		t.Errorf("got events %q, want %s then %s", types, service.EventProductDeactivated, service.EventProductDeleted)
+	}
+}
+
+func TestDeleteProductLeavesItActiveByDefault(t *testing.T) {
+	product, audit, events := deleteDeskLamp(t, false)
+
+	if !product.IsActive || !product.DeletedAt.Valid {
+		t.Errorf("got active=%v deleted=%v, want an active, deleted product", product.IsActive, product.DeletedAt.Valid)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != entity.AuditActionDelete {
+		t.Errorf("got %d audit entries, want only the deletion", len(audit.entries))
+	}
+	if types := eventTypes(events); len(types) != 1 || types[0] != service.EventProductDeleted {
+		t.Errorf("got events %q, want only %s", types, service.EventProductDeleted)
+	}
+}
+
+func TestHardDeleteProductRemovesIt(t *testing.T) {
+	products := newFakeProductRepo(&entity.Product{Name: "Desk Lamp", Price: 10, IsActive: true})
+	audit, events := &fakeAuditRepo{}, &fakeEventLogger{}
+	uc := NewProductUseCase(products, fakeImageRepo{}, nil, nil, audit, nil, nil, fakeTxManager{}, ProductUseCaseOptions{DeactivateOnDelete: true, ImageReportThreshold: 3, NamesPerCategory: true}, events, nil, nil)
+
+	if err := uc.HardDeleteProduct(context.Background(), 1, false); err != nil {
+		t.Fatalf("HardDeleteProduct: %v", err)
+	}
+	if _, err := products.GetByID(context.Background(), 1); err != entity.ErrProductNotFound {
+		t.Errorf("got %v, want %v once the product is gone", err, entity.ErrProductNotFound)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != entity.AuditActionHardDelete {
+		t.Errorf("got %d audit entries, want only the permanent deletion", len(audit.entries))
+	}
+	if types := eventTypes(events); len(types) != 1 || types[0] != service.EventProductHardDeleted {
+		t.Errorf("got events %q, want only %s", types, service.EventProductHardDeleted)
+	}
+
+	if err := uc.HardDeleteProduct(context.Background(), 1, false); err != entity.ErrProductNotFound {
+		t.Errorf("deleting again: got %v, want %v", err, entity.ErrProductNotFound)
+	}
+}
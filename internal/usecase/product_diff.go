@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// productSnapshot is a product's audited fields as they were at some point in time
+type productSnapshot struct {
+	existed bool
+	fields  map[string]interface{}
+}
+
+// GetProductDiff reconstructs a product at from and to by replaying its audit trail and
+// reports the audited fields that differ between the two points
+func (uc *ProductUseCase) GetProductDiff(ctx context.Context, id uint, from, to time.Time) (*service.ProductDiff, error) {
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", entity.ErrInvalidInput)
+	}
+
+	history, err := uc.auditRepo.ListByEntity(ctx, entity.AuditEntityProduct, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fields never touched by the audit trail still have their current value
+	current, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil && !errors.Is(err, entity.ErrProductNotFound) {
+		return nil, err
+	}
+	if current == nil && len(history) == 0 {
+		return nil, entity.ErrProductNotFound
+	}
+	currentFields, err := normalizeAuditFields(productAuditFields(current))
+	if err != nil {
+		return nil, err
+	}
+
+	before := replayProductHistory(history, currentFields, from)
+	after := replayProductHistory(history, currentFields, to)
+
+	changes := entity.AuditChanges{}
+	for _, field := range auditedProductFields {
+		beforeValue, afterValue := before.fields[field], after.fields[field]
+		if before.existed && after.existed && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		if !before.existed && !after.existed {
+			continue
+		}
+		changes[field] = entity.FieldChange{Before: beforeValue, After: afterValue}
+	}
+
+	return &service.ProductDiff{
+		ProductID:     id,
+		From:          from,
+		To:            to,
+		ExistedAtFrom: before.existed,
+		ExistedAtTo:   after.existed,
+		Changes:       changes,
+	}, nil
+}
+
+// replayProductHistory reconstructs a product's audited fields at ts from its audit trail,
+// oldest entry first. A field takes the after value of the last entry at or before ts that
+// changed it, else the before value of the first later entry that did, else its current
+// value. A product whose trail has no create entry predates auditing and is assumed to have
+// existed all along.
+func replayProductHistory(history []*entity.AuditLog, current map[string]interface{}, ts time.Time) productSnapshot {
+	snapshot := productSnapshot{existed: true, fields: map[string]interface{}{}}
+
+	var lastAction string
+	createdLater := false
+	for _, entry := range history {
+		if entry.CreatedAt.After(ts) {
+			if entry.Action == entity.AuditActionCreate {
+				createdLater = true
+			}
+			continue
+		}
+		lastAction = entry.Action
+	}
+	switch {
+	case lastAction == entity.AuditActionDelete:
+		snapshot.existed = false
+	case lastAction == "" && createdLater:
+		snapshot.existed = false
+	}
+	if !snapshot.existed {
+		return snapshot
+	}
+
+	for _, field := range auditedProductFields {
+		value, found := current[field], false
+		for i := len(history) - 1; i >= 0; i-- {
+			entry := history[i]
+			change, ok := entry.Changes[field]
+			if !ok || entry.CreatedAt.After(ts) || entry.Action == entity.AuditActionDelete {
+				continue
+			}
+			value, found = change.After, true
+			break
+		}
+		if !found {
+			for _, entry := range history {
+				if change, ok := entry.Changes[field]; ok && entry.CreatedAt.After(ts) {
+					value = change.Before
+					break
+				}
+			}
+		}
+		snapshot.fields[field] = value
+	}
+	return snapshot
+}
+
+// normalizeAuditFields round-trips fields through JSON so they compare equal to values read
+// back from the audit trail (numbers become float64, for instance)
+func normalizeAuditFields(fields map[string]interface{}) (map[string]interface{}, error) {
+	if fields == nil {
+		return map[string]interface{}{}, nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// ReportBrokenImage records a client's report that a product's image fails to load. The product
+// is flagged for review, and a product.needs_review event logged, when the report count reaches
+// the configured threshold; later reports keep counting without raising the event again.
+func (uc *ProductUseCase) ReportBrokenImage(ctx context.Context, id uint) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.ReportBrokenImage(ctx, id, uc.imageReportThreshold); err != nil {
+			return err
+		}
+
+		// The update holds the row lock, so this reads the count our report produced
+		product, err := uc.productRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if product.ImageURL == "" {
+			return entity.NewValidationError("image_url", "required", fmt.Errorf("%w: product has no image", entity.ErrInvalidInput))
+		}
+
+		if product.NeedsReview && product.ImageReports == uc.imageReportThreshold {
+			logEvent(ctx, uc.events, service.EventProductNeedsReview, entity.AuditEntityProduct, product.ID, map[string]interface{}{
+				"reason":        "broken_image",
+				"image_reports": product.ImageReports,
+				"image_url":     product.ImageURL,
+			})
+		}
+		return nil
+	})
+}
+
+// GetImageReports retrieves a page of products whose image has been reported broken, those
+// flagged for review first
+func (uc *ProductUseCase) GetImageReports(ctx context.Context, page, pageSize int) (*service.ProductListResponse, error) {
+	if pageSize < 1 {
+		return nil, fmt.Errorf("%w: page size must be a positive integer", entity.ErrInvalidInput)
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	total, err := uc.productRepo.CountImageReports(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := uc.productRepo.GetImageReports(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.ProductListResponse{
+		Products: products,
+		PageInfo: service.NewPageInfo(total, page, pageSize),
+	}, nil
+}
+
+// ClearImageReports resets a product's broken image reports and review flag once its image
+// has been checked or replaced
+func (uc *ProductUseCase) ClearImageReports(ctx context.Context, id uint) error {
+	return uc.productRepo.ClearImageReports(ctx, id)
+}
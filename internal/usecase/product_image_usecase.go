@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// AddProductImage appends an image to a product's gallery. The first image of a product,
+// or one flagged as primary, becomes the primary image.
+func (uc *ProductUseCase) AddProductImage(ctx context.Context, productID uint, req *service.AddProductImageRequest) (*entity.ProductImage, error) {
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	image := &entity.ProductImage{
+		ProductID: productID,
+		URL:       req.URL,
+		AltText:   req.AltText,
+	}
+
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		for _, existing := range product.Images {
+			if existing.SortOrder >= image.SortOrder {
+				image.SortOrder = existing.SortOrder + 1
+			}
+		}
+		if err := uc.imageRepo.Create(ctx, image); err != nil {
+			return err
+		}
+
+		if req.IsPrimary || len(product.Images) == 0 {
+			if err := uc.imageRepo.SetPrimary(ctx, productID, image.ID); err != nil {
+				return err
+			}
+			image.IsPrimary = true
+		}
+
+		return uc.syncPrimaryImage(ctx, product)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// DeleteProductImage removes an image from a product's gallery. If it was the primary image,
+// the next image in display order is promoted.
+func (uc *ProductUseCase) DeleteProductImage(ctx context.Context, productID, imageID uint) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.imageRepo.Delete(ctx, productID, imageID); err != nil {
+			return err
+		}
+
+		images, err := uc.imageRepo.ListByProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if len(images) > 0 && primaryImage(images) == nil {
+			if err := uc.imageRepo.SetPrimary(ctx, productID, images[0].ID); err != nil {
+				return err
+			}
+		}
+
+		return uc.syncPrimaryImage(ctx, product)
+	})
+}
+
+// ReorderProductImages sets the display order of a product's images and optionally changes
+// the primary image. ImageIDs must list every image of the product exactly once.
+func (uc *ProductUseCase) ReorderProductImages(ctx context.Context, productID uint, req *service.ReorderProductImagesRequest) ([]*entity.ProductImage, error) {
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	var images []*entity.ProductImage
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[uint]bool, len(product.Images))
+		for _, image := range product.Images {
+			current[image.ID] = true
+		}
+		if len(req.ImageIDs) != len(current) {
+			return fmt.Errorf("%w: image_ids must list all %d images of the product", entity.ErrInvalidInput, len(current))
+		}
+		for _, imageID := range req.ImageIDs {
+			if !current[imageID] {
+				return fmt.Errorf("%w: image %d does not belong to the product", entity.ErrInvalidInput, imageID)
+			}
+		}
+		if req.PrimaryImageID != nil && !current[*req.PrimaryImageID] {
+			return fmt.Errorf("%w: image %d does not belong to the product", entity.ErrInvalidInput, *req.PrimaryImageID)
+		}
+
+		if err := uc.imageRepo.SetSortOrders(ctx, productID, req.ImageIDs); err != nil {
+			return err
+		}
+		if req.PrimaryImageID != nil {
+			if err := uc.imageRepo.SetPrimary(ctx, productID, *req.PrimaryImageID); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.syncPrimaryImage(ctx, product); err != nil {
+			return err
+		}
+
+		images, err = uc.imageRepo.ListByProduct(ctx, productID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// syncPrimaryImage copies the primary image URL into product.ImageURL for older clients.
+// The product is saved even when the URL is unchanged so its cached copy, which includes
+// the gallery, is refreshed.
+func (uc *ProductUseCase) syncPrimaryImage(ctx context.Context, product *entity.Product) error {
+	images, err := uc.imageRepo.ListByProduct(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+
+	before := *product
+	product.ImageURL = ""
+	if primary := primaryImage(images); primary != nil {
+		product.ImageURL = primary.URL
+	}
+
+	if err := uc.saveProduct(ctx, product); err != nil {
+		return err
+	}
+	uc.recordProductAudit(ctx, entity.AuditActionUpdate, product.ID, diffProducts(&before, product))
+
+	return nil
+}
+
+// primaryImage returns the image flagged as primary, if any
+func primaryImage(images []*entity.ProductImage) *entity.ProductImage {
+	for _, image := range images {
+		if image.IsPrimary {
+			return image
+		}
+	}
+	return nil
+}
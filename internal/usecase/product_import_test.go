@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+func TestImportProductsRecordsPriceChanges(t *testing.T) {
+	products := newFakeProductRepo(&entity.Product{Name: "Desk Lamp", Price: 10, Stock: 3, IsActive: true})
+	prices := &fakePriceRepo{}
+	uc := NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, prices, nil, fakeTxManager{}, ProductUseCaseOptions{}, nopEventLogger{}, nil, nil)
+
+	result, err := uc.ImportProducts(context.Background(), []*service.ProductImportRow{
+		{Line: 2, Name: "Desk Lamp", Price: 12, Stock: 3},
+		{Line: 3, Name: "Mug", Price: 5, Stock: 10},
+	})
+	if err != nil {
+		t.Fatalf("ImportProducts: %v", err)
+	}
+	if result.Updated != 1 || result.Created != 1 {
+		t.Fatalf("got %d updated and %d created, want 1 and 1", result.Updated, result.Created)
+	}
+
+	// Creating a product sets its first price rather than changing one
+	if len(prices.entries) != 1 {
+		t.Fatalf("got %d price history entries, want 1", len(prices.entries))
+	}
+	if entry := prices.entries[0]; entry.ProductID != 1 || entry.OldPrice != 10 || entry.NewPrice != 12 {
+		t.Errorf("got entry %+v, want product 1 repriced from 10 to 12", entry)
+	}
+}
+
+func TestImportProductsSkipsRowsWhosePriceChangeCannotBeRecorded(t *testing.T) {
+	products := newFakeProductRepo(&entity.Product{Name: "Desk Lamp", Price: 10, Stock: 3, IsActive: true})
+	prices := &fakePriceRepo{failFor: 1}
+	uc := NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, prices, nil, fakeTxManager{}, ProductUseCaseOptions{}, nopEventLogger{}, nil, nil)
+
+	result, err := uc.ImportProducts(context.Background(), []*service.ProductImportRow{
+		{Line: 2, Name: "Desk Lamp", Price: 12, Stock: 3},
+	})
+	if err != nil {
+		t.Fatalf("ImportProducts: %v", err)
+	}
+	if result.Updated != 0 || result.Skipped != 1 {
+		t.Errorf("got %d updated and %d skipped, want the row skipped", result.Updated, result.Skipped)
+	}
+}
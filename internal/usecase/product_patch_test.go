@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// patchDeskLamp applies the JSON patch body to a described, categorized desk lamp and returns
+// the stored product
+func patchDeskLamp(t *testing.T, body string) (*entity.Product, error) {
+	t.Helper()
+	products := newFakeProductRepo(&entity.Product{Name: "Desk Lamp", Description: "Warm light", Category: "Lighting", Price: 10, Stock: 4, Version: 1})
+	uc := NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, &fakePriceRepo{}, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+
+	var req service.ProductPatchRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	if _, err := uc.PatchProduct(context.Background(), 1, &req); err != nil {
+		return nil, err
+	}
+	product, err := products.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	return product, nil
+}
+
+func TestPatchProductLeavesAbsentFieldsUnchanged(t *testing.T) {
+	product, err := patchDeskLamp(t, `{"version": 1, "price": 12.5}`)
+	if err != nil {
+		t.Fatalf("PatchProduct: %v", err)
+	}
+	if product.Price != 12.5 || product.Name != "Desk Lamp" || product.Description != "Warm light" || product.Category != "Lighting" || product.Stock != 4 {
+		t.Errorf("got %+v, want only the price changed", product)
+	}
+}
+
+func TestPatchProductClearsNullFields(t *testing.T) {
+	product, err := patchDeskLamp(t, `{"version": 1, "description": null, "category": "Office"}`)
+	if err != nil {
+		t.Fatalf("PatchProduct: %v", err)
+	}
+	if product.Description != "" || product.Category != "Office" {
+		t.Errorf("got description %q and category %q, want it cleared and Office", product.Description, product.Category)
+	}
+}
+
+func TestPatchProductRejectsInvalidPatches(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"empty string instead of null", `{"version": 1, "description": ""}`, entity.ErrInvalidInput},
+		{"missing version", `{"price": 12.5}`, entity.ErrValidationFailed},
+		{"stale version", `{"version": 2, "price": 12.5}`, entity.ErrConcurrentModification},
+	}
+	for _, tt := range tests {
+		if _, err := patchDeskLamp(t, tt.body); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/pkg/metrics"
+)
+
+// reservationReleaseBatchSize caps how many expired reservations are released per transaction
+const reservationReleaseBatchSize = 100
+
+// GetProductReservations returns a product's reservations that haven't expired yet, soonest
+// to expire first, with the total quantity they hold
+func (uc *ProductUseCase) GetProductReservations(ctx context.Context, id uint) (*service.ProductReservations, error) {
+	if _, err := uc.productRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	reservations, err := uc.reservationRepo.ListActiveByProduct(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &service.ProductReservations{
+		ProductID:    id,
+		Reservations: reservations,
+	}
+	for _, reservation := range reservations {
+		result.TotalReserved += reservation.Quantity
+	}
+	return result, nil
+}
+
+// ReserveStock holds back req.Quantity units of a product's stock for req.OwnerID until
+// req.ExpiresAt, moving them from the product's available stock to its reserved units. It
+// fails with entity.ErrInsufficientStock if fewer units are available.
+func (uc *ProductUseCase) ReserveStock(ctx context.Context, productID uint, req *service.ReserveStockRequest) (*entity.Reservation, error) {
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+	if !req.ExpiresAt.After(time.Now()) {
+		return nil, entity.NewValidationError("expires_at", "future", fmt.Errorf("%w: expires_at must be in the future", entity.ErrInvalidInput))
+	}
+
+	reservation := &entity.Reservation{
+		ProductID: productID,
+		Quantity:  req.Quantity,
+		OwnerID:   req.OwnerID,
+		ExpiresAt: req.ExpiresAt,
+	}
+	var product *entity.Product
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if product, err = uc.productRepo.ReserveStock(ctx, productID, req.Quantity); err != nil {
+			return err
+		}
+		return uc.reservationRepo.Create(ctx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.recordStockAudit(ctx, product, -req.Quantity, entity.StockReasonReserved)
+	return reservation, nil
+}
+
+// ReleaseExpiredReservations returns the stock held by stale reservations to their products
+// and removes the reservations, reporting how many were released. A reservation is stale once
+// it has expired or, when ttl is positive, once it is older than ttl, e.g. because its cart was
+// abandoned. Each reservation is released in its own savepoint, so one that fails is logged and
+// left for the next run without undoing the others. A released reservation no longer exists,
+// so running this again (or on several instances at once) never returns the same stock twice.
+func (uc *ProductUseCase) ReleaseExpiredReservations(ctx context.Context, ttl time.Duration) (int, error) {
+	total := 0
+	for {
+		var batch []*entity.Reservation
+		var released []releasedReservation
+		failed := 0
+		err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			now := time.Now()
+			var createdBefore time.Time // the zero time matches no reservation
+			if ttl > 0 {
+				createdBefore = now.Add(-ttl)
+			}
+
+			var err error
+			batch, err = uc.reservationRepo.LockStale(ctx, now, createdBefore, reservationReleaseBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, reservation := range batch {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				product, err := uc.releaseReservation(ctx, reservation)
+				if err != nil {
+					uc.logger.ErrorContext(ctx, "Failed to release reservation", slog.Uint64("reservation_id", uint64(reservation.ID)), slog.Uint64("product_id", uint64(reservation.ProductID)), slog.Any("error", err))
+					failed++
+					continue
+				}
+				released = append(released, releasedReservation{reservation: reservation, product: product})
+			}
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		for _, r := range released {
+			if r.product != nil {
+				uc.recordStockAudit(ctx, r.product, r.reservation.Quantity, entity.StockReasonReservationExpired)
+			}
+		}
+		total += len(released)
+		metrics.ReservationsReleasedTotal.Add(float64(len(released)))
+		metrics.ReservationReleaseFailuresTotal.Add(float64(failed))
+
+		// Failed reservations would be picked up again straight away, so leave them to the next run
+		if len(batch) < reservationReleaseBatchSize || failed > 0 {
+			return total, nil
+		}
+	}
+}
+
+// releasedReservation is a reservation removed by ReleaseExpiredReservations, with its product
+// as the release left it, or nil if the product had been deleted
+type releasedReservation struct {
+	reservation *entity.Reservation
+	product     *entity.Product
+}
+
+// releaseReservation moves a reservation's units from its product's reserved units back to
+// its stock and removes it, in a savepoint of the transaction in ctx. It returns the product as
+// the release left it, or nil if the product has been deleted.
+func (uc *ProductUseCase) releaseReservation(ctx context.Context, reservation *entity.Reservation) (*entity.Product, error) {
+	var product *entity.Product
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// A deleted product has no stock to return, but its reservation is still dropped
+		var err error
+		product, err = uc.productRepo.ReleaseReservedStock(ctx, reservation.ProductID, reservation.Quantity)
+		if err != nil && !errors.Is(err, entity.ErrProductNotFound) {
+			return err
+		}
+		return uc.reservationRepo.Delete(ctx, reservation.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// RunReservationReleaser releases stale reservations every interval until ctx is done, see
+// ReleaseExpiredReservations. A run in progress when ctx is done is rolled back, and the
+// releaser returns once it has stopped, so callers can wait for it before closing the database.
+func (uc *ProductUseCase) RunReservationReleaser(ctx context.Context, interval, ttl time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := uc.ReleaseExpiredReservations(ctx, ttl)
+			metrics.ReservationsReleasedPerRun.Observe(float64(released))
+			// Nothing can be released while read-only mode is on, which isn't worth a log line
+			if err != nil && ctx.Err() == nil && !errors.Is(err, entity.ErrReadOnlyMode) {
+				uc.logger.ErrorContext(ctx, "Failed to release stale reservations", slog.Any("error", err))
+			}
+			if released > 0 {
+				uc.logger.InfoContext(ctx, "Released stale reservations", slog.Int("released", released))
+			}
+		}
+	}
+}
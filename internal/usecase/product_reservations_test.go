@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// newReservationProductUseCase returns a product use case with product 1 holding stock units
+func newReservationProductUseCase(stock int) (*ProductUseCase, *fakeProductRepo, *fakeReservationRepo) {
+	products := newFakeProductRepo(&entity.Product{Name: "Desk Lamp", Price: 10, Stock: stock})
+	reservations := newFakeReservationRepo()
+	uc := NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, nil, reservations, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+	return uc, products, reservations
+}
+
+func stockOf(t *testing.T, products *fakeProductRepo) (stock, reserved int) {
+	t.Helper()
+	product, err := products.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	return product.Stock, product.Reserved
+}
+
+func TestReserveStockMovesUnitsToReserved(t *testing.T) {
+	uc, products, reservations := newReservationProductUseCase(10)
+
+	_, err := uc.ReserveStock(context.Background(), 1, &service.ReserveStockRequest{Quantity: 4, OwnerID: "cart-1", ExpiresAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("ReserveStock: %v", err)
+	}
+	if stock, reserved := stockOf(t, products); stock != 6 || reserved != 4 {
+		t.Errorf("got stock=%d reserved=%d, want 6 and 4", stock, reserved)
+	}
+	if len(reservations.reservations) != 1 {
+		t.Errorf("got %d reservations, want 1", len(reservations.reservations))
+	}
+}
+
+func TestReserveStockRefusesMoreThanAvailable(t *testing.T) {
+	uc, products, reservations := newReservationProductUseCase(3)
+
+	_, err := uc.ReserveStock(context.Background(), 1, &service.ReserveStockRequest{Quantity: 4, OwnerID: "cart-1", ExpiresAt: time.Now().Add(time.Hour)})
+	if !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Fatalf("got %v, want %v", err, entity.ErrInsufficientStock)
+	}
+	if stock, reserved := stockOf(t, products); stock != 3 || reserved != 0 {
+		t.Errorf("got stock=%d reserved=%d, want 3 and 0", stock, reserved)
+	}
+	if len(reservations.reservations) != 0 {
+		t.Errorf("got %d reservations, want none", len(reservations.reservations))
+	}
+}
+
+func TestReleaseExpiredReservationsOnlyReturnsReservedUnits(t *testing.T) {
+	uc, products, reservations := newReservationProductUseCase(10)
+
+	_, err := uc.ReserveStock(context.Background(), 1, &service.ReserveStockRequest{Quantity: 4, OwnerID: "cart-1", ExpiresAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("ReserveStock: %v", err)
+	}
+	for _, reservation := range reservations.reservations {
+		reservation.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+	// A reservation that never took stock out must not put any back
+	_ = reservations.Create(context.Background(), &entity.Reservation{ProductID: 1, Quantity: 5, OwnerID: "cart-2", ExpiresAt: time.Now().Add(-time.Minute)})
+
+	released, err := uc.ReleaseExpiredReservations(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ReleaseExpiredReservations: %v", err)
+	}
+	if released != 1 {
+		t.Errorf("released %d reservations, want only the one that held stock", released)
+	}
+	if stock, reserved := stockOf(t, products); stock != 10 || reserved != 0 {
+		t.Errorf("got stock=%d reserved=%d, want 10 and 0", stock, reserved)
+	}
+
+	// Running again releases nothing and leaves the stock alone
+	if again, _ := uc.ReleaseExpiredReservations(context.Background(), 0); again != 0 {
+		t.Errorf("second run released %d reservations, want 0", again)
+	}
+	if stock, _ := stockOf(t, products); stock != 10 {
+		t.Errorf("got stock=%d after a second run, want 10", stock)
+	}
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// priceEndingValidator requires prices to end in a fixed number of cents, e.g. .99
+type priceEndingValidator struct {
+	cents int
+}
+
+// NewPriceEndingValidator creates a validator requiring every price to end in ending, written
+// as a two digit fraction such as ".99"
+func NewPriceEndingValidator(ending string) (service.ProductValidator, error) {
+	digits := strings.TrimPrefix(strings.TrimSpace(ending), ".")
+	cents, err := strconv.Atoi(digits)
+	if err != nil || len(digits) != 2 || cents < 0 {
+		return nil, fmt.Errorf("invalid price ending %q: must be two digits such as .99", ending)
+	}
+	return &priceEndingValidator{cents: cents}, nil
+}
+
+// ValidateProduct checks the cents of the product's price
+func (v *priceEndingValidator) ValidateProduct(ctx context.Context, product *entity.Product) error {
+	cents := int(math.Round(product.Price*100)) % 100
+	if cents != v.cents {
+		return entity.NewValidationError("price", "ending", fmt.Errorf("%w: must end in .%02d", entity.ErrProductPriceEnding, v.cents))
+	}
+	return nil
+}
+
+// skuFormatValidator requires product SKUs to match a pattern
+type skuFormatValidator struct {
+	pattern *regexp.Regexp
+}
+
+// NewSKUFormatValidator creates a validator requiring every SKU that is set to match pattern,
+// a regular expression such as "^[A-Za-z0-9]+(-[A-Za-z0-9]+)*$"
+func NewSKUFormatValidator(pattern string) (service.ProductValidator, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SKU pattern %q: %w", pattern, err)
+	}
+	return &skuFormatValidator{pattern: re}, nil
+}
+
+// ValidateProduct checks the product's SKU, if it has one, against the pattern
+func (v *skuFormatValidator) ValidateProduct(ctx context.Context, product *entity.Product) error {
+	if product.SKU != nil && !v.pattern.MatchString(*product.SKU) {
+		return entity.NewValidationError("sku", "format", fmt.Errorf("%w: must match %s", entity.ErrProductSKUInvalid, v.pattern))
+	}
+	return nil
+}
+
+// requiredFieldChecks reports whether a product field that categories can require is filled in
+var requiredFieldChecks = map[string]func(*entity.Product) bool{
+	"description": func(p *entity.Product) bool { return strings.TrimSpace(p.Description) != "" },
+	"image_url":   func(p *entity.Product) bool { return strings.TrimSpace(p.ImageURL) != "" },
+	"category_id": func(p *entity.Product) bool { return p.CategoryID != nil },
+}
+
+// requiredFieldsValidator requires products in some categories to fill in extra fields
+type requiredFieldsValidator struct {
+	fieldsByCategory map[string][]string // keyed by lowercased category name
+}
+
+// NewRequiredFieldsValidator creates a validator from rules of the form
+// "category:field|field", e.g. "electronics:description|image_url". Categories are matched by
+// name, ignoring case; the fields that can be required are description, image_url and category_id.
+func NewRequiredFieldsValidator(rules []string) (service.ProductValidator, error) {
+	fieldsByCategory := make(map[string][]string, len(rules))
+	for _, rule := range rules {
+		category, fieldList, ok := strings.Cut(rule, ":")
+		category = strings.ToLower(strings.TrimSpace(category))
+		if !ok || category == "" {
+			return nil, fmt.Errorf("invalid required fields rule %q: expected category:field|field", rule)
+		}
+
+		for _, field := range strings.Split(fieldList, "|") {
+			field = strings.TrimSpace(field)
+			if _, known := requiredFieldChecks[field]; !known {
+				return nil, fmt.Errorf("invalid required fields rule %q: unknown field %q", rule, field)
+			}
+			fieldsByCategory[category] = append(fieldsByCategory[category], field)
+		}
+	}
+	return &requiredFieldsValidator{fieldsByCategory: fieldsByCategory}, nil
+}
+
+// ValidateProduct checks the fields required by the product's category, reporting all that are missing
+func (v *requiredFieldsValidator) ValidateProduct(ctx context.Context, product *entity.Product) error {
+	fields := v.fieldsByCategory[strings.ToLower(strings.TrimSpace(product.Category))]
+
+	validationErr := &entity.ValidationError{}
+	for _, field := range fields {
+		if !requiredFieldChecks[field](product) {
+			validationErr.Violations = append(validationErr.Violations, entity.FieldViolation{
+				Field:   field,
+				Rule:    "required_for_category",
+				Message: fmt.Sprintf("%s is required for products in category %s", field, product.Category),
+			})
+		}
+	}
+	if len(validationErr.Violations) > 0 {
+		return validationErr
+	}
+	return nil
+}
+
+// runProductValidators runs the configured validators against product, stopping at the first
+// that rejects it
+func (uc *ProductUseCase) runProductValidators(ctx context.Context, product *entity.Product) error {
+	for _, validator := range uc.validators {
+		if err := validator.ValidateProduct(ctx, product); err != nil {
+			return err
+		}
+	}
+	return nil
+}
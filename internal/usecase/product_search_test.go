@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// newSearchProductUseCase returns a product use case over three products that lets search
+// results be paged through to maxDepth
+func newSearchProductUseCase(maxDepth int) *ProductUseCase {
+	products := newFakeProductRepo(
+		&entity.Product{Name: "Desk Lamp", Price: 10},
+		&entity.Product{Name: "Floor Lamp", Price: 20},
+		&entity.Product{Name: "Lamp Shade", Price: 5},
+	)
+	return NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, nil, nil, fakeTxManager{}, ProductUseCaseOptions{MaxSearchDepth: maxDepth, ImageReportThreshold: 3, NamesPerCategory: true}, nopEventLogger{}, nil, nil)
+}
+
+func TestSearchProductsLimitsTheResultDepth(t *testing.T) {
+	uc := newSearchProductUseCase(100)
+	ctx := context.Background()
+
+	// Page 5 of 20 starts at result 80, inside the first 100
+	if _, err := uc.SearchProducts(ctx, "lamp", 5, 20); err != nil {
+		t.Errorf("page 5: %v", err)
+	}
+	// Page 6 of 20 starts at result 100, past the first 100
+	if _, err := uc.SearchProducts(ctx, "lamp", 6, 20); !errors.Is(err, entity.ErrSearchTooDeep) {
+		t.Errorf("page 6: got %v, want %v", err, entity.ErrSearchTooDeep)
+	}
+}
+
+func TestSearchProductsWithoutADepthLimit(t *testing.T) {
+	uc := newSearchProductUseCase(0)
+
+	response, err := uc.SearchProducts(context.Background(), "lamp", 1000, 20)
+	if err != nil {
+		t.Fatalf("SearchProducts: %v", err)
+	}
+	if len(response.Products) != 0 || response.Total != 3 {
+		t.Errorf("got %d products of %d, want an empty page of 3", len(response.Products), response.Total)
+	}
+}
+
+func TestGetProductsIgnoresTheSearchDepth(t *testing.T) {
+	uc := newSearchProductUseCase(1)
+
+	response, err := uc.GetProducts(context.Background(), nil, 2, 2)
+	if err != nil {
+		t.Fatalf("GetProducts: %v", err)
+	}
+	if len(response.Products) != 1 {
+		t.Errorf("got %d products, want the last 1", len(response.Products))
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestConcurrentDecrementsNeverOversell(t *testing.T) {
+	uc, products, _ := newReservationProductUseCase(10)
+	audit := uc.auditRepo.(*fakeAuditRepo)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sold := 0
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := uc.DecrementStock(context.Background(), 1, 1)
+			if err != nil && !errors.Is(err, entity.ErrInsufficientStock) {
+				t.Errorf("DecrementStock: %v", err)
+			}
+			if err == nil {
+				mu.Lock()
+				sold++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stock, _ := stockOf(t, products); stock != 0 || sold != 10 {
+		t.Errorf("got stock=%d after %d sales, want 0 after 10", stock, sold)
+	}
+
+	// Each audit entry records the levels around its own decrement, so together they cover
+	// every level from 10 down to 0 exactly once
+	if len(audit.entries) != 10 {
+		t.Fatalf("got %d audit entries, want 10", len(audit.entries))
+	}
+	seen := make(map[int]bool)
+	for _, entry := range audit.entries {
+		change := entry.Changes["stock"]
+		before, after := change.Before.(int), change.After.(int)
+		if before-after != 1 || seen[after] {
+			t.Errorf("got stock change %d -> %d, want a decrement by 1 not seen before", before, after)
+		}
+		seen[after] = true
+	}
+}
+
+func TestIncrementStockAuditsTheLevelsItChanged(t *testing.T) {
+	uc, _, _ := newReservationProductUseCase(3)
+	audit := uc.auditRepo.(*fakeAuditRepo)
+
+	if err := uc.IncrementStock(context.Background(), 1, 5); err != nil {
+		t.Fatalf("IncrementStock: %v", err)
+	}
+
+	if len(audit.entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(audit.entries))
+	}
+	if change := audit.entries[0].Changes["stock"]; change.Before != 3 || change.After != 8 {
+		t.Errorf("got stock change %v -> %v, want 3 -> 8", change.Before, change.After)
+	}
+}
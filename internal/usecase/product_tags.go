@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// SetProductTags replaces a product's tags. Tags are trimmed and lower-cased, and duplicates
+// are dropped; a product may have up to entity.MaxProductTags tags.
+func (uc *ProductUseCase) SetProductTags(ctx context.Context, productID uint, req *service.SetProductTagsRequest) (*entity.Product, error) {
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var product *entity.Product
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err = uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.tagRepo.SetTags(ctx, productID, tags); err != nil {
+			return err
+		}
+
+		before := *product
+		product.Tags = make([]entity.ProductTag, len(tags))
+		for i, tag := range tags {
+			product.Tags[i] = entity.ProductTag{ProductID: productID, Tag: tag}
+		}
+		// Saving the product bumps updated_at and evicts it from the product cache
+		if err := uc.saveProduct(ctx, product); err != nil {
+			return err
+		}
+		uc.recordProductAudit(ctx, entity.AuditActionUpdate, productID, diffProducts(&before, product))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// normalizeTags normalizes tags, drops duplicates and sorts them as products load them, rejecting empty or overlong tags and
+// lists longer than entity.MaxProductTags
+func normalizeTags(raw []string) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, value := range raw {
+		tag := entity.NormalizeTag(value)
+		if tag == "" || len([]rune(tag)) > entity.MaxProductTagLen {
+			return nil, entity.NewValidationError("tags", "max", fmt.Errorf("%w: tags must be 1 to %d characters", entity.ErrInvalidInput, entity.MaxProductTagLen))
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	if len(tags) > entity.MaxProductTags {
+		return nil, entity.NewValidationError("tags", "max", fmt.Errorf("%w: a product may have at most %d tags", entity.ErrInvalidInput, entity.MaxProductTags))
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
@@ -2,62 +2,476 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/product-management/internal/domain/entity"
 	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/domain/service"
+	"github.com/product-management/pkg/metrics"
 )
 
 // ProductUseCase handles product business logic
 type ProductUseCase struct {
-	productRepo repository.ProductRepository
+	productRepo     repository.ProductRepository
+	imageRepo       repository.ProductImageRepository
+	tagRepo         repository.ProductTagRepository
+	categoryRepo    repository.CategoryRepository
+	auditRepo       repository.AuditLogRepository
+	priceRepo       repository.PriceHistoryRepository
+	reservationRepo repository.ReservationRepository
+	txManager       repository.TxManager
+	imports         *importTracker
+	importJobs      *importJobStore
+	searchReindex   searchReindexer
+	// maxSearchDepth caps how many search results can be paged through, 0 for no limit
+	maxSearchDepth int
+	// deactivateOnDelete marks products inactive as they are deleted
+	deactivateOnDelete bool
+	// imageReportThreshold is the number of broken image reports that flags a product for review
+	imageReportThreshold int
+	// categoryCase canonicalizes free-text categories as they are saved and filtered on
+	categoryCase CategoryCase
+	// categoryMode decides whether new products named after a category are linked to it
+	categoryMode CategoryMode
+	// namesPerCategory only requires names to be unique within a category rather than globally
+	namesPerCategory bool
+	// validators enforce deployment-specific rules on products before they are saved
+	validators []service.ProductValidator
+	// skus generates SKUs for products created without one, nil to leave them without a SKU
+	skus   *SKUGenerator
+	events service.EventLogger
+	// publisher delivers product events to subscribers such as webhooks
+	publisher service.EventPublisher
+	logger    *slog.Logger
 }
 
-// NewProductUseCase creates a new product use case
-func NewProductUseCase(productRepo repository.ProductRepository) *ProductUseCase {
+// ProductUseCaseOptions holds the settings of a ProductUseCase. The zero value allows one import
+// at a time, unlimited search paging, no broken image review threshold, categories kept as
+// given and unlinked, and globally unique names.
+type ProductUseCaseOptions struct {
+	// MaxConcurrentImports is the number of imports allowed at once, at least one
+	MaxConcurrentImports int
+	// MaxSearchDepth caps how many search results can be paged through, 0 for no limit
+	MaxSearchDepth int
+	// DeactivateOnDelete marks products inactive as they are deleted
+	DeactivateOnDelete bool
+	// ImageReportThreshold is the number of broken image reports that flags a product for review
+	ImageReportThreshold int
+	// CategoryCase canonicalizes free-text categories, CategoryCaseNone if empty
+	CategoryCase CategoryCase
+	// CategoryMode decides whether new products named after a category are linked to it,
+	// CategoryModeFreeText if empty
+	CategoryMode CategoryMode
+	// NamesPerCategory only requires names to be unique within a category rather than globally
+	NamesPerCategory bool
+	// Validators enforce deployment-specific rules on products before they are saved
+	Validators []service.ProductValidator
+	// SKUs generates SKUs for products created without one, nil to leave them without a SKU
+	SKUs *SKUGenerator
+}
+
+// NewProductUseCase creates a new product use case with the given options, logging business
+// events to events and publishing product events to publisher, or nowhere if it is nil.
+// Operational messages go to logger, or the default logger if it is nil.
+func NewProductUseCase(
+	productRepo repository.ProductRepository,
+	imageRepo repository.ProductImageRepository,
+	tagRepo repository.ProductTagRepository,
+	categoryRepo repository.CategoryRepository,
+	auditRepo repository.AuditLogRepository,
+	priceRepo repository.PriceHistoryRepository,
+	reservationRepo repository.ReservationRepository,
+	txManager repository.TxManager,
+	options ProductUseCaseOptions,
+	events service.EventLogger,
+	publisher service.EventPublisher,
+	logger *slog.Logger,
+) *ProductUseCase {
+	if options.CategoryCase == "" {
+		options.CategoryCase = CategoryCaseNone
+	}
+	if options.CategoryMode == "" {
+		options.CategoryMode = CategoryModeFreeText
+	}
+	if publisher == nil {
+		publisher = service.NopEventPublisher{}
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	return &ProductUseCase{
-		productRepo: productRepo,
+		productRepo:     productRepo,
+		imageRepo:       imageRepo,
+		tagRepo:         tagRepo,
+		categoryRepo:    categoryRepo,
+		auditRepo:       auditRepo,
+		priceRepo:       priceRepo,
+		reservationRepo: reservationRepo,
+		txManager:       txManager,
+		imports:         newImportTracker(options.MaxConcurrentImports),
+		importJobs:      newImportJobStore(),
+
+		maxSearchDepth:       options.MaxSearchDepth,
+		deactivateOnDelete:   options.DeactivateOnDelete,
+		imageReportThreshold: options.ImageReportThreshold,
+		categoryCase:         options.CategoryCase,
+		categoryMode:         options.CategoryMode,
+		namesPerCategory:     options.NamesPerCategory,
+		validators:           options.Validators,
+		skus:                 options.SKUs,
+		events:               events,
+		publisher:            publisher,
+		logger:               logger,
 	}
 }
 
 // CreateProductRequest represents create product request data
 type CreateProductRequest struct {
-	Name        string  `json:"name" binding:"required"`
+	Name        string  `json:"name" validate:"required,min=3,max=255"`
+	SKU         string  `json:"sku" validate:"max=64"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
+	Price       float64 `json:"price" validate:"required,gt=0"`
 	Category    string  `json:"category"`
-	Stock       int     `json:"stock" binding:"gte=0"`
+	CategoryID  *uint   `json:"category_id"`
+	Stock       int     `json:"stock" validate:"gte=0"`
 }
 
-// UpdateProductRequest represents update product request data
+// UpdateProductRequest is the full representation of a product sent to replace it. Name,
+// price and stock are required; omitted optional fields are cleared or, for the low stock
+// threshold, reset to the default. image_url and is_active are not part of it and are kept.
+// Version is the version of the product the client read, see checkProductVersion.
 type UpdateProductRequest struct {
-	Name        *string  `json:"name"`
-	Description *string  `json:"description"`
-	Price       *float64 `json:"price"`
-	Category    *string  `json:"category"`
-	Stock       *int     `json:"stock"`
+	Version           *int     `json:"version" validate:"required"`
+	Name              *string  `json:"name" validate:"required,min=3,max=255"`
+	SKU               *string  `json:"sku" validate:"omitempty,max=64"` // an empty SKU removes it
+	Description       *string  `json:"description"`
+	Price             *float64 `json:"price" validate:"required,min=0"`
+	Category          *string  `json:"category"`
+	CategoryID        *uint    `json:"category_id"`
+	Stock             *int     `json:"stock" validate:"required,min=0"`
+	LowStockThreshold *int     `json:"low_stock_threshold" validate:"omitempty,min=0"`
 }
 
 // CreateProduct creates a new product
-func (uc *ProductUseCase) CreateProduct(req *CreateProductRequest) (*entity.Product, error) {
+func (uc *ProductUseCase) CreateProduct(ctx context.Context, req *CreateProductRequest) (*entity.Product, error) {
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
 	product := &entity.Product{
 		Name:        req.Name,
+		SKU:         normalizeSKU(req.SKU),
 		Description: req.Description,
 		Price:       req.Price,
-		Category:    req.Category,
+		Category:    uc.categoryCase.normalize(req.Category),
+		CategoryID:  req.CategoryID,
 		Stock:       req.Stock,
+
+		LowStockThreshold: entity.DefaultLowStockThreshold,
 	}
 
-	if err := uc.productRepo.Create(context.Background(), product); err != nil {
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.linkCategory(ctx, product); err != nil {
+			return err
+		}
+		if err := uc.validateNewProduct(ctx, product); err != nil {
+			return err
+		}
+
+		generatedSKU := product.SKU == nil
+		if err := uc.assignGeneratedSKU(ctx, product); err != nil {
+			return err
+		}
+		err := uc.insertProductSavepoint(ctx, product)
+		// Another product can take a generated SKU between the check and the insert
+		for attempt := 1; generatedSKU && uc.skus != nil && errors.Is(err, entity.ErrProductSKUExists) && attempt < uc.skus.maxAttempts; attempt++ {
+			product.SKU = nil
+			if err := uc.assignGeneratedSKU(ctx, product); err != nil {
+				return err
+			}
+			err = uc.insertProductSavepoint(ctx, product)
+		}
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
+	metrics.ProductsCreatedTotal.Inc()
+	uc.recordProductAudit(ctx, entity.AuditActionCreate, product.ID, diffProducts(nil, product))
 
 	return product, nil
 }
 
+// BulkCreateProducts creates several products in a single transaction and reports per-item results.
+// Every item is validated before anything is written; unless continueOnError is set, a single
+// invalid or failing item rolls back the whole batch and entity.ErrValidationFailed is returned.
+func (uc *ProductUseCase) BulkCreateProducts(ctx context.Context, reqs []*service.ProductCreateRequest, continueOnError bool) ([]*service.BulkCreateResult, error) {
+	results := make([]*service.BulkCreateResult, len(reqs))
+	products := make([]*entity.Product, len(reqs))
+	failed := false
+
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		seen := make(map[string]bool, len(reqs))
+		seenSKUs := make(map[string]bool, len(reqs))
+		for i, req := range reqs {
+			results[i] = &service.BulkCreateResult{Index: i}
+			if req == nil {
+				results[i].Error = entity.ErrInvalidInput.Error()
+				failed = true
+				continue
+			}
+
+			if err := validateStruct(req); err != nil {
+				results[i].Error = err.Error()
+				failed = true
+				continue
+			}
+
+			product := &entity.Product{
+				Name:        req.Name,
+				SKU:         normalizeSKU(req.SKU),
+				Description: req.Description,
+				Price:       req.Price,
+				Stock:       req.Stock,
+				Category:    uc.categoryCase.normalize(req.Category),
+				CategoryID:  req.CategoryID,
+				ImageURL:    req.ImageURL,
+			}
+			if err := product.Validate(); err != nil {
+				results[i].Error = err.Error()
+				failed = true
+				continue
+			}
+			if err := uc.resolveCategory(ctx, product); err != nil {
+				if !errors.Is(err, entity.ErrValidationFailed) {
+					return err
+				}
+				results[i].Error = err.Error()
+				failed = true
+				continue
+			}
+			if err := uc.runProductValidators(ctx, product); err != nil {
+				if !errors.Is(err, entity.ErrValidationFailed) {
+					return err
+				}
+				results[i].Error = err.Error()
+				failed = true
+				continue
+			}
+
+			// Catch duplicates within the batch as well as against existing rows
+			if err := uc.checkNameAvailable(ctx, product); err != nil || seen[uc.nameKey(product)] {
+				if err != nil && !errors.Is(err, entity.ErrProductAlreadyExists) {
+					return err
+				}
+				results[i].Error = entity.ErrProductAlreadyExists.Error()
+				failed = true
+				continue
+			}
+			if err := uc.checkSKUAvailable(ctx, product); err != nil || (product.SKU != nil && seenSKUs[*product.SKU]) {
+				if err != nil && !errors.Is(err, entity.ErrProductSKUExists) {
+					return err
+				}
+				results[i].Error = entity.ErrProductSKUExists.Error()
+				failed = true
+				continue
+			}
+			seen[uc.nameKey(product)] = true
+			if product.SKU != nil {
+				seenSKUs[*product.SKU] = true
+			}
+			products[i] = product
+		}
+
+		if failed && !continueOnError {
+			return entity.ErrValidationFailed
+		}
+
+		for i, product := range products {
+			if product == nil {
+				continue
+			}
+
+			if !continueOnError {
+				if err := uc.insertProduct(ctx, product); err != nil {
+					results[i].Error = err.Error()
+					return err
+				}
+				results[i].Product = product
+				continue
+			}
+
+			// A nested transaction becomes a savepoint, so one failed insert doesn't abort the rest
+			err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				return uc.insertProduct(ctx, product)
+			})
+			if err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+			results[i].Product = product
+		}
+
+		return nil
+	})
+	if err != nil {
+		// Nothing was persisted, so don't report products from the rolled-back transaction
+		for _, result := range results {
+			if result != nil {
+				result.Product = nil
+			}
+		}
+		return results, err
+	}
+
+	for _, result := range results {
+		if result.Product != nil {
+			metrics.ProductsCreatedTotal.Inc()
+			uc.recordProductAudit(ctx, entity.AuditActionCreate, result.Product.ID, diffProducts(nil, result.Product))
+		}
+	}
+
+	return results, nil
+}
+
+// ValidateProduct runs the create validation rules against a request without persisting anything
+func (uc *ProductUseCase) ValidateProduct(ctx context.Context, req *CreateProductRequest) error {
+	if err := validateStruct(req); err != nil {
+		return err
+	}
+
+	product := &entity.Product{
+		Name:        req.Name,
+		SKU:         normalizeSKU(req.SKU),
+		Description: req.Description,
+		Price:       req.Price,
+		Category:    uc.categoryCase.normalize(req.Category),
+		CategoryID:  req.CategoryID,
+		Stock:       req.Stock,
+	}
+
+	err := uc.validateNewProduct(ctx, product)
+	// Name and SKU uniqueness are reported as field errors here so forms can highlight them
+	if errors.Is(err, entity.ErrProductAlreadyExists) {
+		return entity.NewValidationError("name", "unique", err)
+	}
+	if errors.Is(err, entity.ErrProductSKUExists) {
+		return entity.NewValidationError("sku", "unique", err)
+	}
+	return err
+}
+
+// validateNewProduct checks a product that is about to be created
+func (uc *ProductUseCase) validateNewProduct(ctx context.Context, product *entity.Product) error {
+	if err := product.Validate(); err != nil {
+		return err
+	}
+
+	if err := uc.resolveCategory(ctx, product); err != nil {
+		return err
+	}
+
+	if err := uc.runProductValidators(ctx, product); err != nil {
+		return err
+	}
+
+	if err := uc.checkNameAvailable(ctx, product); err != nil {
+		return err
+	}
+
+	return uc.checkSKUAvailable(ctx, product)
+}
+
+// checkNameAvailable returns entity.ErrProductAlreadyExists if another live product already
+// uses the product's name, within the product's category when names are unique per category
+func (uc *ProductUseCase) checkNameAvailable(ctx context.Context, product *entity.Product) error {
+	var exists bool
+	var err error
+	if uc.namesPerCategory {
+		exists, err = uc.productRepo.ExistsByNameInCategory(ctx, product.Name, product.Category, product.ID)
+	} else {
+		exists, err = uc.productRepo.ExistsByName(ctx, product.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if exists {
+		return entity.ErrProductAlreadyExists
+	}
+	return nil
+}
+
+// nameKey identifies the products whose names must differ from each other
+func (uc *ProductUseCase) nameKey(product *entity.Product) string {
+	if uc.namesPerCategory {
+		return product.Category + "\x00" + product.Name
+	}
+	return product.Name
+}
+
+// normalizeSKU trims a SKU, treating an empty one as no SKU
+func normalizeSKU(sku string) *string {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return nil
+	}
+	return &sku
+}
+
+// checkSKUAvailable returns entity.ErrProductSKUExists if another live product already has
+// the product's SKU. The database enforces this too; checking first gives a clean error.
+func (uc *ProductUseCase) checkSKUAvailable(ctx context.Context, product *entity.Product) error {
+	if product.SKU == nil {
+		return nil
+	}
+
+	existing, err := uc.productRepo.GetBySKU(ctx, *product.SKU)
+	if errors.Is(err, entity.ErrProductNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != product.ID {
+		return entity.ErrProductSKUExists
+	}
+	return nil
+}
+
+// resolveCategory checks that a product's CategoryID refers to an existing category and copies
+// the category's name into the legacy free-text Category field
+func (uc *ProductUseCase) resolveCategory(ctx context.Context, product *entity.Product) error {
+	if product.CategoryID == nil {
+		return nil
+	}
+
+	category, err := uc.categoryRepo.GetByID(ctx, *product.CategoryID)
+	if errors.Is(err, entity.ErrCategoryNotFound) {
+		return entity.NewValidationError("category_id", "exists", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	product.Category = uc.categoryCase.normalize(category.Name)
+	return nil
+}
+
+// GetProductBySKU retrieves a live product by its SKU
+func (uc *ProductUseCase) GetProductBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	return uc.productRepo.GetBySKU(ctx, strings.TrimSpace(sku))
+}
+
 // GetProduct retrieves a product by ID
-func (uc *ProductUseCase) GetProduct(id uint) (*entity.Product, error) {
-	product, err := uc.productRepo.GetByID(context.Background(), id)
+func (uc *ProductUseCase) GetProduct(ctx context.Context, id uint) (*entity.Product, error) {
+	product, err := uc.productRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -65,61 +479,938 @@ func (uc *ProductUseCase) GetProduct(id uint) (*entity.Product, error) {
 	return product, nil
 }
 
-// GetAllProducts retrieves all products with optional filtering
-func (uc *ProductUseCase) GetAllProducts(category string, limit, offset int) ([]*entity.Product, error) {
-	filter := &repository.ProductFilter{
-		Category: category,
+// GetProductsByIDs retrieves the live products among ids in the order requested, listing the
+// IDs that match no live product instead of failing on them. Duplicate IDs are looked up once.
+func (uc *ProductUseCase) GetProductsByIDs(ctx context.Context, ids []uint) (*service.ProductBatchResult, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: at least one product ID is required", entity.ErrInvalidInput)
 	}
-	
-	products, err := uc.productRepo.GetAll(context.Background(), filter, offset, limit)
+
+	unique := uniqueIDs(ids)
+	products, err := uc.productRepo.GetByIDs(ctx, unique)
 	if err != nil {
 		return nil, err
 	}
 
-	return products, nil
+	byID := make(map[uint]*entity.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+	result := &service.ProductBatchResult{
+		Products: make([]*entity.Product, 0, len(products)),
+		NotFound: []uint{},
+	}
+	for _, id := range unique {
+		if product, ok := byID[id]; ok {
+			result.Products = append(result.Products, product)
+		} else {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	return result, nil
 }
 
-// UpdateProduct updates an existing product
-func (uc *ProductUseCase) UpdateProduct(id uint, req *UpdateProductRequest) (*entity.Product, error) {
-	product, err := uc.productRepo.GetByID(context.Background(), id)
+// GetProducts retrieves a paginated list of products with optional filtering and ordering
+func (uc *ProductUseCase) GetProducts(ctx context.Context, filter *repository.ProductFilter, page, pageSize int) (*service.ProductListResponse, error) {
+	uc.normalizeCategoryFilter(filter)
+
+	// Never fall through to an unbounded query that fetches every product
+	if pageSize < 1 {
+		return nil, fmt.Errorf("%w: page size must be a positive integer", entity.ErrInvalidInput)
+	}
+	if page < 1 {
+		page = 1
+	}
+	offset := (page - 1) * pageSize
+
+	// Skip the queries for clients that have already hung up or run out of time
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	total, err := uc.productRepo.GetTotalCount(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	products, err := uc.productRepo.GetAll(ctx, filter, offset, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update only provided fields
+	return &service.ProductListResponse{
+		Products: products,
+		PageInfo: service.NewPageInfo(total, page, pageSize),
+	}, nil
+}
+
+// GetLowStockProducts retrieves a page of active products at or below their low stock
+// threshold, lowest stock first
+func (uc *ProductUseCase) GetLowStockProducts(ctx context.Context, page, pageSize int) (*service.ProductListResponse, error) {
+	if pageSize < 1 {
+		return nil, fmt.Errorf("%w: page size must be a positive integer", entity.ErrInvalidInput)
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	total, err := uc.productRepo.CountLowStock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := uc.productRepo.GetLowStock(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.ProductListResponse{
+		Products: products,
+		PageInfo: service.NewPageInfo(total, page, pageSize),
+	}, nil
+}
+
+// maxSearchSuggestions caps the number of "did you mean" suggestions returned
+const maxSearchSuggestions = 5
+
+// SearchProducts searches products by name or description. Queries shorter than
+// service.MinSearchQueryLength are rejected with entity.ErrSearchQueryTooShort; a valid
+// query with no matches returns an empty page with suggestions of similar product names.
+// Pages starting past the configured result depth are rejected with entity.ErrSearchTooDeep.
+// Matches are ordered by weighted relevance, see SearchProductsWithOptions.
+func (uc *ProductUseCase) SearchProducts(ctx context.Context, searchTerm string, page, pageSize int) (*service.ProductSearchResponse, error) {
+	return uc.SearchProductsWithOptions(ctx, searchTerm, service.SearchOptions{}, page, pageSize)
+}
+
+// SearchProductsWithOptions searches products like SearchProducts, scoring matches with the
+// requested relevance mode and dropping those scoring below opts.MinScore. Each product's
+// score is returned in its rank field.
+func (uc *ProductUseCase) SearchProductsWithOptions(ctx context.Context, searchTerm string, opts service.SearchOptions, page, pageSize int) (*service.ProductSearchResponse, error) {
+	if opts.Relevance != "" && !repository.SearchRelevanceModes[opts.Relevance] {
+		return nil, fmt.Errorf("%w: unknown relevance mode %q", entity.ErrInvalidInput, opts.Relevance)
+	}
+	if opts.MinScore != nil && *opts.MinScore < 0 {
+		return nil, fmt.Errorf("%w: min_score must not be negative", entity.ErrInvalidInput)
+	}
+
+	searchTerm = strings.TrimSpace(searchTerm)
+	if len([]rune(searchTerm)) < service.MinSearchQueryLength {
+		return nil, entity.ErrSearchQueryTooShort
+	}
+	if uc.maxSearchDepth > 0 && (page-1)*pageSize >= uc.maxSearchDepth {
+		return nil, fmt.Errorf("%w: only the first %d results can be paged through, please refine the query",
+			entity.ErrSearchTooDeep, uc.maxSearchDepth)
+	}
+
+	filter := &repository.ProductFilter{SearchTerm: searchTerm, Relevance: opts.Relevance, MinScore: opts.MinScore}
+	list, err := uc.GetProducts(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &service.ProductSearchResponse{ProductListResponse: *list}
+	if list.Total == 0 && ctx.Err() == nil {
+		// Suggestions are best effort (e.g. pg_trgm may be unavailable), so errors are not fatal
+		suggestions, err := uc.productRepo.SuggestNames(ctx, searchTerm, maxSearchSuggestions)
+		if err != nil {
+			uc.logger.WarnContext(ctx, "Failed to build search suggestions", slog.String("query", searchTerm), slog.Any("error", err))
+		}
+		response.Suggestions = suggestions
+	}
+
+	return response, nil
+}
+
+// CountProducts returns the number of products matching filter, the same total GetProducts
+// reports for it, without fetching any rows
+func (uc *ProductUseCase) CountProducts(ctx context.Context, filter *repository.ProductFilter) (int64, error) {
+	uc.normalizeCategoryFilter(filter)
+	return uc.productRepo.GetTotalCount(ctx, filter)
+}
+
+// GetStats aggregates the products matching filter, the same products GetProducts lists for it,
+// without loading them
+func (uc *ProductUseCase) GetStats(ctx context.Context, filter *repository.ProductFilter) (*repository.ProductStats, error) {
+	uc.normalizeCategoryFilter(filter)
+	return uc.productRepo.GetStats(ctx, filter)
+}
+
+// GetProductsCursor retrieves a page of products after the given cursor, ordered by ID
+func (uc *ProductUseCase) GetProductsCursor(ctx context.Context, filter *repository.ProductFilter, cursor string, limit int) (*service.ProductCursorResponse, error) {
+	uc.normalizeCategoryFilter(filter)
+
+	// Keyset pagination is always ordered by ID, so a custom ordering can't be honoured
+	if filter != nil && filter.OrderBy != "" {
+		return nil, fmt.Errorf("%w: order_by is not supported with cursor pagination", entity.ErrInvalidInput)
+	}
+
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit must be positive", entity.ErrInvalidInput)
+	}
+
+	afterID, err := decodeProductCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row to find out whether another page exists
+	products, err := uc.productRepo.GetAfterID(ctx, filter, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &service.ProductCursorResponse{
+		Products: products,
+		Limit:    limit,
+	}
+	if len(products) > limit {
+		response.Products = products[:limit]
+		response.NextCursor = encodeProductCursor(products[limit-1].ID)
+	}
+
+	return response, nil
+}
+
+// exportBatchSize is the number of products fetched per query while exporting
+const exportBatchSize = 500
+
+// ExportProducts streams the products matching the filter with an ID greater than opts.SinceID
+// to fn in ascending ID order, stopping after opts.Limit products when set. Products are fetched
+// in keyset batches so memory stays flat regardless of catalog size. Batches only share a
+// snapshot when opts.Consistent is set, at the cost of holding a transaction open throughout.
+func (uc *ProductUseCase) ExportProducts(ctx context.Context, filter *repository.ProductFilter, opts service.ExportOptions, fn func(*entity.Product) error) error {
+	uc.normalizeCategoryFilter(filter)
+
+	if opts.Limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative", entity.ErrInvalidInput)
+	}
+
+	export := func(ctx context.Context) error {
+		afterID := opts.SinceID
+		remaining := opts.Limit
+		for {
+			batchSize := exportBatchSize
+			if opts.Limit > 0 && remaining < batchSize {
+				batchSize = remaining
+			}
+
+			products, err := uc.productRepo.GetAfterID(ctx, filter, afterID, batchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, product := range products {
+				if err := fn(product); err != nil {
+					return err
+				}
+			}
+
+			if opts.Limit > 0 {
+				remaining -= len(products)
+				if remaining == 0 {
+					return nil
+				}
+			}
+			if len(products) < batchSize {
+				return nil
+			}
+			afterID = products[len(products)-1].ID
+		}
+	}
+
+	if opts.Consistent {
+		return uc.txManager.WithinSnapshot(ctx, export)
+	}
+	return export(ctx)
+}
+
+// ImportProducts creates or updates products by name from parsed import rows, matching names
+// within the row's category when names are unique per category. Each row is handled
+// independently so one bad row doesn't abort the whole file.
+func (uc *ProductUseCase) ImportProducts(ctx context.Context, rows []*service.ProductImportRow) (*service.ImportResult, error) {
+	release, err := uc.imports.acquire(service.ImportKindProducts, len(rows))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return uc.importProducts(ctx, rows)
+}
+
+// StartImportJob runs a product import in the background and returns the queued job.
+// The import slot is taken up front so an over-limit request fails immediately, and the
+// job runs detached from ctx's cancellation so it outlives the request that started it.
+func (uc *ProductUseCase) StartImportJob(ctx context.Context, rows []*service.ProductImportRow, rowErrors []service.ImportRowError) (*service.ImportJob, error) {
+	release, err := uc.imports.acquire(service.ImportKindProducts, len(rows))
+	if err != nil {
+		return nil, err
+	}
+
+	job := uc.importJobs.create()
+	go func() {
+		defer release()
+		defer func() {
+			if r := recover(); r != nil {
+				uc.logger.ErrorContext(ctx, "Import job panicked", slog.String("job_id", job.ID), slog.Any("panic", r))
+				uc.importJobs.finish(job.ID, nil, fmt.Errorf("import failed unexpectedly"))
+			}
+		}()
+
+		uc.importJobs.start(job.ID)
+		result, err := uc.importProducts(context.WithoutCancel(ctx), rows)
+		if result != nil {
+			result.AddRowErrors(rowErrors)
+		}
+		uc.importJobs.finish(job.ID, result, err)
+	}()
+
+	return job, nil
+}
+
+// WaitForImports blocks until running imports, including background jobs, have finished or
+// ctx is done. It is used on shutdown so imports aren't cut off when the database closes.
+func (uc *ProductUseCase) WaitForImports(ctx context.Context) error {
+	return uc.imports.wait(ctx)
+}
+
+// GetImportJob returns the current state of a background import
+func (uc *ProductUseCase) GetImportJob(ctx context.Context, id string) (*service.ImportJob, error) {
+	return uc.importJobs.get(id)
+}
+
+// importTarget finds the existing product an import row updates: the one with the row's name,
+// looked up within the row's category when names are unique per category
+func (uc *ProductUseCase) importTarget(ctx context.Context, name, category string) (*entity.Product, error) {
+	if uc.namesPerCategory {
+		return uc.productRepo.GetByNameInCategory(ctx, name, category)
+	}
+	return uc.productRepo.GetByName(ctx, name)
+}
+
+// importProducts performs an import; callers must hold an import slot
+func (uc *ProductUseCase) importProducts(ctx context.Context, rows []*service.ProductImportRow) (*service.ImportResult, error) {
+	result := &service.ImportResult{}
+	skip := func(line int, err error) {
+		result.Skipped++
+		result.Errors = append(result.Errors, service.ImportRowError{Line: line, Reason: err.Error()})
+	}
+
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		product, err := uc.importTarget(ctx, row.Name, uc.categoryCase.normalize(row.Category))
+		if err != nil && !errors.Is(err, entity.ErrProductNotFound) {
+			skip(row.Line, err)
+			continue
+		}
+
+		created := product == nil
+		var before *entity.Product
+		if created {
+			product = &entity.Product{Name: row.Name, IsActive: true}
+		} else {
+			snapshot := *product
+			before = &snapshot
+		}
+		product.Description = row.Description
+		product.Price = row.Price
+		product.Stock = row.Stock
+		product.Category = uc.categoryCase.normalize(row.Category)
+		product.ImageURL = row.ImageURL
+		if row.IsActive != nil {
+			product.IsActive = *row.IsActive
+		}
+
+		if err := product.Validate(); err != nil {
+			skip(row.Line, err)
+			continue
+		}
+		if err := uc.runProductValidators(ctx, product); err != nil {
+			skip(row.Line, err)
+			continue
+		}
+
+		if created {
+			err = uc.insertProduct(ctx, product)
+			// GORM substitutes the column default for a false bool on insert, so persist it explicitly
+			if err == nil && !product.IsActive {
+				err = uc.productRepo.BulkUpdateStatus(ctx, []uint{product.ID}, false)
+			}
+		} else {
+			// The price history row must not diverge from the product it describes
+			err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := uc.saveProduct(ctx, product); err != nil {
+					return err
+				}
+				return uc.recordPriceChange(ctx, product.ID, before.Price, product.Price)
+			})
+		}
+		if err != nil {
+			skip(row.Line, err)
+			continue
+		}
+
+		if created {
+			result.Created++
+			metrics.ProductsCreatedTotal.Inc()
+			uc.recordProductAudit(ctx, entity.AuditActionCreate, product.ID, diffProducts(nil, product))
+		} else {
+			result.Updated++
+			uc.recordProductAudit(ctx, entity.AuditActionUpdate, product.ID, diffProducts(before, product))
+		}
+	}
+
+	return result, nil
+}
+
+// ImportPrices updates the prices of existing products by SKU or ID in a single transaction.
+// Rows that don't match a product or carry an invalid price are reported and skipped;
+// only database failures abort (and roll back) the import.
+func (uc *ProductUseCase) ImportPrices(ctx context.Context, rows []*service.PriceUpdateRow, dryRun bool) (*service.PriceImportResult, error) {
+	release, err := uc.imports.acquire(service.ImportKindPrices, len(rows))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result := &service.PriceImportResult{DryRun: dryRun}
+
+	// Audits are recorded once the import has committed, so a rolled back import never
+	// publishes events or webhooks for prices that were not changed
+	var changed []service.PriceUpdateResult
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		changed = changed[:0]
+		for _, row := range rows {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			rowResult := service.PriceUpdateResult{Line: row.Line, ID: row.ID, SKU: row.SKU, NewPrice: row.Price}
+
+			if row.Price < 0 {
+				rowResult.Status = service.PriceUpdateStatusInvalid
+				rowResult.Reason = entity.ErrProductPriceInvalid.Error()
+				result.Invalid++
+				result.Rows = append(result.Rows, rowResult)
+				continue
+			}
+
+			product, err := uc.priceUpdateProduct(ctx, row)
+			if errors.Is(err, entity.ErrProductNotFound) {
+				rowResult.Status = service.PriceUpdateStatusNotFound
+				result.NotFound++
+				result.Rows = append(result.Rows, rowResult)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			rowResult.ID = product.ID
+			rowResult.OldPrice = product.Price
+			if !dryRun {
+				product.Price = row.Price
+				if err := uc.saveProduct(ctx, product); err != nil {
+					return err
+				}
+				if err := uc.recordPriceChange(ctx, product.ID, rowResult.OldPrice, product.Price); err != nil {
+					return err
+				}
+				changed = append(changed, rowResult)
+			}
+
+			rowResult.Status = service.PriceUpdateStatusUpdated
+			result.Updated++
+			result.Rows = append(result.Rows, rowResult)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range changed {
+		uc.recordProductAudit(ctx, entity.AuditActionUpdate, row.ID, entity.AuditChanges{
+			"price": {Before: row.OldPrice, After: row.NewPrice},
+		})
+	}
+	return result, nil
+}
+
+// priceUpdateProduct retrieves the product a price import row names, by SKU if it has one
+func (uc *ProductUseCase) priceUpdateProduct(ctx context.Context, row *service.PriceUpdateRow) (*entity.Product, error) {
+	if row.SKU != "" {
+		return uc.GetProductBySKU(ctx, row.SKU)
+	}
+	return uc.productRepo.GetByID(ctx, row.ID)
+}
+
+// ListActiveImports returns the imports that are currently running
+func (uc *ProductUseCase) ListActiveImports(ctx context.Context) []service.ActiveImport {
+	return uc.imports.list()
+}
+
+// encodeProductCursor encodes the last seen product ID as an opaque cursor
+func encodeProductCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+// decodeProductCursor decodes a cursor produced by encodeProductCursor; an empty cursor starts from the beginning
+func decodeProductCursor(cursor string) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", entity.ErrInvalidInput)
+	}
+
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", entity.ErrInvalidInput)
+	}
+
+	return uint(id), nil
+}
+
+// UpdateProduct replaces a product with the representation in req. Unlike PatchProduct,
+// fields left out of req are cleared rather than kept.
+func (uc *ProductUseCase) UpdateProduct(ctx context.Context, id uint, req *UpdateProductRequest) (*entity.Product, error) {
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProductVersion(product, *req.Version); err != nil {
+		return nil, err
+	}
+	before := *product
+
+	product.Name = *req.Name
+	product.SKU = normalizeSKU(valueOrZero(req.SKU))
+	product.Description = valueOrZero(req.Description)
+	product.Price = *req.Price
+	product.Category = uc.categoryCase.normalize(valueOrZero(req.Category))
+	product.CategoryID = req.CategoryID
+	product.Stock = *req.Stock
+	product.LowStockThreshold = entity.DefaultLowStockThreshold
+	if req.LowStockThreshold != nil {
+		product.LowStockThreshold = *req.LowStockThreshold
+	}
+	if err := uc.resolveCategory(ctx, product); err != nil {
+		return nil, err
+	}
+
+	if err := uc.saveProductUpdate(ctx, &before, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// checkProductVersion returns entity.ErrConcurrentModification unless product is still at the
+// version a client read before editing it. The repository checks again as it saves, which
+// catches changes made between here and then.
+func checkProductVersion(product *entity.Product, version int) error {
+	if product.Version != version {
+		return entity.ErrConcurrentModification
+	}
+	return nil
+}
+
+// valueOrZero dereferences value, treating nil as the zero value
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+// PatchProduct applies a partial update to a product. Absent fields are left unchanged and
+// null clears description, category or image_url; see service.ProductPatchRequest.
+func (uc *ProductUseCase) PatchProduct(ctx context.Context, id uint, req *service.ProductPatchRequest) (*entity.Product, error) {
+	if err := validateStruct(req); err != nil {
+		return nil, err
+	}
+
+	description, err := patchOptionalString("description", req.Description)
+	if err != nil {
+		return nil, err
+	}
+	category, err := patchOptionalString("category", req.Category)
+	if err != nil {
+		return nil, err
+	}
+	imageURL, err := patchOptionalString("image_url", req.ImageURL)
+	if err != nil {
+		return nil, err
+	}
+	sku, err := patchOptionalString("sku", req.SKU)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProductVersion(product, *req.Version); err != nil {
+		return nil, err
+	}
+	before := *product
+
 	if req.Name != nil {
 		product.Name = *req.Name
 	}
-	if req.Description != nil {
-		product.Description = *req.Description
-	}
 	if req.Price != nil {
 		product.Price = *req.Price
 	}
-	if req.Category != nil {
-		product.Category = *req.Category
-	}
 	if req.Stock != nil {
 		product.Stock = *req.Stock
 	}
+	if req.LowStockThreshold != nil {
+		product.LowStockThreshold = *req.LowStockThreshold
+	}
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+	if description != nil {
+		product.Description = *description
+	}
+	if category != nil {
+		product.Category = uc.categoryCase.normalize(*category)
+	}
+	if imageURL != nil {
+		product.ImageURL = *imageURL
+	}
+	if sku != nil {
+		product.SKU = normalizeSKU(*sku)
+	}
+	if req.CategoryID != nil {
+		product.CategoryID = req.CategoryID
+		if err := uc.resolveCategory(ctx, product); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := uc.productRepo.Update(context.Background(), product); err != nil {
+	if err := uc.saveProductUpdate(ctx, &before, product); err != nil {
 		return nil, err
 	}
-
 	return product, nil
 }
 
-// DeleteProduct deletes a product
-func (uc *ProductUseCase) DeleteProduct(id uint) error {
-	return uc.productRepo.Delete(context.Background(), id)
+// patchOptionalString returns the value a patch sets a clearable field to, or nil to leave it
+// unchanged. Null clears the field; an empty string is rejected so clearing is always explicit.
+func patchOptionalString(field string, value service.NullableString) (*string, error) {
+	if !value.Set {
+		return nil, nil
+	}
+	if value.Null {
+		cleared := ""
+		return &cleared, nil
+	}
+	if strings.TrimSpace(value.Value) == "" {
+		return nil, entity.NewValidationError(field, "not_empty", fmt.Errorf("%w: send null to clear %s", entity.ErrInvalidInput, field))
+	}
+	return &value.Value, nil
+}
+
+// saveProductUpdate validates and stores the changes made to product, recording its price
+// history and audit trail against before
+func (uc *ProductUseCase) saveProductUpdate(ctx context.Context, before, product *entity.Product) error {
+	if err := product.Validate(); err != nil {
+		return err
+	}
+	if err := uc.runProductValidators(ctx, product); err != nil {
+		return err
+	}
+	if uc.nameKey(product) != uc.nameKey(before) {
+		if err := uc.checkNameAvailable(ctx, product); err != nil {
+			return err
+		}
+	}
+	if err := uc.checkSKUAvailable(ctx, product); err != nil {
+		return err
+	}
+
+	// The price history row must not diverge from the product it describes
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.saveProduct(ctx, product); err != nil {
+			return err
+		}
+		return uc.recordPriceChange(ctx, product.ID, before.Price, product.Price)
+	})
+	if err != nil {
+		return err
+	}
+	uc.recordProductAudit(ctx, entity.AuditActionUpdate, product.ID, diffProducts(before, product))
+	uc.notifyLowStock(ctx, product, before.Stock)
+	return nil
+}
+
+// DeleteProduct deletes a product. Unless force is set, a product that is still referenced
+// by other records is not deleted and a *entity.ProductReferencedError is returned instead.
+func (uc *ProductUseCase) DeleteProduct(ctx context.Context, id uint, force bool) error {
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		if err := uc.checkUnreferenced(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	// Deactivating in the same transaction means no one sees a deleted product as active
+	before := *product
+	deactivate := uc.deactivateOnDelete && product.IsActive
+
+	// Products are soft-deleted, so the images' ON DELETE CASCADE doesn't fire on its own
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if deactivate {
+			product.IsActive = false
+			if err := uc.saveProduct(ctx, product); err != nil {
+				return err
+			}
+		}
+		if err := uc.imageRepo.DeleteByProduct(ctx, id); err != nil {
+			return err
+		}
+		return uc.productRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+	metrics.ProductsDeletedTotal.Inc()
+	if deactivate {
+		uc.recordProductAudit(ctx, entity.AuditActionDeactivate, id, diffProducts(&before, product))
+	}
+	uc.recordProductAudit(ctx, entity.AuditActionDelete, id, diffProducts(product, nil))
+
+	return nil
+}
+
+// HardDeleteProduct permanently deletes a product; its images and tags go with it through their
+// ON DELETE CASCADE. This cannot be undone, though the product's audit trail is kept. References
+// are checked as in DeleteProduct.
+func (uc *ProductUseCase) HardDeleteProduct(ctx context.Context, id uint, force bool) error {
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		if err := uc.checkUnreferenced(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.productRepo.HardDelete(ctx, id); err != nil {
+		return err
+	}
+	metrics.ProductsDeletedTotal.Inc()
+	uc.recordProductAudit(ctx, entity.AuditActionHardDelete, id, diffProducts(product, nil))
+
+	return nil
+}
+
+// checkUnreferenced returns a *entity.ProductReferencedError when other records still
+// reference a product
+func (uc *ProductUseCase) checkUnreferenced(ctx context.Context, id uint) error {
+	references, err := uc.productRepo.CountReferences(ctx, id)
+	if err != nil {
+		return err
+	}
+	if references.Total() > 0 {
+		return &entity.ProductReferencedError{References: references}
+	}
+	return nil
+}
+
+// ListDeletedProducts retrieves a paginated list of soft-deleted products, most recently deleted first
+func (uc *ProductUseCase) ListDeletedProducts(ctx context.Context, page, pageSize int) (*service.DeletedProductListResponse, error) {
+	offset := (page - 1) * pageSize
+
+	total, err := uc.productRepo.CountDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := uc.productRepo.GetDeleted(ctx, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make([]*service.DeletedProduct, len(products))
+	for i, product := range products {
+		deleted[i] = &service.DeletedProduct{Product: product, DeletedAt: product.DeletedAt.Time}
+	}
+
+	return &service.DeletedProductListResponse{
+		Products: deleted,
+		PageInfo: service.NewPageInfo(total, page, pageSize),
+	}, nil
+}
+
+// GetProductReferences returns counts of the records referencing a product
+func (uc *ProductUseCase) GetProductReferences(ctx context.Context, id uint) (entity.ProductReferences, error) {
+	if _, err := uc.productRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return uc.productRepo.CountReferences(ctx, id)
+}
+
+// BulkUpdateProductStatus updates the active status of the live products among ids and reports
+// which IDs were updated, soft-deleted or unknown, so callers can tell what took effect
+func (uc *ProductUseCase) BulkUpdateProductStatus(ctx context.Context, ids []uint, isActive bool) (*service.BulkStatusResult, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: at least one product ID is required", entity.ErrInvalidInput)
+	}
+
+	// Drop duplicates so each ID is reported once
+	unique := uniqueIDs(ids)
+
+	result := &service.BulkStatusResult{}
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		partition, err := uc.productRepo.PartitionIDs(ctx, unique)
+		if err != nil {
+			return err
+		}
+
+		if len(partition.Matched) > 0 {
+			if err := uc.productRepo.BulkUpdateStatus(ctx, partition.Matched, isActive); err != nil {
+				return err
+			}
+		}
+
+		result.Updated = nonNilIDs(partition.Matched)
+		result.SoftDeleted = nonNilIDs(partition.SoftDeleted)
+		result.NotFound = nonNilIDs(partition.NotFound)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence of each
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// nonNilIDs returns ids, or an empty slice if it is nil, so it serializes as [] rather than null
+func nonNilIDs(ids []uint) []uint {
+	if ids == nil {
+		return []uint{}
+	}
+	return ids
 }
 
 // UpdateStock updates product stock
-func (uc *ProductUseCase) UpdateStock(id uint, quantity int) error {
+func (uc *ProductUseCase) UpdateStock(ctx context.Context, id uint, quantity int) error {
 	if quantity < 0 {
-		return errors.New("quantity cannot be negative")
+		return entity.NewValidationError("quantity", "min", entity.ErrProductStockInvalid)
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.productRepo.UpdateStock(ctx, id, quantity); err != nil {
+		return err
+	}
+	uc.recordProductAudit(ctx, entity.AuditActionStockChange, id, entity.AuditChanges{
+		"stock": {Before: product.Stock, After: quantity},
+	})
+	previousStock := product.Stock
+	product.Stock = quantity
+	uc.notifyLowStock(ctx, product, previousStock)
+
+	return nil
+}
+
+// GetProductHistory returns the audit trail of a product, oldest first. The history of
+// deleted products stays available.
+func (uc *ProductUseCase) GetProductHistory(ctx context.Context, id uint) ([]*entity.AuditLog, error) {
+	return uc.auditRepo.ListByEntity(ctx, entity.AuditEntityProduct, id)
+}
+
+// GetPriceHistory returns the price changes of a product, oldest first
+func (uc *ProductUseCase) GetPriceHistory(ctx context.Context, id uint) ([]*entity.PriceHistory, error) {
+	if _, err := uc.productRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.priceRepo.ListByProduct(ctx, id)
+}
+
+// recordPriceChange records a price change made by the actor in ctx; unchanged prices are skipped
+func (uc *ProductUseCase) recordPriceChange(ctx context.Context, productID uint, oldPrice, newPrice float64) error {
+	if oldPrice == newPrice {
+		return nil
+	}
+
+	entry := &entity.PriceHistory{
+		ProductID: productID,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		ChangedAt: time.Now(),
+	}
+	if actorID, ok := service.ActorIDFromContext(ctx); ok {
+		entry.ChangedBy = &actorID
+	}
+	return uc.priceRepo.Create(ctx, entry)
+}
+
+// DecrementStock atomically removes qty units from a product's stock
+func (uc *ProductUseCase) DecrementStock(ctx context.Context, id uint, qty int) error {
+	if qty <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", entity.ErrInvalidInput)
+	}
+
+	product, err := uc.productRepo.DecrementStock(ctx, id, qty)
+	if err != nil {
+		return err
+	}
+	uc.recordStockAudit(ctx, product, -qty, "")
+
+	return nil
+}
+
+// IncrementStock atomically adds qty units to a product's stock
+func (uc *ProductUseCase) IncrementStock(ctx context.Context, id uint, qty int) error {
+	if qty <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", entity.ErrInvalidInput)
+	}
+
+	product, err := uc.productRepo.IncrementStock(ctx, id, qty)
+	if err != nil {
+		return err
 	}
+	uc.recordStockAudit(ctx, product, qty, "")
 
-	return uc.productRepo.UpdateStock(context.Background(), id, quantity)
+	return nil
 }
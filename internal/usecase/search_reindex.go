@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// searchReindexBatch is how many products have their search vectors recomputed per statement.
+// Each batch commits on its own, so rows are only locked briefly and normal traffic carries on.
+const searchReindexBatch = 500
+
+// searchReindexer tracks the search reindex, of which only one runs at a time
+type searchReindexer struct {
+	mu      sync.Mutex
+	status  service.SearchReindexStatus
+	running sync.WaitGroup
+}
+
+// begin records a reindex as started, failing if one is already running
+func (r *searchReindexer) begin() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status.Running {
+		return entity.ErrSearchReindexRunning
+	}
+
+	now := time.Now()
+	r.status = service.SearchReindexStatus{Running: true, StartedAt: &now}
+	r.running.Add(1)
+	return nil
+}
+
+// progress counts n more products as reindexed
+func (r *searchReindexer) progress(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.Processed += n
+}
+
+// finish records the running reindex as done, failed if err is not nil
+func (r *searchReindexer) finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.status.Running = false
+	r.status.FinishedAt = &now
+	if err != nil {
+		r.status.Error = err.Error()
+	}
+	r.running.Done()
+}
+
+// snapshot returns the status of the latest reindex
+func (r *searchReindexer) snapshot() service.SearchReindexStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// StartSearchReindex recomputes the search vectors of every product in the background and
+// returns the status of the reindex it started. It fails with entity.ErrSearchReindexRunning
+// while another reindex is running.
+func (uc *ProductUseCase) StartSearchReindex(ctx context.Context) (service.SearchReindexStatus, error) {
+	if err := uc.searchReindex.begin(); err != nil {
+		return service.SearchReindexStatus{}, err
+	}
+
+	go func() {
+		uc.searchReindex.finish(uc.reindexSearch(context.WithoutCancel(ctx)))
+	}()
+	return uc.searchReindex.snapshot(), nil
+}
+
+// SearchReindexStatus returns the progress of the running search reindex, or the outcome of
+// the last one
+func (uc *ProductUseCase) SearchReindexStatus() service.SearchReindexStatus {
+	return uc.searchReindex.snapshot()
+}
+
+// ReindexSearchIfStale recomputes every product's search vector if they were computed with a
+// search index version other than version, then records version as current. It is run on
+// startup, so a change to how vectors are computed reaches the products stored before it.
+func (uc *ProductUseCase) ReindexSearchIfStale(ctx context.Context, version int) error {
+	stored, err := uc.productRepo.GetSearchIndexVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if stored == version {
+		return nil
+	}
+
+	if err := uc.searchReindex.begin(); err != nil {
+		return err
+	}
+	err = uc.reindexSearch(ctx)
+	if err == nil {
+		err = uc.productRepo.SetSearchIndexVersion(ctx, version)
+	}
+	uc.searchReindex.finish(err)
+	if err != nil {
+		return err
+	}
+
+	uc.logger.InfoContext(ctx, "Reindexed product search", slog.Int("from_version", stored), slog.Int("to_version", version))
+	return nil
+}
+
+// WaitForSearchReindex blocks until a running search reindex has finished or ctx is done. It
+// is used on shutdown so a reindex isn't cut off when the database closes.
+func (uc *ProductUseCase) WaitForSearchReindex(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		uc.searchReindex.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reindexSearch recomputes every product's search vector a batch at a time, in ID order.
+// Batches are idempotent, so a reindex that fails part way can simply be run again.
+func (uc *ProductUseCase) reindexSearch(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("search reindex panicked: %v", r)
+		}
+	}()
+
+	var afterID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		count, lastID, err := uc.productRepo.RefreshSearchVectors(ctx, afterID, searchReindexBatch)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+		uc.searchReindex.progress(count)
+		afterID = lastID
+	}
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+// SKU template placeholders
+const (
+	skuCategoryPrefix = "{CATEGORY_PREFIX}" // first letters of the product's category, or GEN
+	skuSequence       = "{SEQUENCE}"        // next value of the SKU sequence, zero-padded to six digits
+	skuRandom         = "{RANDOM}"          // random uppercase letters and digits
+)
+
+const (
+	skuCategoryPrefixLength = 3
+	skuDefaultCategory      = "GEN"
+	skuRandomAlphabet       = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var skuPlaceholderPattern = regexp.MustCompile(`\{[A-Z_]+\}`)
+
+// SKUGenerator builds SKUs for products created without one from a template such as
+// "{CATEGORY_PREFIX}-{SEQUENCE}" or "P-{RANDOM}"
+type SKUGenerator struct {
+	template     string
+	randomLength int // length of the {RANDOM} part
+	maxAttempts  int // SKUs generated before giving up when each one is taken
+}
+
+// NewSKUGenerator creates a SKU generator for template. The template must contain {SEQUENCE} or
+// {RANDOM} so that generated SKUs differ; {CATEGORY_PREFIX} is optional.
+func NewSKUGenerator(template string, randomLength, maxAttempts int) (*SKUGenerator, error) {
+	for _, placeholder := range skuPlaceholderPattern.FindAllString(template, -1) {
+		switch placeholder {
+		case skuCategoryPrefix, skuSequence, skuRandom:
+		default:
+			return nil, fmt.Errorf("unknown SKU template placeholder %s", placeholder)
+		}
+	}
+	if !strings.Contains(template, skuSequence) && !strings.Contains(template, skuRandom) {
+		return nil, fmt.Errorf("SKU template %q must contain %s or %s", template, skuSequence, skuRandom)
+	}
+	if strings.Contains(template, skuRandom) && randomLength < 4 {
+		return nil, fmt.Errorf("random SKU length must be at least 4, got %d", randomLength)
+	}
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("SKU generation attempts must be at least 1, got %d", maxAttempts)
+	}
+
+	return &SKUGenerator{template: template, randomLength: randomLength, maxAttempts: maxAttempts}, nil
+}
+
+// generate fills in the template for product, taking a sequence number from nextSequence only
+// when the template numbers its SKUs
+func (g *SKUGenerator) generate(ctx context.Context, product *entity.Product, nextSequence func(context.Context) (int64, error)) (string, error) {
+	sku := strings.ReplaceAll(g.template, skuCategoryPrefix, categoryPrefix(product.Category))
+
+	if strings.Contains(sku, skuSequence) {
+		next, err := nextSequence(ctx)
+		if err != nil {
+			return "", err
+		}
+		sku = strings.ReplaceAll(sku, skuSequence, fmt.Sprintf("%06d", next))
+	}
+	for strings.Contains(sku, skuRandom) {
+		random, err := randomSKUPart(g.randomLength)
+		if err != nil {
+			return "", err
+		}
+		sku = strings.Replace(sku, skuRandom, random, 1)
+	}
+
+	return sku, nil
+}
+
+// categoryPrefix returns the first letters and digits of category in upper case
+func categoryPrefix(category string) string {
+	var prefix strings.Builder
+	for _, r := range category {
+		if prefix.Len() == skuCategoryPrefixLength {
+			break
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			prefix.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	if prefix.Len() == 0 {
+		return skuDefaultCategory
+	}
+	return prefix.String()
+}
+
+// randomSKUPart returns n random characters from skuRandomAlphabet
+func randomSKUPart(n int) (string, error) {
+	alphabetSize := big.NewInt(int64(len(skuRandomAlphabet)))
+	part := make([]byte, n)
+	for i := range part {
+		index, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random SKU: %w", err)
+		}
+		part[i] = skuRandomAlphabet[index.Int64()]
+	}
+	return string(part), nil
+}
+
+// assignGeneratedSKU gives a product without a SKU a generated one when SKU generation is enabled,
+// generating another while the SKU is already taken
+func (uc *ProductUseCase) assignGeneratedSKU(ctx context.Context, product *entity.Product) error {
+	if uc.skus == nil || product.SKU != nil {
+		return nil
+	}
+
+	for attempt := 0; attempt < uc.skus.maxAttempts; attempt++ {
+		sku, err := uc.skus.generate(ctx, product, uc.productRepo.NextSKUSequence)
+		if err != nil {
+			return err
+		}
+		product.SKU = &sku
+		if err := uc.checkSKUAvailable(ctx, product); !errors.Is(err, entity.ErrProductSKUExists) {
+			return err
+		}
+	}
+
+	product.SKU = nil
+	return fmt.Errorf("%w: no free SKU after %d attempts", entity.ErrProductSKUExists, uc.skus.maxAttempts)
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+)
+
+func TestNewSKUGeneratorValidatesTheTemplate(t *testing.T) {
+	tests := []struct {
+		template     string
+		randomLength int
+		valid        bool
+	}{
+		{"{CATEGORY_PREFIX}-{SEQUENCE}", 0, true},
+		{"P-{RANDOM}", 8, true},
+		{"{CATEGORY_PREFIX}-{SEQUENCE}-{RANDOM}", 4, true},
+		{"{CATEGORY_PREFIX}", 0, false},
+		{"FIXED", 0, false},
+		{"P-{RANDOM}", 3, false},
+		{"{CATEGORY}-{SEQUENCE}", 0, false},
+	}
+	for _, tt := range tests {
+		_, err := NewSKUGenerator(tt.template, tt.randomLength, 3)
+		if (err == nil) != tt.valid {
+			t.Errorf("%q with random length %d: got %v, want valid=%v", tt.template, tt.randomLength, err, tt.valid)
+		}
+	}
+}
+
+func TestCategoryPrefix(t *testing.T) {
+	tests := map[string]string{
+		"Lighting":    "LIG",
+		"tv":          "TV",
+		"3-D Prints":  "3DP",
+		"Éclairage":   "CLA",
+		"":            "GEN",
+		"--- !!! ---": "GEN",
+	}
+	for category, want := range tests {
+		if got := categoryPrefix(category); got != want {
+			t.Errorf("categoryPrefix(%q): got %q, want %q", category, got, want)
+		}
+	}
+}
+
+// newSKUProductUseCase returns a product use case generating SKUs from template, over products
+func newSKUProductUseCase(t *testing.T, template string, products *fakeProductRepo) *ProductUseCase {
+	t.Helper()
+	skus, err := NewSKUGenerator(template, 6, 3)
+	if err != nil {
+		t.Fatalf("NewSKUGenerator: %v", err)
+	}
+	return NewProductUseCase(products, nil, nil, nil, &fakeAuditRepo{}, nil, nil, fakeTxManager{}, ProductUseCaseOptions{ImageReportThreshold: 3, NamesPerCategory: true, SKUs: skus}, nopEventLogger{}, nil, nil)
+}
+
+func TestCreateProductGeneratesMissingSKUs(t *testing.T) {
+	uc := newSKUProductUseCase(t, "{CATEGORY_PREFIX}-{SEQUENCE}", newFakeProductRepo())
+
+	lamp, err := createInCategory(uc, "Desk Lamp", "Lighting")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	chair, err := createInCategory(uc, "Office Chair", "")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	if lamp.SKU == nil || *lamp.SKU != "LIG-000001" {
+		t.Errorf("got SKU %v, want LIG-000001", lamp.SKU)
+	}
+	if chair.SKU == nil || *chair.SKU != "GEN-000002" {
+		t.Errorf("got SKU %v, want GEN-000002", chair.SKU)
+	}
+}
+
+func TestCreateProductSkipsGeneratedSKUsThatAreTaken(t *testing.T) {
+	taken := "LIG-000001"
+	uc := newSKUProductUseCase(t, "{CATEGORY_PREFIX}-{SEQUENCE}", newFakeProductRepo(&entity.Product{Name: "Floor Lamp", SKU: &taken}))
+
+	product, err := createInCategory(uc, "Desk Lamp", "Lighting")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if product.SKU == nil || *product.SKU != "LIG-000002" {
+		t.Errorf("got SKU %v, want LIG-000002", product.SKU)
+	}
+}
+
+func TestCreateProductKeepsAGivenSKU(t *testing.T) {
+	products := newFakeProductRepo()
+	uc := newSKUProductUseCase(t, "P-{RANDOM}", products)
+
+	product, err := uc.CreateProduct(context.Background(), &CreateProductRequest{Name: "Desk Lamp", Price: 10, SKU: "CUSTOM-1"})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if product.SKU == nil || *product.SKU != "CUSTOM-1" {
+		t.Errorf("got SKU %v, want CUSTOM-1", product.SKU)
+	}
+
+	generated, err := createInCategory(uc, "Floor Lamp", "Lighting")
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if generated.SKU == nil || !regexp.MustCompile(`^P-[A-Z0-9]{6}$`).MatchString(*generated.SKU) {
+		t.Errorf("got SKU %v, want P- and 6 random characters", generated.SKU)
+	}
+}
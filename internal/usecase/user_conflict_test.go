@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newConflictAuthUseCase returns an auth use case with alice and bob registered as users 1 and 2
+func newConflictAuthUseCase() *AuthUseCase {
+	users := newFakeUserRepo(
+		&entity.User{Email: "alice@example.com", Username: "alice", IsActive: true},
+		&entity.User{Email: "bob@example.com", Username: "bob", IsActive: true},
+	)
+	return NewAuthUseCase(users, &fakeAuditRepo{}, nil, fakeTxManager{}, EmailVerification{}, PasswordReset{}, bcrypt.MinCost, PasswordPolicies{}, nil, nopEventLogger{}, ProfileLimits{}, nil)
+}
+
+func TestRegisterReportsWhichFieldIsTaken(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		username string
+		want     error
+	}{
+		{"email taken", "Alice@example.com", "someone", entity.ErrEmailAlreadyExists},
+		{"username taken", "carol@example.com", "ALICE", entity.ErrUsernameAlreadyExists},
+		{"both taken", "alice@example.com", "alice", entity.ErrEmailAlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newConflictAuthUseCase()
+			_, err := uc.Register(context.Background(), &service.RegisterRequest{Email: tt.email, Username: tt.username, Password: "correct horse battery"})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileReportsATakenUsername(t *testing.T) {
+	uc := newConflictAuthUseCase()
+	const bobID = 2
+
+	if _, err := uc.UpdateProfile(context.Background(), bobID, map[string]interface{}{"username": "Alice"}); !errors.Is(err, entity.ErrUsernameAlreadyExists) {
+		t.Errorf("got %v, want %v", err, entity.ErrUsernameAlreadyExists)
+	}
+	if _, err := uc.UpdateProfile(context.Background(), bobID, map[string]interface{}{"username": "BOB"}); err != nil {
+		t.Errorf("changing the casing of one's own username: %v", err)
+	}
+}
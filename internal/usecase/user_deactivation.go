@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// MaxBulkDeactivateUsers caps how many users a single bulk deactivation may name
+const MaxBulkDeactivateUsers = 100
+
+// BulkDeactivateUsers deactivates the users among ids in a single transaction, auditing each
+// one. Deactivated users' tokens stop being accepted immediately, since token validation
+// reloads the user. Unknown users, the acting user and admins whose deactivation would leave
+// no active admin are skipped and reported instead.
+func (uc *AuthUseCase) BulkDeactivateUsers(ctx context.Context, ids []uint) (*service.BulkDeactivateResult, error) {
+	if len(ids) == 0 {
+		return nil, entity.NewValidationError("user_ids", "min", entity.ErrUserIDsRequired)
+	}
+	if len(ids) > MaxBulkDeactivateUsers {
+		return nil, entity.NewValidationError("user_ids", "max", entity.ErrTooManyUserIDs)
+	}
+
+	// Drop duplicates so each ID is reported once
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	result := &service.BulkDeactivateResult{
+		Deactivated: []uint{},
+		Skipped:     []service.BulkDeactivateSkip{},
+	}
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		admins, err := uc.userRepo.GetAdminUsers(ctx)
+		if err != nil {
+			return err
+		}
+		activeAdmins := len(admins)
+
+		for _, id := range unique {
+			if rejectSelf(ctx, id) != nil {
+				result.Skipped = append(result.Skipped, service.BulkDeactivateSkip{ID: id, Reason: service.SkipReasonSelf})
+				continue
+			}
+
+			user, err := uc.userRepo.GetByID(ctx, id)
+			if err != nil {
+				if errors.Is(err, entity.ErrUserNotFound) {
+					result.Skipped = append(result.Skipped, service.BulkDeactivateSkip{ID: id, Reason: service.SkipReasonNotFound})
+					continue
+				}
+				return err
+			}
+
+			if !user.IsActive {
+				result.Skipped = append(result.Skipped, service.BulkDeactivateSkip{ID: id, Reason: service.SkipReasonAlreadyInactive})
+				continue
+			}
+
+			if user.IsAdmin {
+				if activeAdmins <= 1 {
+					result.Skipped = append(result.Skipped, service.BulkDeactivateSkip{ID: id, Reason: service.SkipReasonLastAdmin})
+					continue
+				}
+				activeAdmins--
+			}
+
+			user.IsActive = false
+			user.ClearVerificationToken()
+			if err := uc.userRepo.Update(ctx, user); err != nil {
+				return err
+			}
+
+			auditLog := newUserAuditLog(ctx, id, entity.AuditActionDeactivate, entity.AuditChanges{
+				"is_active": {Before: true, After: false},
+			})
+			if err := uc.auditRepo.Create(ctx, auditLog); err != nil {
+				return err
+			}
+
+			result.Deactivated = append(result.Deactivated, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range result.Deactivated {
+		logEvent(ctx, uc.events, service.EventUserDeactivated, entity.AuditEntityUser, id, nil)
+	}
+
+	result.Count = len(result.Deactivated)
+	return result, nil
+}
+
+// DeactivateUser stops a user from logging in while keeping them visible to admins. Their
+// tokens stop being accepted immediately. Deactivating an already inactive user is a no-op.
+func (uc *AuthUseCase) DeactivateUser(ctx context.Context, id uint) (*entity.User, error) {
+	if err := rejectSelf(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.setUserActive(ctx, id, false)
+}
+
+// ReactivateUser lets a deactivated user log in again. Reactivating an active user is a no-op.
+func (uc *AuthUseCase) ReactivateUser(ctx context.Context, id uint) (*entity.User, error) {
+	return uc.setUserActive(ctx, id, true)
+}
+
+// setUserActive sets a user's active flag, auditing and logging the change if there was one
+func (uc *AuthUseCase) setUserActive(ctx context.Context, id uint, active bool) (*entity.User, error) {
+	action, eventType := entity.AuditActionDeactivate, service.EventUserDeactivated
+	if active {
+		action, eventType = entity.AuditActionReactivate, service.EventUserReactivated
+	}
+
+	var user *entity.User
+	changed := false
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if user.IsActive == active {
+			return nil
+		}
+
+		user.IsActive = active
+		if !active {
+			// A pending verification link must not outlive the deactivation
+			user.ClearVerificationToken()
+		}
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		changed = true
+
+		return uc.auditRepo.Create(ctx, newUserAuditLog(ctx, id, action, entity.AuditChanges{
+			"is_active": {Before: !active, After: active},
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		logEvent(ctx, uc.events, eventType, entity.AuditEntityUser, id, nil)
+	}
+
+	return user, nil
+}
+
+// rejectSelf returns entity.ErrCannotModifySelf if id is the acting user, so an admin can't
+// lock themselves out
+func rejectSelf(ctx context.Context, id uint) error {
+	if actorID, ok := service.ActorIDFromContext(ctx); ok && actorID == id {
+		return entity.ErrCannotModifySelf
+	}
+	return nil
+}
+
+// newUserAuditLog builds an audit entry for a change to a user made by the actor in ctx
+func newUserAuditLog(ctx context.Context, id uint, action string, changes entity.AuditChanges) *entity.AuditLog {
+	auditLog := &entity.AuditLog{
+		EntityType: entity.AuditEntityUser,
+		EntityID:   id,
+		Action:     action,
+		Changes:    changes,
+	}
+	if actorID, ok := service.ActorIDFromContext(ctx); ok {
+		auditLog.ActorUserID = &actorID
+	}
+	return auditLog
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+func TestBulkDeactivateUsersSkipsTheCaller(t *testing.T) {
+	uc, users, _ := newVerificationAuthUseCase(t)
+	other := &entity.User{Email: "ops@example.com", Username: "ops", IsActive: true, IsAdmin: true, EmailVerified: true}
+	_ = users.Create(context.Background(), other)
+	adminCtx := service.WithActorID(context.Background(), adminID)
+
+	result, err := uc.BulkDeactivateUsers(adminCtx, []uint{adminID, other.ID})
+	if err != nil {
+		t.Fatalf("BulkDeactivateUsers: %v", err)
+	}
+
+	if len(result.Deactivated) != 1 || result.Deactivated[0] != other.ID {
+		t.Errorf("got deactivated %v, want only user %d", result.Deactivated, other.ID)
+	}
+	want := service.BulkDeactivateSkip{ID: adminID, Reason: service.SkipReasonSelf}
+	if len(result.Skipped) != 1 || result.Skipped[0] != want {
+		t.Errorf("got skipped %+v, want %+v", result.Skipped, want)
+	}
+	if caller, _ := users.GetByID(context.Background(), adminID); !caller.IsActive {
+		t.Error("the caller deactivated their own account")
+	}
+}
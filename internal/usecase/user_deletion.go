@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+// DeleteUser soft-deletes a user. The user disappears from listings and can no longer log in,
+// but can be brought back with RestoreUser.
+func (uc *AuthUseCase) DeleteUser(ctx context.Context, id uint) error {
+	if err := rejectSelf(ctx, id); err != nil {
+		return err
+	}
+
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.userRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+		if err := uc.userRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return uc.auditRepo.Create(ctx, newUserAuditLog(ctx, id, entity.AuditActionDelete, nil))
+	})
+	if err != nil {
+		return err
+	}
+	logEvent(ctx, uc.events, service.EventUserDeleted, entity.AuditEntityUser, id, nil)
+
+	return nil
+}
+
+// HardDeleteUser permanently deletes a user, whether live or already soft-deleted. This
+// cannot be undone; the user's audit trail is kept.
+func (uc *AuthUseCase) HardDeleteUser(ctx context.Context, id uint) error {
+	if err := rejectSelf(ctx, id); err != nil {
+		return err
+	}
+
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.userRepo.GetByID(ctx, id); err != nil {
+			if !errors.Is(err, entity.ErrUserNotFound) {
+				return err
+			}
+			if _, err := uc.userRepo.GetDeletedByID(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := uc.userRepo.HardDelete(ctx, id); err != nil {
+			return err
+		}
+		return uc.auditRepo.Create(ctx, newUserAuditLog(ctx, id, entity.AuditActionHardDelete, nil))
+	})
+	if err != nil {
+		return err
+	}
+	logEvent(ctx, uc.events, service.EventUserHardDeleted, entity.AuditEntityUser, id, nil)
+
+	return nil
+}
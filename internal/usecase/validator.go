@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/product-management/internal/domain/entity"
+)
+
+// validate checks request structs against their `validate` struct tags
+var validate = newValidator()
+
+// usernamePattern is the charset allowed in usernames by the "username" validation tag
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// newValidator creates a validator that reports fields by their JSON names
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterValidation("username", func(fl validator.FieldLevel) bool {
+		return usernamePattern.MatchString(fl.Field().String())
+	})
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+	return v
+}
+
+// validateStruct validates s field by field, returning an *entity.ValidationError
+// describing every failed rule
+func validateStruct(s interface{}) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	return TranslateValidationErrors(validationErrs)
+}
+
+// TranslateValidationErrors converts validator errors into an *entity.ValidationError
+func TranslateValidationErrors(errs validator.ValidationErrors) *entity.ValidationError {
+	validationErr := &entity.ValidationError{}
+	for _, fe := range errs {
+		validationErr.Violations = append(validationErr.Violations, entity.FieldViolation{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: violationMessage(fe),
+		})
+	}
+	return validationErr
+}
+
+// violationMessage builds a human-readable message for a failed rule
+func violationMessage(fe validator.FieldError) string {
+	return fieldViolationMessage(fe.Field(), fe)
+}
+
+// fieldViolationMessage builds a human-readable message for a failed rule on the named field,
+// for errors such as those of validate.Var that don't know the field's name
+func fieldViolationMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "min", "gte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at least %s characters", field, fe.Param())
+		}
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "max", "lte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at most %s characters", field, fe.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fe.Param())
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "username":
+		return fmt.Sprintf("%s may only contain letters, digits, underscores, dots and hyphens", field)
+	default:
+		return fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/service"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// newWebhookUseCase returns a webhook use case giving each delivery three attempts, with a
+// webhook subscribed to product.created and another to product.deleted
+func newWebhookUseCase(sender *fakeWebhookSender) (*WebhookUseCase, *fakeWebhookRepo) {
+	webhooks := newFakeWebhookRepo(
+		&entity.Webhook{URL: "https://example.com/created", Secret: testWebhookSecret, Events: entity.WebhookEvents{service.EventProductCreated}, IsActive: true},
+		&entity.Webhook{URL: "https://example.com/deleted", Secret: testWebhookSecret, Events: entity.WebhookEvents{service.EventProductDeleted}, IsActive: true},
+	)
+	policy := WebhookDeliveryPolicy{MaxAttempts: 3, RetryDelay: time.Minute, Timeout: time.Second}
+	return NewWebhookUseCase(webhooks, fakeTxManager{}, sender, policy, nil), webhooks
+}
+
+// queueCreatedEvent queues a product.created event for product 7
+func queueCreatedEvent(t *testing.T, uc *WebhookUseCase) {
+	t.Helper()
+	event := service.ProductEvent{ProductID: 7, Action: entity.AuditActionCreate, OccurredAt: time.Now().Add(-time.Second)}
+	if err := uc.HandleProductEvent(context.Background(), event); err != nil {
+		t.Fatalf("HandleProductEvent: %v", err)
+	}
+}
+
+// makeDue makes every pending delivery due again, as if its retry delay had passed
+func makeDue(repo *fakeWebhookRepo) {
+	for _, delivery := range repo.deliveries {
+		delivery.NextAttemptAt = time.Now().Add(-time.Second)
+	}
+}
+
+func TestHandleProductEventQueuesSubscribedWebhooksOnly(t *testing.T) {
+	uc, repo := newWebhookUseCase(&fakeWebhookSender{})
+	queueCreatedEvent(t, uc)
+
+	if len(repo.deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1 for the subscribed webhook", len(repo.deliveries))
+	}
+	delivery := repo.deliveries[0]
+	if delivery.WebhookID != 1 || delivery.EventType != service.EventProductCreated || delivery.Status != entity.WebhookDeliveryPending {
+		t.Errorf("got delivery %+v, want a pending product.created delivery to webhook 1", delivery)
+	}
+	var payload service.WebhookPayload
+	if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil || payload.ProductID != 7 {
+		t.Errorf("got payload %s, want one for product 7", delivery.Payload)
+	}
+}
+
+func TestDeliverDueRecordsSuccess(t *testing.T) {
+	uc, repo := newWebhookUseCase(&fakeWebhookSender{statuses: []int{204}})
+	queueCreatedEvent(t, uc)
+
+	if err := uc.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("DeliverDue: %v", err)
+	}
+	delivery := repo.deliveries[0]
+	if delivery.Status != entity.WebhookDeliverySucceeded || delivery.Attempts != 1 || delivery.DeliveredAt == nil {
+		t.Errorf("got status %s after %d attempts, want a single successful attempt", delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestDeliverDueRetriesUntilAttemptsRunOut(t *testing.T) {
+	sender := &fakeWebhookSender{statuses: []int{503}, err: errors.New("connection refused")}
+	uc, repo := newWebhookUseCase(sender)
+	queueCreatedEvent(t, uc)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if err := uc.DeliverDue(context.Background()); err != nil {
+			t.Fatalf("DeliverDue: %v", err)
+		}
+		delivery := repo.deliveries[0]
+		if delivery.Attempts != attempt {
+			t.Fatalf("got %d attempts, want %d", delivery.Attempts, attempt)
+		}
+		if attempt < 3 {
+			if delivery.Status != entity.WebhookDeliveryPending || !delivery.NextAttemptAt.After(time.Now()) {
+				t.Fatalf("attempt %d: got status %s due at %v, want a pending retry in the future", attempt, delivery.Status, delivery.NextAttemptAt)
+			}
+			// Not due yet, so nothing is sent
+			if err := uc.DeliverDue(context.Background()); err != nil || len(sender.sent) != attempt {
+				t.Fatalf("attempt %d: got %d sends (err %v) before the retry was due, want %d", attempt, len(sender.sent), err, attempt)
+			}
+			makeDue(repo)
+		}
+	}
+
+	delivery := repo.deliveries[0]
+	if delivery.Status != entity.WebhookDeliveryFailed || delivery.LastError != "connection refused" {
+		t.Errorf("got status %s with error %q, want failed with the last attempt's error", delivery.Status, delivery.LastError)
+	}
+}
+
+func TestDeliverDueGivesUpOnRejectedPayloads(t *testing.T) {
+	uc, repo := newWebhookUseCase(&fakeWebhookSender{statuses: []int{400}})
+	queueCreatedEvent(t, uc)
+
+	if err := uc.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("DeliverDue: %v", err)
+	}
+	delivery := repo.deliveries[0]
+	if delivery.Status != entity.WebhookDeliveryFailed || delivery.Attempts != 1 || delivery.ResponseStatus == nil || *delivery.ResponseStatus != 400 {
+		t.Errorf("got status %s after %d attempts, want failed after one attempt answered with 400", delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestRetryDelayDoublesUpToTheCap(t *testing.T) {
+	uc := &WebhookUseCase{policy: WebhookDeliveryPolicy{RetryDelay: time.Minute}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{20, webhookMaxRetryDelay},
+	}
+	for _, tt := range tests {
+		if got := uc.retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("after %d attempts: got %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
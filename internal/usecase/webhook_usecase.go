@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/product-management/internal/domain/entity"
+	"github.com/product-management/internal/domain/repository"
+	"github.com/product-management/internal/domain/service"
+)
+
+const (
+	// webhookDeliveryBatch is how many due deliveries a worker claims at a time
+	webhookDeliveryBatch = 10
+	// webhookDeliveryLogLimit is how many recent deliveries are listed for a webhook
+	webhookDeliveryLogLimit = 100
+	// webhookMaxRetryDelay caps the backoff between delivery attempts
+	webhookMaxRetryDelay = time.Hour
+	// maxDeliveryErrorLength keeps recorded delivery errors within their column
+	maxDeliveryErrorLength = 1000
+)
+
+// WebhookDeliveryPolicy configures how webhook deliveries are attempted
+type WebhookDeliveryPolicy struct {
+	MaxAttempts int           // attempts before a delivery is given up on
+	RetryDelay  time.Duration // wait before the first retry, doubling for each one after
+	Timeout     time.Duration // how long a single attempt may take
+}
+
+// WebhookUseCase handles webhook configuration and delivers product events to webhooks
+type WebhookUseCase struct {
+	webhookRepo repository.WebhookRepository
+	txManager   repository.TxManager
+	sender      service.WebhookSender
+	policy      WebhookDeliveryPolicy
+	// wake tells the delivery worker that new deliveries are waiting
+	wake   chan struct{}
+	logger *slog.Logger
+}
+
+// NewWebhookUseCase creates a new webhook use case sending deliveries with sender according to
+// policy and logging delivery failures to logger, or the default logger if it is nil
+func NewWebhookUseCase(webhookRepo repository.WebhookRepository, txManager repository.TxManager, sender service.WebhookSender, policy WebhookDeliveryPolicy, logger *slog.Logger) *WebhookUseCase {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &WebhookUseCase{
+		webhookRepo: webhookRepo,
+		txManager:   txManager,
+		sender:      sender,
+		policy:      policy,
+		wake:        make(chan struct{}, 1),
+		logger:      logger,
+	}
+}
+
+// webhookEventTypes are the event types webhooks can subscribe to
+var webhookEventTypes = func() map[string]bool {
+	types := make(map[string]bool, len(productEventTypes))
+	for _, eventType := range productEventTypes {
+		types[eventType] = true
+	}
+	return types
+}()
+
+// CreateWebhook creates a new webhook
+func (uc *WebhookUseCase) CreateWebhook(ctx context.Context, req *service.WebhookRequest) (*entity.Webhook, error) {
+	webhook := &entity.Webhook{}
+	if err := applyWebhookRequest(webhook, req); err != nil {
+		return nil, err
+	}
+	if err := uc.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook by its ID
+func (uc *WebhookUseCase) GetWebhook(ctx context.Context, id uint) (*entity.Webhook, error) {
+	return uc.webhookRepo.GetByID(ctx, id)
+}
+
+// ListWebhooks retrieves every webhook
+func (uc *WebhookUseCase) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	return uc.webhookRepo.GetAll(ctx)
+}
+
+// UpdateWebhook replaces a webhook's URL, events and active flag, and its secret if one is given
+func (uc *WebhookUseCase) UpdateWebhook(ctx context.Context, id uint, req *service.WebhookRequest) (*entity.Webhook, error) {
+	var webhook *entity.Webhook
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		webhook, err = uc.webhookRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := applyWebhookRequest(webhook, req); err != nil {
+			return err
+		}
+		return uc.webhookRepo.Update(ctx, webhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook along with its delivery log
+func (uc *WebhookUseCase) DeleteWebhook(ctx context.Context, id uint) error {
+	return uc.webhookRepo.Delete(ctx, id)
+}
+
+// ListDeliveries retrieves a webhook's most recent deliveries, newest first
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, id uint) ([]*entity.WebhookDelivery, error) {
+	if _, err := uc.webhookRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return uc.webhookRepo.GetDeliveries(ctx, id, webhookDeliveryLogLimit)
+}
+
+// applyWebhookRequest validates req and copies it onto webhook
+func applyWebhookRequest(webhook *entity.Webhook, req *service.WebhookRequest) error {
+	if err := validateStruct(req); err != nil {
+		return err
+	}
+
+	events := make(entity.WebhookEvents, 0, len(req.Events))
+	seen := make(map[string]bool, len(req.Events))
+	for _, event := range req.Events {
+		if !webhookEventTypes[event] {
+			return entity.NewValidationError("events", "oneof", fmt.Errorf("%w: %s", entity.ErrWebhookEventUnknown, event))
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	webhook.URL = req.URL
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	webhook.Events = events
+	webhook.IsActive = req.IsActive == nil || *req.IsActive
+
+	return webhook.Validate()
+}
+
+// HandleProductEvent queues a delivery of event to every active webhook subscribed to it and
+// wakes the delivery worker. It only records the deliveries, so it is cheap enough to run on
+// the request that raised the event.
+func (uc *WebhookUseCase) HandleProductEvent(ctx context.Context, event service.ProductEvent) error {
+	eventType, ok := productEventTypes[event.Action]
+	if !ok {
+		return nil
+	}
+
+	// Queue the deliveries even if the client goes away now that the change has been made
+	ctx = context.WithoutCancel(ctx)
+
+	webhooks, err := uc.webhookRepo.GetActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*entity.WebhookDelivery
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(eventType) {
+			continue
+		}
+		if payload == nil {
+			payload, err = json.Marshal(service.WebhookPayload{
+				Event:      eventType,
+				ProductID:  event.ProductID,
+				ActorID:    event.ActorID,
+				OccurredAt: event.OccurredAt,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode webhook payload: %w", err)
+			}
+		}
+		deliveries = append(deliveries, &entity.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventType:     eventType,
+			Payload:       string(payload),
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: event.OccurredAt,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := uc.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// RunDeliveryWorker sends due webhook deliveries as they are queued, and at least every
+// pollInterval to pick up retries, until ctx is canceled
+func (uc *WebhookUseCase) RunDeliveryWorker(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+
+		// Deliveries wait while read-only mode is on, which isn't worth a log line
+		if err := uc.DeliverDue(ctx); err != nil && ctx.Err() == nil && !errors.Is(err, entity.ErrReadOnlyMode) {
+			uc.logger.ErrorContext(ctx, "Failed to deliver webhooks", slog.Any("error", err))
+		}
+	}
+}
+
+// DeliverDue attempts every due webhook delivery, a batch at a time
+func (uc *WebhookUseCase) DeliverDue(ctx context.Context) error {
+	// A claimed batch is hidden from other workers for long enough to attempt all of it
+	lease := uc.policy.Timeout*webhookDeliveryBatch + time.Minute
+
+	for ctx.Err() == nil {
+		deliveries, err := uc.webhookRepo.ClaimDueDeliveries(ctx, time.Now(), lease, webhookDeliveryBatch)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		webhooks := make(map[uint]*entity.Webhook)
+		for _, delivery := range deliveries {
+			webhook, ok := webhooks[delivery.WebhookID]
+			if !ok {
+				webhook, err = uc.webhookRepo.GetByID(ctx, delivery.WebhookID)
+				if err != nil && !errors.Is(err, entity.ErrWebhookNotFound) {
+					return err
+				}
+				webhooks[delivery.WebhookID] = webhook
+			}
+			// The webhook was deleted since the batch was claimed, taking its deliveries with it
+			if webhook == nil {
+				continue
+			}
+			if err := uc.attempt(ctx, webhook, delivery); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// attempt sends delivery to webhook once and records the outcome, scheduling a retry with
+// exponential backoff when the endpoint was unreachable, overloaded or failing
+func (uc *WebhookUseCase) attempt(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) error {
+	now := time.Now()
+	if !webhook.IsActive {
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = "webhook is inactive"
+		return uc.webhookRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	status, err := uc.sender.Send(ctx, webhook, delivery)
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	delivery.LastError = ""
+	if err == nil {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case err == nil && status >= 200 && status < 300:
+		delivery.Status = entity.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+	case retryableDelivery(status, err) && delivery.Attempts < uc.policy.MaxAttempts:
+		delivery.NextAttemptAt = now.Add(uc.retryDelay(delivery.Attempts))
+		delivery.LastError = deliveryError(status, err)
+	default:
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = deliveryError(status, err)
+	}
+
+	return uc.webhookRepo.UpdateDelivery(ctx, delivery)
+}
+
+// retryableDelivery reports whether an attempt that got status, or failed with err, may
+// succeed if tried again. Other 4xx responses mean the endpoint rejected the payload.
+func retryableDelivery(status int, err error) bool {
+	return err != nil || status >= 500 || status == 429 || status == 408
+}
+
+// retryDelay returns the wait before the attempt after the given number of attempts
+func (uc *WebhookUseCase) retryDelay(attempts int) time.Duration {
+	delay := uc.policy.RetryDelay
+	for i := 1; i < attempts && delay < webhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookMaxRetryDelay)
+}
+
+// deliveryError describes why an attempt did not succeed
+func deliveryError(status int, err error) string {
+	message := fmt.Sprintf("endpoint responded with status %d", status)
+	if err != nil {
+		message = err.Error()
+	}
+	if len(message) > maxDeliveryErrorLength {
+		message = message[:maxDeliveryErrorLength]
+	}
+	return message
+}
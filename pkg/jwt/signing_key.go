@@ -0,0 +1,97 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256" // HMAC with a shared secret
+	AlgorithmRS256 = "RS256" // RSA, so tokens can be verified with only the public key
+)
+
+// ErrCannotSign is returned when generating a token with a key that can only verify
+var ErrCannotSign = errors.New("signing key has no private key, tokens can only be verified")
+
+// SigningKey is the algorithm tokens are signed with and the key material for it
+type SigningKey struct {
+	method    jwt.SigningMethod
+	signKey   interface{} // nil for a verify-only RSA key
+	verifyKey interface{}
+}
+
+// NewHMACKey creates an HS256 signing key from a shared secret
+func NewHMACKey(secret string) (SigningKey, error) {
+	if secret == "" {
+		return SigningKey{}, errors.New("HS256 requires a secret")
+	}
+	return SigningKey{method: jwt.SigningMethodHS256, signKey: []byte(secret), verifyKey: []byte(secret)}, nil
+}
+
+// NewRSAKey creates an RS256 signing key from PEM-encoded keys. The private key may be empty
+// for services that only verify tokens; the public key is derived from it when empty.
+func NewRSAKey(privatePEM, publicPEM []byte) (SigningKey, error) {
+	key := SigningKey{method: jwt.SigningMethodRS256}
+
+	if len(privatePEM) > 0 {
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return SigningKey{}, fmt.Errorf("invalid RSA private key: %w", err)
+		}
+		key.signKey, key.verifyKey = privateKey, &privateKey.PublicKey
+	}
+	if len(publicPEM) > 0 {
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return SigningKey{}, fmt.Errorf("invalid RSA public key: %w", err)
+		}
+		key.verifyKey = publicKey
+	}
+
+	if key.verifyKey == nil {
+		return SigningKey{}, errors.New("RS256 requires a private or public key")
+	}
+	return key, nil
+}
+
+// LoadSigningKey creates the signing key for algorithm: HS256 uses secret, RS256 reads PEM keys
+// from privateKeyPath and publicKeyPath, either of which may be empty (see NewRSAKey)
+func LoadSigningKey(algorithm, secret, privateKeyPath, publicKeyPath string) (SigningKey, error) {
+	switch algorithm {
+	case AlgorithmHS256:
+		return NewHMACKey(secret)
+	case AlgorithmRS256:
+		privatePEM, err := readKeyFile(privateKeyPath)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		publicPEM, err := readKeyFile(publicKeyPath)
+		if err != nil {
+			return SigningKey{}, err
+		}
+		return NewRSAKey(privatePEM, publicPEM)
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported JWT algorithm %q: must be %s or %s", algorithm, AlgorithmHS256, AlgorithmRS256)
+	}
+}
+
+// Algorithm returns the name of the key's signing algorithm
+func (k SigningKey) Algorithm() string {
+	return k.method.Alg()
+}
+
+// readKeyFile reads a PEM key file, returning nil when path is empty
+func readKeyFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return data, nil
+}
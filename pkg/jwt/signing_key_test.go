@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newRSAKeyPEMs returns a new RSA private key and its public key, PEM-encoded
+func newRSAKeyPEMs(t *testing.T) (privatePEM, publicPEM []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	privatePEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	return privatePEM, publicPEM
+}
+
+func newRSATokenManager(t *testing.T, privatePEM, publicPEM []byte) *TokenManager {
+	t.Helper()
+	key, err := NewRSAKey(privatePEM, publicPEM)
+	if err != nil {
+		t.Fatalf("NewRSAKey: %v", err)
+	}
+	return NewTokenManager(key, time.Hour, 24*time.Hour, 0, "", "")
+}
+
+func TestRS256TokensRoundTripAndVerifyWithThePublicKeyAlone(t *testing.T) {
+	privatePEM, publicPEM := newRSAKeyPEMs(t)
+	issuer := newRSATokenManager(t, privatePEM, nil)
+	verifier := newRSATokenManager(t, nil, publicPEM)
+
+	token, err := issuer.GenerateToken(7, "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	for name, tokens := range map[string]*TokenManager{"issuer": issuer, "verifier": verifier} {
+		claims, err := tokens.ValidateToken(token)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if claims.UserID != 7 || claims.Role != "user" {
+			t.Errorf("%s: got %+v, want user 7's claims", name, claims)
+		}
+	}
+
+	if _, err := verifier.GenerateToken(7, "user@example.com", "user"); !errors.Is(err, ErrCannotSign) {
+		t.Errorf("signing with a verify-only key: got %v, want %v", err, ErrCannotSign)
+	}
+}
+
+func TestRS256RejectsHS256Tokens(t *testing.T) {
+	privatePEM, publicPEM := newRSAKeyPEMs(t)
+	tokens := newRSATokenManager(t, privatePEM, nil)
+
+	claims := Claims{
+		UserID: 1,
+		Role:   "admin",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	// Signing with the public key as an HMAC secret is the classic algorithm confusion attack
+	for name, secret := range map[string][]byte{"public key as secret": publicPEM, "unrelated secret": []byte(testSecret)} {
+		forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		if _, err := tokens.ValidateToken(forged); err == nil {
+			t.Errorf("%s: an HS256 token was accepted by an RS256 manager", name)
+		}
+	}
+}
+
+func TestHS256RejectsRS256Tokens(t *testing.T) {
+	privatePEM, _ := newRSAKeyPEMs(t)
+	token, err := newRSATokenManager(t, privatePEM, nil).GenerateToken(1, "admin@example.com", "admin")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	if _, err := newHMACTokenManager(t, 0).ValidateToken(token); err == nil {
+		t.Error("an RS256 token was accepted by an HS256 manager")
+	}
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	privatePEM, publicPEM := newRSAKeyPEMs(t)
+	dir := t.TempDir()
+	privatePath, publicPath := filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem")
+	if err := os.WriteFile(privatePath, privatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(publicPath, publicPEM, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                       string
+		algorithm, secret          string
+		privateKeyPath, publicPath string
+		valid                      bool
+	}{
+		{"HS256", AlgorithmHS256, testSecret, "", "", true},
+		{"HS256 without a secret", AlgorithmHS256, "", "", "", false},
+		{"RS256 key pair", AlgorithmRS256, "", privatePath, publicPath, true},
+		{"RS256 public key only", AlgorithmRS256, "", "", publicPath, true},
+		{"RS256 without keys", AlgorithmRS256, "", "", "", false},
+		{"RS256 missing key file", AlgorithmRS256, "", filepath.Join(dir, "missing.pem"), "", false},
+		{"RS256 public key as the private key", AlgorithmRS256, "", publicPath, "", false},
+		{"unsupported algorithm", "none", testSecret, "", "", false},
+	}
+	for _, tt := range tests {
+		key, err := LoadSigningKey(tt.algorithm, tt.secret, tt.privateKeyPath, tt.publicPath)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: got %v, want valid=%v", tt.name, err, tt.valid)
+		}
+		if err == nil && key.Algorithm() != tt.algorithm {
+			t.Errorf("%s: got algorithm %s, want %s", tt.name, key.Algorithm(), tt.algorithm)
+		}
+	}
+}
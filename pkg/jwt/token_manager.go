@@ -1,59 +1,129 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Token types, so a refresh token can't be used as an access token and vice versa
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 // Claims represents the JWT claims
 type Claims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	Type   string `json:"typ,omitempty"` // empty on tokens issued before types were added, which are access tokens
 	jwt.RegisteredClaims
 }
 
+// IsRefresh reports whether the claims belong to a refresh token
+func (c *Claims) IsRefresh() bool {
+	return c.Type == TokenTypeRefresh
+}
+
 // TokenManager handles JWT token operations
 type TokenManager struct {
-	secret     string
-	expiresIn  time.Duration
+	key              SigningKey
+	expiresIn        time.Duration
+	refreshExpiresIn time.Duration
+	leeway           time.Duration
+	issuer           string
+	audience         string
 }
 
-// NewTokenManager creates a new token manager
-func NewTokenManager(secret string, expiresIn time.Duration) *TokenManager {
+// NewTokenManager creates a new token manager signing and verifying tokens with key. Leeway is
+// the clock skew tolerated when checking a token's exp, nbf and iat times. Tokens are issued
+// with the given iss and aud claims and only accepted when they carry them, so tokens minted
+// for another service sharing the key can't be used here; an empty issuer or audience leaves
+// that claim out.
+func NewTokenManager(key SigningKey, expiresIn, refreshExpiresIn, leeway time.Duration, issuer, audience string) *TokenManager {
 	return &TokenManager{
-		secret:    secret,
-		expiresIn: expiresIn,
+		key:              key,
+		expiresIn:        expiresIn,
+		refreshExpiresIn: refreshExpiresIn,
+		leeway:           leeway,
+		issuer:           issuer,
+		audience:         audience,
 	}
 }
 
-// GenerateToken generates a new JWT token
+// ExpiresIn returns how long access tokens are valid for
+func (tm *TokenManager) ExpiresIn() time.Duration {
+	return tm.expiresIn
+}
+
+// GenerateToken generates a new JWT access token
 func (tm *TokenManager) GenerateToken(userID uint, email, role string) (string, error) {
+	return tm.generate(userID, email, role, TokenTypeAccess, tm.expiresIn)
+}
+
+// GenerateRefreshToken generates a new JWT refresh token, which can only be exchanged for
+// new tokens
+func (tm *TokenManager) GenerateRefreshToken(userID uint, email, role string) (string, error) {
+	return tm.generate(userID, email, role, TokenTypeRefresh, tm.refreshExpiresIn)
+}
+
+// generate signs a token of the given type that is valid for ttl. Each token gets a random ID
+// so it can be revoked on its own.
+func (tm *TokenManager) generate(userID uint, email, role, tokenType string, ttl time.Duration) (string, error) {
+	if tm.key.signKey == nil {
+		return "", ErrCannotSign
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	now := time.Now()
 	claims := Claims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
+		Type:   tokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tm.expiresIn)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        hex.EncodeToString(id),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    tm.issuer,
 		},
 	}
+	if tm.audience != "" {
+		claims.Audience = jwt.ClaimStrings{tm.audience}
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(tm.secret))
+	token := jwt.NewWithClaims(tm.key.method, claims)
+	return token.SignedString(tm.key.signKey)
 }
 
-// ValidateToken validates a JWT token and returns the claims
+// ValidateToken validates a JWT token and returns the claims. Only tokens signed with the
+// configured algorithm are accepted, so "none" and algorithm-confusion tokens are rejected,
+// and tokens from another issuer or for another audience are rejected too.
 func (tm *TokenManager) ValidateToken(tokenString string) (*Claims, error) {
+	algorithm := tm.key.Algorithm()
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{algorithm}), jwt.WithLeeway(tm.leeway), jwt.WithIssuedAt()}
+	if tm.issuer != "" {
+		options = append(options, jwt.WithIssuer(tm.issuer))
+	}
+	if tm.audience != "" {
+		options = append(options, jwt.WithAudience(tm.audience))
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if token.Method.Alg() != algorithm {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(tm.secret), nil
-	})
+		return tm.key.verifyKey, nil
+	}, options...)
 
 	if err != nil {
 		return nil, err
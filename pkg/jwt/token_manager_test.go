@@ -0,0 +1,107 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret-that-is-long-enough-for-hs256"
+
+func newHMACTokenManager(t *testing.T, leeway time.Duration) *TokenManager {
+	t.Helper()
+	key, err := NewHMACKey(testSecret)
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	return NewTokenManager(key, time.Hour, 24*time.Hour, leeway, "", "")
+}
+
+// signAt signs an access token with the test secret that becomes valid, and claims to have
+// been issued, at notBefore
+func signAt(t *testing.T, notBefore time.Time) string {
+	t.Helper()
+	claims := Claims{
+		UserID: 1,
+		Role:   "user",
+		Type:   TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(notBefore.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(notBefore),
+			NotBefore: jwt.NewNumericDate(notBefore),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func TestValidateTokenToleratesClockSkewWithinTheLeeway(t *testing.T) {
+	tokens := newHMACTokenManager(t, 5*time.Second)
+
+	if _, err := tokens.ValidateToken(signAt(t, time.Now().Add(3*time.Second))); err != nil {
+		t.Errorf("token from a clock 3s ahead: %v, want it accepted", err)
+	}
+	if _, err := tokens.ValidateToken(signAt(t, time.Now().Add(10*time.Second))); err == nil {
+		t.Error("token from a clock 10s ahead was accepted, want it rejected")
+	}
+}
+
+func TestValidateTokenWithoutLeewayRejectsFutureTokens(t *testing.T) {
+	tokens := newHMACTokenManager(t, 0)
+
+	if _, err := tokens.ValidateToken(signAt(t, time.Now().Add(3*time.Second))); err == nil {
+		t.Error("token from a clock 3s ahead was accepted, want it rejected")
+	}
+}
+
+func TestValidateTokenAcceptsRecentlyExpiredTokensWithinTheLeeway(t *testing.T) {
+	tokens := newHMACTokenManager(t, 5*time.Second)
+
+	// Valid from two hours ago, so it expired an hour ago
+	if _, err := tokens.ValidateToken(signAt(t, time.Now().Add(-2*time.Hour))); err == nil {
+		t.Error("token expired an hour ago was accepted, want it rejected")
+	}
+	// Expired 2s ago
+	if _, err := tokens.ValidateToken(signAt(t, time.Now().Add(-time.Hour-2*time.Second))); err != nil {
+		t.Errorf("token expired 2s ago: %v, want it accepted", err)
+	}
+}
+
+func TestValidateTokenRequiresTheConfiguredIssuerAndAudience(t *testing.T) {
+	key, err := NewHMACKey(testSecret)
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	products := NewTokenManager(key, time.Hour, 24*time.Hour, 0, "auth.example.com", "products")
+	orders := NewTokenManager(key, time.Hour, 24*time.Hour, 0, "auth.example.com", "orders")
+	otherIssuer := NewTokenManager(key, time.Hour, 24*time.Hour, 0, "elsewhere.example.com", "products")
+	unscoped := NewTokenManager(key, time.Hour, 24*time.Hour, 0, "", "")
+
+	token, err := products.GenerateToken(1, "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	claims, err := products.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if claims.Issuer != "auth.example.com" || len(claims.Audience) != 1 || claims.Audience[0] != "products" {
+		t.Errorf("got iss %q and aud %q, want auth.example.com and products", claims.Issuer, claims.Audience)
+	}
+
+	for name, tokens := range map[string]*TokenManager{"another audience": orders, "another issuer": otherIssuer} {
+		if _, err := tokens.ValidateToken(token); err == nil {
+			t.Errorf("%s: the token was accepted", name)
+		}
+	}
+
+	// Tokens without iss and aud are refused once they are required
+	bare, _ := unscoped.GenerateToken(1, "user@example.com", "user")
+	if _, err := products.ValidateToken(bare); err == nil {
+		t.Error("a token without iss and aud was accepted")
+	}
+}
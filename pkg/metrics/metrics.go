@@ -0,0 +1,64 @@
+// Package metrics defines the Prometheus collectors exposed by the service
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// HTTP metrics. Routes are labeled by their template (e.g. /api/v1/products/:id)
+// rather than the raw path to keep label cardinality bounded.
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served.",
+	}, []string{"method", "route"})
+)
+
+// Business metrics
+var (
+	ProductsCreatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "products_created_total",
+		Help: "Total number of products created.",
+	})
+
+	ProductsDeletedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "products_deleted_total",
+		Help: "Total number of products deleted.",
+	})
+
+	ReservationsReleasedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "reservations_released_total",
+		Help: "Total number of stale reservations released back to stock.",
+	})
+
+	ReservationReleaseFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "reservation_release_failures_total",
+		Help: "Total number of stale reservations that failed to be released.",
+	})
+
+	ReservationsReleasedPerRun = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "reservations_released_per_run",
+		Help:    "Number of stale reservations released by each run of the release job.",
+		Buckets: []float64{0, 1, 5, 10, 50, 100, 500, 1000},
+	})
+)
+
+// Handler returns the HTTP handler serving metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,9 @@
+// Package version exposes build information, set at link time with
+// -ldflags "-X github.com/product-management/pkg/version.Version=... -X github.com/product-management/pkg/version.Commit=..."
+package version
+
+// Version is the release version of the build
+var Version = "dev"
+
+// Commit is the VCS revision the build was made from
+var Commit = "unknown"